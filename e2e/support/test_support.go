@@ -2695,10 +2695,12 @@ func CreateKamelet(t *testing.T, operatorID string, ctx context.Context, ns stri
 				Labels:    labels,
 			},
 			Spec: v1.KameletSpec{
-				Definition: &v1.JSONSchemaProps{
-					Properties: properties,
+				KameletSpecBase: v1.KameletSpecBase{
+					Definition: &v1.JSONSchemaProps{
+						Properties: properties,
+					},
+					Template: asTemplate(t, template),
 				},
-				Template: asTemplate(t, template),
 			},
 		}
 
@@ -48,6 +48,11 @@ type BuildSpec struct {
 	// the maximum amount of parallel running builds started by this operator instance
 	// Deprecated: no longer in use in Camel K 2 - maintained for backward compatibility
 	MaxRunningBuilds int32 `json:"maxRunningBuilds,omitempty"`
+	// The priority of the Build. The builds with a higher priority are scheduled first.
+	Priority int32 `json:"priority,omitempty"`
+	// Cancel requests the cancellation of the Build. A cancelled Build is stopped,
+	// moved to the Cancelled phase and never retried.
+	Cancel bool `json:"cancel,omitempty"`
 }
 
 // Task represents the abstract task. Only one of the task should be configured to represent the specific task chosen.
@@ -226,6 +231,16 @@ type BuildStatus struct {
 	// Change to Duration / ISO 8601 when CRD uses OpenAPI spec v3
 	// https://github.com/OAI/OpenAPI-Specification/issues/845
 	Duration string `json:"duration,omitempty"`
+	// the usage of the Maven cache by the build (if any)
+	MavenCache *MavenCacheStatus `json:"mavenCache,omitempty"`
+}
+
+// MavenCacheStatus reports how the build used the persistent Maven cache.
+type MavenCacheStatus struct {
+	// the number of artifacts resolved from the cache
+	Hits int32 `json:"hits,omitempty"`
+	// the number of artifacts downloaded from the remote repositories
+	Misses int32 `json:"misses,omitempty"`
 }
 
 // BuildPhase -- .
@@ -256,6 +271,23 @@ const (
 	BuildPhaseInterrupted = "Interrupted"
 	// BuildPhaseError -- .
 	BuildPhaseError BuildPhase = "Error"
+	// BuildPhaseCancelled -- .
+	BuildPhaseCancelled BuildPhase = "Cancelled"
+
+	// BuildFailureCodeUnknown -- .
+	BuildFailureCodeUnknown = "Unknown"
+	// BuildFailureCodeRegistryUnavailable -- .
+	BuildFailureCodeRegistryUnavailable = "RegistryUnavailable"
+	// BuildFailureCodeNetworkError -- .
+	BuildFailureCodeNetworkError = "NetworkError"
+	// BuildFailureCodeOutOfMemory -- .
+	BuildFailureCodeOutOfMemory = "OutOfMemory"
+	// BuildFailureCodeCompilationError -- .
+	BuildFailureCodeCompilationError = "CompilationError"
+	// BuildFailureCodeUnresolvableDependency -- .
+	BuildFailureCodeUnresolvableDependency = "UnresolvableDependency"
+	// BuildFailureCodeInvalidSource -- .
+	BuildFailureCodeInvalidSource = "InvalidSource"
 
 	// BuildConditionScheduled --.
 	BuildConditionScheduled BuildConditionType = "Scheduled"
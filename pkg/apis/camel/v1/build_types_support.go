@@ -141,7 +141,7 @@ func (in *BuildStatus) Failed(err error) BuildStatus {
 
 func (in *BuildStatus) IsFinished() bool {
 	return in.Phase == BuildPhaseSucceeded || in.Phase == BuildPhaseFailed ||
-		in.Phase == BuildPhaseInterrupted || in.Phase == BuildPhaseError
+		in.Phase == BuildPhaseInterrupted || in.Phase == BuildPhaseError || in.Phase == BuildPhaseCancelled
 }
 
 func (in *BuildStatus) SetCondition(condType BuildConditionType, status corev1.ConditionStatus, reason string, message string) {
@@ -250,6 +250,8 @@ func (bl BuildList) HasRunningBuilds() bool {
 	return false
 }
 
+// HasScheduledBuildsBefore visit all items in the list of builds and search for a scheduled build that takes precedence
+// over the given build, i.e., that has a higher priority, or the same priority and has been created before.
 func (bl BuildList) HasScheduledBuildsBefore(build *Build) (bool, *Build) {
 	for _, b := range bl.Items {
 		if b.Name == build.Name {
@@ -257,7 +259,8 @@ func (bl BuildList) HasScheduledBuildsBefore(build *Build) (bool, *Build) {
 		}
 
 		if (b.Status.Phase == BuildPhaseInitialization || b.Status.Phase == BuildPhaseScheduling) &&
-			b.CreationTimestamp.Before(&build.CreationTimestamp) {
+			(b.Spec.Priority > build.Spec.Priority ||
+				b.Spec.Priority == build.Spec.Priority && b.CreationTimestamp.Before(&build.CreationTimestamp)) {
 			return true, &b
 		}
 	}
@@ -109,7 +109,7 @@ var BuildOrderStrategies = []BuildOrderStrategy{
 
 // KameletRepositorySpec defines the location of the Kamelet catalog to use.
 type KameletRepositorySpec struct {
-	// the remote repository in the format github:ORG/REPO/PATH_TO_KAMELETS_FOLDER
+	// the repository of Kamelets, in the format github:ORG/REPO[/PATH_TO_KAMELETS_FOLDER][@VERSION], dir:/PATH_TO_KAMELETS_FOLDER[@GIT_REF], http[s]://HOST/PATH_TO_INDEX_OR_BUNDLE, or mvn:GROUP_ID:ARTIFACT_ID:VERSION
 	URI string `json:"uri,omitempty"`
 }
 
@@ -141,8 +141,22 @@ type Failure struct {
 	Time metav1.Time `json:"time"`
 	// the recovery attempted for this failure
 	Recovery FailureRecovery `json:"recovery"`
+	// the category of the failure, that tells whether it can be recovered
+	Category FailureCategory `json:"category,omitempty"`
+	// a code identifying the cause of the failure
+	Code string `json:"code,omitempty"`
 }
 
+// FailureCategory tells whether a failure can be recovered by retrying.
+type FailureCategory string
+
+const (
+	// FailureCategoryTransient a failure that may not happen again when retrying, ie, a network timeout.
+	FailureCategoryTransient FailureCategory = "Transient"
+	// FailureCategoryPermanent a failure that happens again when retrying, ie, a compilation error.
+	FailureCategoryPermanent FailureCategory = "Permanent"
+)
+
 // FailureRecovery defines the attempts to recover a failure.
 type FailureRecovery struct {
 	// attempt number
@@ -193,10 +207,14 @@ type Traits struct {
 	Environment *trait.EnvironmentTrait `property:"environment" json:"environment,omitempty"`
 	// The configuration of Error Handler trait
 	ErrorHandler *trait.ErrorHandlerTrait `property:"error-handler" json:"error-handler,omitempty"`
+	// The configuration of Gateway trait
+	Gateway *trait.GatewayTrait `property:"gateway" json:"gateway,omitempty"`
 	// The configuration of GC trait
 	GC *trait.GCTrait `property:"gc" json:"gc,omitempty"`
 	// The configuration of Health trait
 	Health *trait.HealthTrait `property:"health" json:"health,omitempty"`
+	// The configuration of HPA trait
+	HPA *trait.HPATrait `property:"hpa" json:"hpa,omitempty"`
 	// The configuration of Ingress trait
 	Ingress *trait.IngressTrait `property:"ingress" json:"ingress,omitempty"`
 	// The configuration of Istio trait
@@ -215,6 +233,8 @@ type Traits struct {
 	Logging *trait.LoggingTrait `property:"logging" json:"logging,omitempty"`
 	// The configuration of Mount trait
 	Mount *trait.MountTrait `property:"mount" json:"mount,omitempty"`
+	// The configuration of NetworkPolicy trait
+	NetworkPolicy *trait.NetworkPolicyTrait `property:"network-policy" json:"network-policy,omitempty"`
 	// The configuration of OpenAPI trait
 	OpenAPI *trait.OpenAPITrait `property:"openapi" json:"openapi,omitempty"`
 	// The configuration of Owner trait
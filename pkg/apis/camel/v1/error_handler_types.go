@@ -24,6 +24,14 @@ const (
 	ErrorHandlerRefDefaultName = "defaultErrorHandler"
 	// ErrorHandlerAppPropertiesPrefix the prefix used for the error handler bean.
 	ErrorHandlerAppPropertiesPrefix = "camel.beans.defaultErrorHandler"
+	// ErrorHandlerRetryWhileRefName the name of the predicate bean controlling the redeliveries.
+	ErrorHandlerRetryWhileRefName = "errorHandlerRetryWhile"
+	// ErrorHandlerRetryWhileAppPropertiesPrefix the prefix used for the retry while predicate bean.
+	ErrorHandlerRetryWhileAppPropertiesPrefix = "camel.beans." + ErrorHandlerRetryWhileRefName
+	// ErrorHandlerCircuitBreakerRefName the name of the route policy bean acting as a circuit breaker.
+	ErrorHandlerCircuitBreakerRefName = "errorHandlerCircuitBreaker"
+	// ErrorHandlerCircuitBreakerAppPropertiesPrefix the prefix used for the circuit breaker route policy bean.
+	ErrorHandlerCircuitBreakerAppPropertiesPrefix = "camel.beans." + ErrorHandlerCircuitBreakerRefName
 )
 
 // ErrorHandlerSpec represents an unstructured object for an error handler.
@@ -36,6 +44,30 @@ type ErrorHandlerParameters struct {
 	RawMessage `json:",inline,omitempty"`
 }
 
+// ErrorHandlerRedelivery defines how a failing event is redelivered before the error handler gives up.
+type ErrorHandlerRedelivery struct {
+	// The maximum number of redelivery attempts, -1 to redeliver forever
+	MaximumRedeliveries *int `json:"maximumRedeliveries,omitempty"`
+	// The delay in milliseconds before the first redelivery attempt
+	RedeliveryDelay *int64 `json:"redeliveryDelay,omitempty"`
+	// The multiplier applied to the delay after each attempt, enabling the exponential back-off
+	BackOffMultiplier *float64 `json:"backOffMultiplier,omitempty"`
+	// The predicate the failing event must match to be redelivered
+	RetryWhile *PipePredicate `json:"retryWhile,omitempty"`
+	// Log the failing event once the redeliveries are exhausted only, instead of logging each attempt
+	LogExhaustedOnly *bool `json:"logExhaustedOnly,omitempty"`
+}
+
+// ErrorHandlerCircuitBreaker stops consuming events from the source when too many of them fail.
+type ErrorHandlerCircuitBreaker struct {
+	// The number of failures opening the circuit
+	FailureThreshold int `json:"failureThreshold"`
+	// The window in milliseconds in which the failures are counted (default 60000)
+	FailureWindow *int64 `json:"failureWindow,omitempty"`
+	// The time in milliseconds after which the circuit is half opened to try consuming again (default 30000)
+	HalfOpenAfter *int64 `json:"halfOpenAfter,omitempty"`
+}
+
 // BeanProperties represent an unstructured object properties to be set on a bean.
 type BeanProperties struct {
 	RawMessage `json:",inline,omitempty"`
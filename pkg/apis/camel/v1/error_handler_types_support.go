@@ -59,6 +59,7 @@ func (e baseErrorHandler) Validate() error {
 // ErrorHandlerNone --.
 type ErrorHandlerNone struct {
 	baseErrorHandler
+	CircuitBreaker *ErrorHandlerCircuitBreaker `json:"circuitBreaker,omitempty"`
 }
 
 // Type --.
@@ -68,16 +69,30 @@ func (e ErrorHandlerNone) Type() ErrorHandlerType {
 
 // Configuration --.
 func (e ErrorHandlerNone) Configuration() (map[string]interface{}, error) {
-	return map[string]interface{}{
+	properties := map[string]interface{}{
 		ErrorHandlerAppPropertiesPrefix: "#class:org.apache.camel.builder.NoErrorHandlerBuilder",
 		ErrorHandlerRefName:             ErrorHandlerRefDefaultName,
-	}, nil
+	}
+	if e.CircuitBreaker != nil {
+		e.CircuitBreaker.configure(properties)
+	}
+
+	return properties, nil
+}
+
+// Validate --.
+func (e ErrorHandlerNone) Validate() error {
+	if e.CircuitBreaker != nil {
+		return e.CircuitBreaker.validate()
+	}
+	return nil
 }
 
 // ErrorHandlerLog represent a default (log) error handler type.
 type ErrorHandlerLog struct {
 	ErrorHandlerNone
 	Parameters *ErrorHandlerParameters `json:"parameters,omitempty"`
+	Redelivery *ErrorHandlerRedelivery `json:"redelivery,omitempty"`
 }
 
 // Type --.
@@ -103,10 +118,25 @@ func (e ErrorHandlerLog) Configuration() (map[string]interface{}, error) {
 			properties[ErrorHandlerAppPropertiesPrefix+"."+key] = value
 		}
 	}
+	// the redelivery policy has precedence over the unstructured parameters
+	if e.Redelivery != nil {
+		e.Redelivery.configure(properties)
+	}
 
 	return properties, nil
 }
 
+// Validate --.
+func (e ErrorHandlerLog) Validate() error {
+	if err := e.ErrorHandlerNone.Validate(); err != nil {
+		return err
+	}
+	if e.Redelivery != nil {
+		return e.Redelivery.validate()
+	}
+	return nil
+}
+
 // ErrorHandlerSink represents a sink error handler type which behave like a dead letter channel.
 type ErrorHandlerSink struct {
 	ErrorHandlerLog
@@ -139,5 +169,93 @@ func (e ErrorHandlerSink) Validate() error {
 	if e.DLCEndpoint == nil {
 		return fmt.Errorf("missing endpoint in Error Handler Sink")
 	}
+	return e.ErrorHandlerLog.Validate()
+}
+
+// configure sets the redelivery policy on the error handler bean.
+func (r ErrorHandlerRedelivery) configure(properties map[string]interface{}) {
+	if r.MaximumRedeliveries != nil {
+		properties[ErrorHandlerAppPropertiesPrefix+".maximumRedeliveries"] = *r.MaximumRedeliveries
+	}
+	if r.RedeliveryDelay != nil {
+		properties[ErrorHandlerAppPropertiesPrefix+".redeliveryDelay"] = *r.RedeliveryDelay
+	}
+	if r.BackOffMultiplier != nil {
+		properties[ErrorHandlerAppPropertiesPrefix+".useExponentialBackOff"] = *r.BackOffMultiplier > 1
+		properties[ErrorHandlerAppPropertiesPrefix+".backOffMultiplier"] = *r.BackOffMultiplier
+	}
+	if r.RetryWhile != nil {
+		language := r.RetryWhile.Language
+		if language == "" {
+			language = PipePredicateLanguageSimple
+		}
+		properties[ErrorHandlerRetryWhileAppPropertiesPrefix] = "#class:" + predicateClasses[language]
+		properties[ErrorHandlerRetryWhileAppPropertiesPrefix+".expression"] = r.RetryWhile.Expression
+		properties[ErrorHandlerAppPropertiesPrefix+".retryWhile"] = "#bean:" + ErrorHandlerRetryWhileRefName
+	}
+	if r.LogExhaustedOnly != nil {
+		properties[ErrorHandlerAppPropertiesPrefix+".logRetryAttempted"] = !*r.LogExhaustedOnly
+		properties[ErrorHandlerAppPropertiesPrefix+".logExhausted"] = true
+	}
+}
+
+func (r ErrorHandlerRedelivery) validate() error {
+	if r.MaximumRedeliveries != nil && *r.MaximumRedeliveries < -1 {
+		return fmt.Errorf("invalid maximum redeliveries %d: must be -1 (forever) or greater", *r.MaximumRedeliveries)
+	}
+	if r.RedeliveryDelay != nil && *r.RedeliveryDelay < 0 {
+		return fmt.Errorf("invalid redelivery delay %d: must not be negative", *r.RedeliveryDelay)
+	}
+	if r.BackOffMultiplier != nil && *r.BackOffMultiplier < 1 {
+		return fmt.Errorf("invalid back-off multiplier %v: must be 1 or greater", *r.BackOffMultiplier)
+	}
+	if r.RetryWhile != nil {
+		if r.RetryWhile.Expression == "" {
+			return fmt.Errorf("missing retry while expression")
+		}
+		if _, ok := predicateClasses[r.RetryWhile.Language]; !ok && r.RetryWhile.Language != "" {
+			return fmt.Errorf("unsupported retry while language %s", r.RetryWhile.Language)
+		}
+	}
+	return nil
+}
+
+// predicateClasses maps the predicate languages to the Camel expression definitions evaluating them.
+var predicateClasses = map[PipePredicateLanguage]string{
+	PipePredicateLanguageSimple:   "org.apache.camel.model.language.SimpleExpression",
+	PipePredicateLanguageJSONPath: "org.apache.camel.model.language.JsonPathExpression",
+}
+
+const (
+	defaultCircuitBreakerFailureWindow int64 = 60000
+	defaultCircuitBreakerHalfOpenAfter int64 = 30000
+)
+
+// configure declares the route policy bean acting as a circuit breaker.
+func (c ErrorHandlerCircuitBreaker) configure(properties map[string]interface{}) {
+	failureWindow := defaultCircuitBreakerFailureWindow
+	if c.FailureWindow != nil {
+		failureWindow = *c.FailureWindow
+	}
+	halfOpenAfter := defaultCircuitBreakerHalfOpenAfter
+	if c.HalfOpenAfter != nil {
+		halfOpenAfter = *c.HalfOpenAfter
+	}
+	properties[ErrorHandlerCircuitBreakerAppPropertiesPrefix] = "#class:org.apache.camel.throttling.ThrottlingExceptionRoutePolicy"
+	properties[ErrorHandlerCircuitBreakerAppPropertiesPrefix+".threshold"] = c.FailureThreshold
+	properties[ErrorHandlerCircuitBreakerAppPropertiesPrefix+".failureWindow"] = failureWindow
+	properties[ErrorHandlerCircuitBreakerAppPropertiesPrefix+".halfOpenAfter"] = halfOpenAfter
+}
+
+func (c ErrorHandlerCircuitBreaker) validate() error {
+	if c.FailureThreshold < 1 {
+		return fmt.Errorf("invalid circuit breaker failure threshold %d: must be 1 or greater", c.FailureThreshold)
+	}
+	if c.FailureWindow != nil && *c.FailureWindow < 1 {
+		return fmt.Errorf("invalid circuit breaker failure window %d: must be 1 or greater", *c.FailureWindow)
+	}
+	if c.HalfOpenAfter != nil && *c.HalfOpenAfter < 1 {
+		return fmt.Errorf("invalid circuit breaker half open delay %d: must be 1 or greater", *c.HalfOpenAfter)
+	}
 	return nil
 }
@@ -57,6 +57,8 @@ type IntegrationSpec struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 	// the sources which contain the Camel routes to run
 	Sources []SourceSpec `json:"sources,omitempty"`
+	// the git repository the operator checks the sources out from
+	Git *GitSourceSpec `json:"git,omitempty"`
 	// a source in YAML DSL language which contain the routes to run
 	Flows []Flow `json:"flows,omitempty"`
 	// the reference of the `IntegrationKit` which is used for this Integration
@@ -81,6 +83,19 @@ type IntegrationSpec struct {
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 }
 
+// GitSourceSpec defines the location of the Integration sources in a git repository.
+type GitSourceSpec struct {
+	// the URL of the repository
+	URL string `json:"url"`
+	// the branch, tag or commit SHA to check out (default `HEAD`)
+	Ref string `json:"ref,omitempty"`
+	// the path of the source file, or of the directory containing the source files, relative to the root of the repository
+	Path string `json:"path,omitempty"`
+	// the interval at which the ref is checked for new commits, that trigger a rebuild of the Integration (default `5m`).
+	// A zero interval disables the check.
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
 // IntegrationStatus defines the observed state of Integration.
 type IntegrationStatus struct {
 	// ObservedGeneration is the most recent generation observed for this Integration.
@@ -101,6 +116,10 @@ type IntegrationStatus struct {
 	Platform string `json:"platform,omitempty"`
 	// a list of sources generated for this Integration
 	GeneratedSources []SourceSpec `json:"generatedSources,omitempty"`
+	// the SHA of the commit the sources have been checked out from, when they are located in a git repository
+	GitCommit string `json:"gitCommit,omitempty"`
+	// the last time the git ref of the sources has been checked for new commits
+	GitLastCheckTime *metav1.Time `json:"gitLastCheckTime,omitempty"`
 	// the runtime version targeted for this Integration
 	RuntimeVersion string `json:"runtimeVersion,omitempty"`
 	// the runtime provider targeted for this Integration
@@ -205,6 +224,10 @@ const (
 	IntegrationConditionIngressAvailableReason string = "IngressAvailable"
 	// IntegrationConditionIngressNotAvailableReason --.
 	IntegrationConditionIngressNotAvailableReason string = "IngressNotAvailable"
+	// IntegrationConditionGatewayAvailableReason --.
+	IntegrationConditionGatewayAvailableReason string = "GatewayAvailable"
+	// IntegrationConditionGatewayNotAvailableReason --.
+	IntegrationConditionGatewayNotAvailableReason string = "GatewayNotAvailable"
 	// IntegrationConditionKnativeServiceAvailableReason --.
 	IntegrationConditionKnativeServiceAvailableReason string = "KnativeServiceAvailable"
 	// IntegrationConditionKnativeServiceNotAvailableReason --.
@@ -251,8 +274,20 @@ const (
 	IntegrationConditionKameletsAvailableReason string = "KameletsAvailable"
 	// IntegrationConditionKameletsNotAvailableReason --.
 	IntegrationConditionKameletsNotAvailableReason string = "KameletsNotAvailable"
+	// IntegrationConditionKameletsNotReadyReason --.
+	IntegrationConditionKameletsNotReadyReason string = "KameletsNotReady"
 	// IntegrationConditionImportingKindAvailableReason used (as false) if we're trying to import an unsupported kind.
 	IntegrationConditionImportingKindAvailableReason string = "ImportingKindAvailable"
+	// IntegrationConditionRollout reports the state of a progressive rollout.
+	IntegrationConditionRollout IntegrationConditionType = "Rollout"
+	// IntegrationConditionRolloutProgressingReason used (as false) while the new version is being deployed.
+	IntegrationConditionRolloutProgressingReason string = "RolloutProgressing"
+	// IntegrationConditionRolloutAnalysisReason used (as false) while the new version is ready and being analyzed.
+	IntegrationConditionRolloutAnalysisReason string = "RolloutAnalysis"
+	// IntegrationConditionRolloutPromotedReason used (as true) once the new version has replaced the stable one.
+	IntegrationConditionRolloutPromotedReason string = "RolloutPromoted"
+	// IntegrationConditionRolloutAbortedReason used (as false) when the new version has failed and has been discarded.
+	IntegrationConditionRolloutAbortedReason string = "RolloutAborted"
 )
 
 // IntegrationCondition describes the state of a resource at a certain point.
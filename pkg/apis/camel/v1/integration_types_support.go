@@ -20,6 +20,7 @@ package v1
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,9 @@ import (
 // IntegrationLabel is used to tag k8s object created by a given Integration.
 const IntegrationLabel = "camel.apache.org/integration"
 
+// DefaultGitPollInterval is the default interval at which the git ref of the Integration sources is checked for new commits.
+const DefaultGitPollInterval = 5 * time.Minute
+
 // IntegrationSyntheticLabel is used to tag k8s synthetic Integrations.
 const IntegrationSyntheticLabel = "camel.apache.org/is-synthetic"
 
@@ -37,6 +41,16 @@ const IntegrationImportedKindLabel = "camel.apache.org/imported-from-kind"
 // IntegrationImportedNameLabel specifies from what resource an Integration was imported.
 const IntegrationImportedNameLabel = "camel.apache.org/imported-from-name"
 
+// IntegrationRolloutLabel identifies the stable and the candidate Pods of an Integration during a progressive rollout.
+const IntegrationRolloutLabel = "camel.apache.org/rollout"
+
+const (
+	// IntegrationRolloutStable is the IntegrationRolloutLabel value of the Pods running the stable version.
+	IntegrationRolloutStable = "stable"
+	// IntegrationRolloutCandidate is the IntegrationRolloutLabel value of the Pods running the new version.
+	IntegrationRolloutCandidate = "candidate"
+)
+
 func NewIntegration(namespace string, name string) Integration {
 	return Integration{
 		TypeMeta: metav1.TypeMeta{
@@ -93,6 +107,17 @@ func (in *IntegrationSpec) AddSources(sources ...SourceSpec) {
 	in.Sources = append(in.Sources, sources...)
 }
 
+// GetPollInterval returns the interval at which the git ref is checked for new commits, or 0 if the check is disabled.
+func (in *GitSourceSpec) GetPollInterval() time.Duration {
+	if in == nil {
+		return 0
+	}
+	if in.PollInterval == nil {
+		return DefaultGitPollInterval
+	}
+	return in.PollInterval.Duration
+}
+
 func (in *IntegrationSpec) AddFlows(flows ...Flow) {
 	in.Flows = append(in.Flows, flows...)
 }
@@ -278,6 +303,13 @@ func (in *Integration) SetReadyConditionError(err string) {
 	in.SetReadyCondition(corev1.ConditionFalse, IntegrationConditionErrorReason, err)
 }
 
+// IsRolledOut returns true when the current version of the Integration is the one serving, that is when no
+// progressive rollout is in progress or has been aborted.
+func (in *Integration) IsRolledOut() bool {
+	condition := in.Status.GetCondition(IntegrationConditionRollout)
+	return condition == nil || condition.Reason == IntegrationConditionRolloutPromotedReason
+}
+
 // IsSynthetic returns true for synthetic Integrations (non managed, likely imported from external deployments).
 func (in *Integration) IsSynthetic() bool {
 	return in.Annotations[IntegrationSyntheticLabel] == "true"
@@ -123,6 +123,8 @@ type IntegrationKitStatus struct {
 	Version string `json:"version,omitempty"`
 	// a list of conditions which happened for the events related the kit
 	Conditions []IntegrationKitCondition `json:"conditions,omitempty"`
+	// the last time the kit was found in use, either by an Integration or by the revision history of its Deployment
+	LastUsedTimestamp *metav1.Time `json:"lastUsedTimestamp,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -209,3 +211,16 @@ type IntegrationKitCondition struct {
 	// A human-readable message indicating details about the transition.
 	Message string `json:"message,omitempty"`
 }
+
+// IntegrationKitRetentionSpec defines the policy used to garbage collect the IntegrationKits created by the operator.
+// An IntegrationKit is never garbage collected while it's in use, that is when it's referenced by an Integration,
+// or when its image is still referenced by the revision history of an Integration Deployment.
+type IntegrationKitRetentionSpec struct {
+	// the number of most recently used IntegrationKits to keep for each Integration
+	KeepPerIntegration *int32 `json:"keepPerIntegration,omitempty"`
+	// how long to keep an IntegrationKit after it has been last used
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// whether to delete the container image of the garbage collected IntegrationKits from the registry as well.
+	// It has no effect with the S2I publish strategy, as the images are garbage collected with the kits.
+	DeleteImages bool `json:"deleteImages,omitempty"`
+}
@@ -135,6 +135,12 @@ type IntegrationPlatformBuildSpec struct {
 	PublishStrategyOptions map[string]string `json:"PublishStrategyOptions,omitempty"`
 	// the maximum amount of parallel running pipelines started by this operator instance
 	MaxRunningBuilds int32 `json:"maxRunningBuilds,omitempty"`
+	// the policy used to garbage collect the IntegrationKits which are no longer in use
+	KitRetention *IntegrationKitRetentionSpec `json:"kitRetention,omitempty"`
+	// the maximum amount of parallel running builds started by this operator instance in a single namespace, only honored in the operator namespace
+	MaxRunningBuildsPerNamespace int32 `json:"maxRunningBuildsPerNamespace,omitempty"`
+	// the priority of the builds, the builds with a higher priority are scheduled first (default `0`), only honored in the operator namespace
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // IntegrationPlatformKameletSpec define the behavior for all the Kamelets controller by the IntegrationPlatform.
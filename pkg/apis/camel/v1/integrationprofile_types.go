@@ -89,6 +89,10 @@ type IntegrationProfileBuildSpec struct {
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 	// Maven configuration used to build the Camel/Camel-Quarkus applications
 	Maven MavenSpec `json:"maven,omitempty"`
+	// the policy used to garbage collect the IntegrationKits which are no longer in use
+	KitRetention *IntegrationKitRetentionSpec `json:"kitRetention,omitempty"`
+	// the priority of the builds, the builds with a higher priority are scheduled first (default `0`), only honored in the operator namespace
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // IntegrationProfileKameletSpec define the behavior for all the Kamelets controller by the IntegrationProfile.
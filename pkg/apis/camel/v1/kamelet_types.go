@@ -49,6 +49,8 @@ var (
 	reservedKameletNames = map[string]bool{"source": true, "sink": true}
 	// KameletIDProperty used to identify.
 	KameletIDProperty = "id"
+	// KameletVersionProperty used to pin the version of the Kamelet.
+	KameletVersionProperty = "kameletVersion"
 )
 
 // +genclient
@@ -73,6 +75,14 @@ type Kamelet struct {
 
 // KameletSpec specifies the configuration required to execute a Kamelet.
 type KameletSpec struct {
+	KameletSpecBase `json:",inline"`
+	// the alternative versions of the Kamelet, keyed by version name, that can be pinned with the kameletVersion property.
+	// The Kamelet specification itself is the default version, used when no version is pinned.
+	Versions map[string]KameletSpecBase `json:"versions,omitempty"`
+}
+
+// KameletSpecBase specifies the configuration of a version of a Kamelet.
+type KameletSpecBase struct {
 	// defines the formal configuration of the Kamelet
 	Definition *JSONSchemaProps `json:"definition,omitempty"`
 	// sources in any Camel DSL supported
@@ -211,6 +221,8 @@ const (
 	KameletConditionReasonInvalidProperty string = "InvalidProperty"
 	// KameletConditionReasonInvalidTemplate --.
 	KameletConditionReasonInvalidTemplate string = "InvalidTemplate"
+	// KameletConditionReasonInvalidSchema --.
+	KameletConditionReasonInvalidSchema string = "InvalidSchema"
 )
 
 // KameletPhase --.
@@ -18,7 +18,9 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -177,6 +179,33 @@ func (k *Kamelet) SortedTypesKeys() []TypeSlot {
 	return res
 }
 
+// SortedVersions returns the sorted names of the alternative versions of the Kamelet.
+func (k *Kamelet) SortedVersions() []string {
+	res := make([]string, 0, len(k.Spec.Versions))
+	for version := range k.Spec.Versions {
+		res = append(res, version)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// CloneWithVersion returns a copy of the Kamelet whose specification is the given version, without the alternative versions.
+// The default version is used when the given version is empty.
+func (k *Kamelet) CloneWithVersion(version string) (*Kamelet, error) {
+	clone := k.DeepCopy()
+	clone.Spec.Versions = nil
+	if version == "" {
+		return clone, nil
+	}
+	spec, ok := k.Spec.Versions[version]
+	if !ok {
+		return nil, fmt.Errorf("version %q of Kamelet %s not found, available versions are [%s]",
+			version, k.Name, strings.Join(k.SortedVersions(), ","))
+	}
+	clone.Spec.KameletSpecBase = *spec.DeepCopy()
+	return clone, nil
+}
+
 func ValidKameletName(name string) bool {
 	return !reservedKameletNames[name]
 }
@@ -52,6 +52,30 @@ type MavenSpec struct {
 	// e.g., `-V,--no-transfer-progress,-Dstyle.color=never`.
 	// See https://maven.apache.org/ref/3.8.4/maven-embedder/cli.html.
 	CLIOptions []string `json:"cliOptions,omitempty"`
+	// The persistent cache of Maven artifacts shared by the builder Pods.
+	// It only applies to the `pod` build strategy.
+	Cache *MavenCacheSpec `json:"cache,omitempty"`
+}
+
+// MavenCacheSpec defines a persistent cache of Maven artifacts, shared across builds.
+// The cache is mounted read-only as an extra repository by the builds, and the artifacts
+// downloaded by a build are published into it atomically once the build completes,
+// so that concurrent builds can safely share it.
+type MavenCacheSpec struct {
+	// The name of an existing PersistentVolumeClaim that stores the cache.
+	// When not set, the operator creates and manages the `camel-k-maven-cache` PersistentVolumeClaim,
+	// in the namespace where the builder Pods run.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	// The storage class of the operator managed PersistentVolumeClaim.
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// The requested storage of the operator managed PersistentVolumeClaim (default `10Gi`).
+	Size string `json:"size,omitempty"`
+	// The access mode of the operator managed PersistentVolumeClaim (default `ReadWriteMany`).
+	// Use `ReadWriteOnce` only when the builder Pods all run on the same node.
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+	// The maximum size of the cache content, e.g., `8Gi`. When it's exceeded, the least recently
+	// used artifacts are evicted at the end of the builds. There is no eviction when not set.
+	MaxSize string `json:"maxSize,omitempty"`
 }
 
 // Repository defines a Maven repository.
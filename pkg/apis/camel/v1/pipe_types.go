@@ -60,14 +60,70 @@ type PipeSpec struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 	// Custom SA to use for the Pipe
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Routing is an optional routing of the data produced by the Steps to several branches, each ending with its own sink
+	Routing *PipeRouting `json:"routing,omitempty"`
 }
 
+// PipeRouting defines how the data is routed to the branches of a Pipe.
+type PipeRouting struct {
+	// Type is the routing strategy, either Multicast, to send the data to all the branches and to the Sink, if any,
+	// or Choice, to send the data to the first branch whose condition matches, or to the Sink otherwise
+	// +kubebuilder:validation:Enum=Multicast;Choice
+	Type PipeRoutingType `json:"type"`
+	// ParallelProcessing sends the data to the branches concurrently, when using the Multicast routing
+	ParallelProcessing bool `json:"parallelProcessing,omitempty"`
+	// Branches are the branches the data is routed to
+	Branches []PipeBranch `json:"branches"`
+}
+
+// PipeRoutingType is the routing strategy of a Pipe.
+type PipeRoutingType string
+
+const (
+	// PipeRoutingTypeMulticast sends the data to all the branches.
+	PipeRoutingTypeMulticast PipeRoutingType = "Multicast"
+	// PipeRoutingTypeChoice sends the data to the first branch whose condition matches.
+	PipeRoutingTypeChoice PipeRoutingType = "Choice"
+)
+
+// PipeBranch is a chain of steps ending with a sink, the data of a Pipe can be routed to.
+type PipeBranch struct {
+	// Condition is the predicate selecting the data routed to the branch, required by the Choice routing
+	Condition *PipePredicate `json:"condition,omitempty"`
+	// Steps contains an optional list of intermediate steps that are executed before the Sink of the branch
+	Steps []Endpoint `json:"steps,omitempty"`
+	// Sink is the destination of the branch
+	Sink Endpoint `json:"sink"`
+}
+
+// PipePredicate is an expression evaluated against the data flowing through a Pipe.
+type PipePredicate struct {
+	// Language is the language of the expression
+	// +kubebuilder:validation:Enum=simple;jsonpath
+	// +kubebuilder:default=simple
+	Language PipePredicateLanguage `json:"language,omitempty"`
+	// Expression is the predicate expression, e.g., ${header.type} == 'order'
+	Expression string `json:"expression"`
+}
+
+// PipePredicateLanguage is the language of a predicate expression.
+type PipePredicateLanguage string
+
+const (
+	// PipePredicateLanguageSimple is the Camel Simple language.
+	PipePredicateLanguageSimple PipePredicateLanguage = "simple"
+	// PipePredicateLanguageJSONPath is the JsonPath language.
+	PipePredicateLanguageJSONPath PipePredicateLanguage = "jsonpath"
+)
+
 // Endpoint represents a source/sink external entity (could be any Kubernetes resource or Camel URI).
 type Endpoint struct {
 	// Ref can be used to declare a Kubernetes resource as source/sink endpoint
 	Ref *corev1.ObjectReference `json:"ref,omitempty"`
 	// URI can be used to specify the (Camel) endpoint explicitly
 	URI *string `json:"uri,omitempty"`
+	// Inline can be used to declare a step as a fragment of Camel YAML DSL, e.g., a setHeader or a filter EIP
+	Inline *Flow `json:"inline,omitempty"`
 	// Properties are a key value representation of endpoint properties
 	Properties *EndpointProperties `json:"properties,omitempty"`
 	// DataTypes defines the data type of the data produced/consumed by the endpoint and references a given data type specification.
@@ -135,6 +191,22 @@ const (
 	PipeIntegrationConditionError PipeConditionType = "IntegrationError"
 	// PipeIntegrationDeprecationNotice is used to report the usage of a deprecated resource.
 	PipeIntegrationDeprecationNotice PipeConditionType = "DeprecationNotice"
+	// PipeConditionPropertyValidPrefix is the prefix of the conditions reporting the endpoint properties that do not match
+	// the definition of the referenced Kamelet, followed by the endpoint and the property, e.g., PropertyValid/source.message.
+	PipeConditionPropertyValidPrefix = "PropertyValid/"
+	// PipeConditionDataTypesCompatible is used to report the compatibility of the data types flowing through the Pipe endpoints.
+	PipeConditionDataTypesCompatible PipeConditionType = "DataTypesCompatible"
+)
+
+const (
+	// PipeKameletsNotReadyReason is used to report the Pipe references Kamelets that are not ready.
+	PipeKameletsNotReadyReason string = "KameletsNotReady"
+	// PipeInvalidPropertiesReason is used to report the Pipe endpoints have properties that do not match the Kamelet definitions.
+	PipeInvalidPropertiesReason string = "InvalidProperties"
+	// PipeIncompatibleDataTypesReason is used to report data produced by a Pipe endpoint that cannot be consumed by the next one.
+	PipeIncompatibleDataTypesReason string = "IncompatibleDataTypes"
+	// PipeDataTypesConvertedReason is used to report the transformers added to convert the data flowing through the Pipe endpoints.
+	PipeDataTypesConvertedReason string = "DataTypesConverted"
 )
 
 // PipePhase --.
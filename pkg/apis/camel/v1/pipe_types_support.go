@@ -168,6 +168,11 @@ func (p *EndpointProperties) GetPropertyMap() (map[string]string, error) {
 	return stringProps, nil
 }
 
+// IsEmpty returns true if the endpoint declares neither a reference, a URI nor an inline step.
+func (e Endpoint) IsEmpty() bool {
+	return e.Ref == nil && e.URI == nil && e.Inline == nil
+}
+
 // NewPipe --.
 func NewPipe(namespace string, name string) Pipe {
 	return Pipe{
@@ -47,4 +47,29 @@ type DeploymentTrait struct {
 	// Absolute number is calculated from percentage by rounding up.
 	// Defaults to `25%`.
 	RollingUpdateMaxSurge *intstr.IntOrString `property:"rolling-update-max-surge" json:"rollingUpdateMaxSurge,omitempty"`
+	// The progressive delivery strategy to use to roll out a new version of the integration.
+	// The new version is first deployed as a second deployment, next to the stable one, and it is promoted
+	// once it has been ready for the analysis period, or aborted as soon as it fails.
+	// With `Canary`, the new version receives a share of the traffic, proportional to its number of replicas.
+	// With `BlueGreen`, the new version runs with all the replicas, but it only receives traffic once promoted.
+	RolloutStrategy RolloutStrategyType `property:"rollout-strategy" json:"rolloutStrategy,omitempty"`
+	// The percentage of the replicas running the new version during a `Canary` rollout.
+	// Defaults to `20`.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	CanaryWeight *int32 `property:"canary-weight" json:"canaryWeight,omitempty"`
+	// The time in seconds the new version must be ready for, before it is promoted.
+	// Defaults to `60`.
+	RolloutAnalysisSeconds *int32 `property:"rollout-analysis-seconds" json:"rolloutAnalysisSeconds,omitempty"`
 }
+
+// RolloutStrategyType is the progressive delivery strategy used to roll out a new version of an integration.
+// +kubebuilder:validation:Enum=Canary;BlueGreen
+type RolloutStrategyType string
+
+const (
+	// RolloutStrategyCanary gives the new version a share of the traffic, proportional to its number of replicas.
+	RolloutStrategyCanary RolloutStrategyType = "Canary"
+	// RolloutStrategyBlueGreen runs the new version with all the replicas, and switches the traffic once promoted.
+	RolloutStrategyBlueGreen RolloutStrategyType = "BlueGreen"
+)
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+// The Gateway trait can be used to expose the service associated with the integration
+// to the outside world, with a Gateway API HTTPRoute attached to a parent Gateway.
+//
+// It's enabled by default whenever a Service is added to the integration (through the `service` trait),
+// provided the Gateway API is installed in the cluster, and the parent Gateway is configured,
+// e.g., for all the integrations from the IntegrationPlatform traits.
+//
+// Path matches are expressed as `[<Exact|PathPrefix|RegularExpression>:]<path>`, e.g., `/api` or `Exact:/health`,
+// and header matches as `<name>=<value>`, or `<name>~=<regex>` to match a regular expression.
+// The traffic can be split between the integration Service and other Services, e.g., another integration,
+// with additional backends expressed as `<service>[:<port>]=<weight>`.
+//
+// +camel-k:trait=gateway.
+type GatewayTrait struct {
+	Trait `property:",squash" json:",inline"`
+	// The parent Gateway the routes are attached to, in the form `[<namespace>/]<name>`.
+	Gateway string `property:"gateway" json:"gateway,omitempty"`
+	// The name of the parent Gateway listener the routes are attached to.
+	SectionName string `property:"section-name" json:"sectionName,omitempty"`
+	// The list of hostnames the routes match.
+	Hosts []string `property:"hosts" json:"hosts,omitempty"`
+	// The list of request paths the HTTP route matches, in the form `[<Exact|PathPrefix|RegularExpression>:]<path>` (default `PathPrefix:/`).
+	Paths []string `property:"paths" json:"paths,omitempty"`
+	// The list of request headers the routes match, in the form `<name>=<value>`, or `<name>~=<regex>`.
+	Headers []string `property:"headers" json:"headers,omitempty"`
+	// The weight of the integration Service among the route backends (default `1`).
+	Weight *int32 `property:"weight" json:"weight,omitempty"`
+	// The list of additional route backends, in the form `<service>[:<port>]=<weight>`.
+	Backends []string `property:"backends" json:"backends,omitempty"`
+	// The timeout for the whole HTTP request, e.g., `30s`.
+	RequestTimeout string `property:"request-timeout" json:"requestTimeout,omitempty"`
+	// The timeout for each request from the Gateway to the backends, e.g., `10s`.
+	BackendRequestTimeout string `property:"backend-request-timeout" json:"backendRequestTimeout,omitempty"`
+	// To also create a GRPCRoute, for integrations exposing gRPC services (default `false`).
+	GRPC *bool `property:"grpc" json:"grpc,omitempty"`
+	// To automatically enable the trait when the integration exposes a Service and the parent Gateway is configured (default `true`).
+	Auto *bool `property:"auto" json:"auto,omitempty"`
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+// The HPA trait configures a HorizontalPodAutoscaler, that automatically scales the Integration
+// based on the CPU and memory utilization of its pods, or on custom metrics, e.g., exposed by the Prometheus adapter.
+//
+// The autoscaler targets the `scale` sub-resource of the Integration, or of the Pipe it has been created from,
+// so that the number of replicas it computes is reflected in the `replicas` field of the resource.
+// It is only supported with the `deployment` controller strategy.
+//
+// Custom metrics are expressed as `<metric-name>=<average-value>`, and scale the Integration on the average value
+// of the metric across its pods, e.g., `application_camel_exchanges_inflight=10`.
+//
+// Scaling policies are expressed as `<Pods|Percent>:<value>:<period-seconds>`, e.g., `Pods:4:60` allows to add or remove
+// up to 4 pods every minute.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=hpa.
+type HPATrait struct {
+	Trait `property:",squash" json:",inline"`
+	// The lower limit for the number of replicas to which the autoscaler can scale down (default `1`).
+	MinReplicas *int32 `property:"min-replicas" json:"minReplicas,omitempty"`
+	// The upper limit for the number of replicas to which the autoscaler can scale up (default `10`).
+	MaxReplicas *int32 `property:"max-replicas" json:"maxReplicas,omitempty"`
+	// The target average CPU utilization, as a percentage of the requested CPU of the pods.
+	// Defaults to `80` when no other metric is configured.
+	CPUUtilization *int32 `property:"cpu-utilization" json:"cpuUtilization,omitempty"`
+	// The target average memory utilization, as a percentage of the requested memory of the pods.
+	MemoryUtilization *int32 `property:"memory-utilization" json:"memoryUtilization,omitempty"`
+	// The list of custom pod metrics to scale on, in the form `<metric-name>=<average-value>`.
+	CustomMetrics []string `property:"custom-metrics" json:"customMetrics,omitempty"`
+	// The number of seconds for which past recommendations are considered while scaling up (default `0`).
+	ScaleUpStabilizationWindowSeconds *int32 `property:"scale-up-stabilization-window-seconds" json:"scaleUpStabilizationWindowSeconds,omitempty"`
+	// The list of policies applied while scaling up, in the form `<Pods|Percent>:<value>:<period-seconds>`.
+	ScaleUpPolicies []string `property:"scale-up-policies" json:"scaleUpPolicies,omitempty"`
+	// The number of seconds for which past recommendations are considered while scaling down (default `300`).
+	ScaleDownStabilizationWindowSeconds *int32 `property:"scale-down-stabilization-window-seconds" json:"scaleDownStabilizationWindowSeconds,omitempty"`
+	// The list of policies applied while scaling down, in the form `<Pods|Percent>:<value>:<period-seconds>`.
+	ScaleDownPolicies []string `property:"scale-down-policies" json:"scaleDownPolicies,omitempty"`
+}
@@ -24,7 +24,7 @@ type KameletsTrait struct {
 	Trait `property:",squash" json:",inline"`
 	// Automatically inject all referenced Kamelets and their default configuration (enabled by default)
 	Auto *bool `property:"auto" json:"auto,omitempty"`
-	// Comma separated list of Kamelet names to load into the current integration
+	// Comma separated list of Kamelet names to load into the current integration, optionally pinned to a version, e.g., `timer-source@v1`
 	List string `property:"list" json:"list,omitempty"`
 	// The directory where the application mounts and reads Kamelet spec (default `/etc/camel/kamelets`)
 	MountPoint string `property:"mount-point" json:"mountPoint,omitempty"`
@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+// The NetworkPolicy trait generates a NetworkPolicy that restricts the network traffic of the Integration pods.
+//
+// Ingress traffic is only allowed on the ports of the Integration Service, when the Integration exposes HTTP services,
+// and it's denied otherwise.
+//
+// When `default-deny` is enabled, the egress traffic is denied too, except towards the cluster DNS, and towards the ports
+// the Integration connects to, as inferred from the endpoint URIs of its routes, e.g., `https://my-host:8443/api`
+// or `kafka:my-topic?brokers=my-cluster:9092`, or from the default ports of well-known components.
+// Egress can be widened per component with egress rules, expressed as `<component>=<port>[/<protocol>][@<cidr>]`,
+// e.g., `kafka=9093`, `jdbc=5432@10.0.0.0/16`, or `*=443` to apply it regardless of the components in use.
+//
+// It is only supported with the `deployment` and `cron-job` controller strategies.
+//
+// It's disabled by default.
+//
+// +camel-k:trait=network-policy.
+type NetworkPolicyTrait struct {
+	Trait `property:",squash" json:",inline"`
+	// Deny the egress traffic that isn't explicitly allowed (default `false`).
+	DefaultDeny *bool `property:"default-deny" json:"defaultDeny,omitempty"`
+	// Allow the egress traffic towards the ports inferred from the endpoint URIs of the Integration routes (default `true`).
+	AutoEgress *bool `property:"auto-egress" json:"autoEgress,omitempty"`
+	// The list of additional egress rules, in the form `<component>=<port>[/<protocol>][@<cidr>]`.
+	// A rule only applies when the component is used by the Integration, unless the component is `*`.
+	Egress []string `property:"egress" json:"egress,omitempty"`
+	// The list of namespaces allowed to reach the Integration pods (default all namespaces).
+	IngressNamespaces []string `property:"ingress-namespaces" json:"ingressNamespaces,omitempty"`
+}
@@ -290,6 +290,16 @@ func (in *DeploymentTrait) DeepCopyInto(out *DeploymentTrait) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.CanaryWeight != nil {
+		in, out := &in.CanaryWeight, &out.CanaryWeight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RolloutAnalysisSeconds != nil {
+		in, out := &in.RolloutAnalysisSeconds, &out.RolloutAnalysisSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentTrait.
@@ -370,6 +380,118 @@ func (in *GCTrait) DeepCopy() *GCTrait {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTrait) DeepCopyInto(out *GatewayTrait) {
+	*out = *in
+	in.Trait.DeepCopyInto(&out.Trait)
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Auto != nil {
+		in, out := &in.Auto, &out.Auto
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTrait.
+func (in *GatewayTrait) DeepCopy() *GatewayTrait {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTrait)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HPATrait) DeepCopyInto(out *HPATrait) {
+	*out = *in
+	in.Trait.DeepCopyInto(&out.Trait)
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CPUUtilization != nil {
+		in, out := &in.CPUUtilization, &out.CPUUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryUtilization != nil {
+		in, out := &in.MemoryUtilization, &out.MemoryUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CustomMetrics != nil {
+		in, out := &in.CustomMetrics, &out.CustomMetrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaleUpStabilizationWindowSeconds != nil {
+		in, out := &in.ScaleUpStabilizationWindowSeconds, &out.ScaleUpStabilizationWindowSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleUpPolicies != nil {
+		in, out := &in.ScaleUpPolicies, &out.ScaleUpPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaleDownStabilizationWindowSeconds != nil {
+		in, out := &in.ScaleDownStabilizationWindowSeconds, &out.ScaleDownStabilizationWindowSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleDownPolicies != nil {
+		in, out := &in.ScaleDownPolicies, &out.ScaleDownPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HPATrait.
+func (in *HPATrait) DeepCopy() *HPATrait {
+	if in == nil {
+		return nil
+	}
+	out := new(HPATrait)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthTrait) DeepCopyInto(out *HealthTrait) {
 	*out = *in
@@ -759,6 +881,42 @@ func (in *MountTrait) DeepCopy() *MountTrait {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyTrait) DeepCopyInto(out *NetworkPolicyTrait) {
+	*out = *in
+	in.Trait.DeepCopyInto(&out.Trait)
+	if in.DefaultDeny != nil {
+		in, out := &in.DefaultDeny, &out.DefaultDeny
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AutoEgress != nil {
+		in, out := &in.AutoEgress, &out.AutoEgress
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IngressNamespaces != nil {
+		in, out := &in.IngressNamespaces, &out.IngressNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyTrait.
+func (in *NetworkPolicyTrait) DeepCopy() *NetworkPolicyTrait {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyTrait)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenAPITrait) DeepCopyInto(out *OpenAPITrait) {
 	*out = *in
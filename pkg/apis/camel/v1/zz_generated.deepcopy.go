@@ -252,6 +252,11 @@ func (in *BuildStatus) DeepCopyInto(out *BuildStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MavenCache != nil {
+		in, out := &in.MavenCache, &out.MavenCache
+		*out = new(MavenCacheStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildStatus.
@@ -762,6 +767,11 @@ func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(Flow)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Properties != nil {
 		in, out := &in.Properties, &out.Properties
 		*out = new(EndpointProperties)
@@ -806,15 +816,45 @@ func (in *EndpointProperties) DeepCopy() *EndpointProperties {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorHandlerCircuitBreaker) DeepCopyInto(out *ErrorHandlerCircuitBreaker) {
+	*out = *in
+	if in.FailureWindow != nil {
+		in, out := &in.FailureWindow, &out.FailureWindow
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HalfOpenAfter != nil {
+		in, out := &in.HalfOpenAfter, &out.HalfOpenAfter
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorHandlerCircuitBreaker.
+func (in *ErrorHandlerCircuitBreaker) DeepCopy() *ErrorHandlerCircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorHandlerCircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ErrorHandlerLog) DeepCopyInto(out *ErrorHandlerLog) {
 	*out = *in
-	out.ErrorHandlerNone = in.ErrorHandlerNone
+	in.ErrorHandlerNone.DeepCopyInto(&out.ErrorHandlerNone)
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = new(ErrorHandlerParameters)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Redelivery != nil {
+		in, out := &in.Redelivery, &out.Redelivery
+		*out = new(ErrorHandlerRedelivery)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorHandlerLog.
@@ -831,6 +871,11 @@ func (in *ErrorHandlerLog) DeepCopy() *ErrorHandlerLog {
 func (in *ErrorHandlerNone) DeepCopyInto(out *ErrorHandlerNone) {
 	*out = *in
 	out.baseErrorHandler = in.baseErrorHandler
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(ErrorHandlerCircuitBreaker)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorHandlerNone.
@@ -863,6 +908,46 @@ func (in *ErrorHandlerParameters) DeepCopy() *ErrorHandlerParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorHandlerRedelivery) DeepCopyInto(out *ErrorHandlerRedelivery) {
+	*out = *in
+	if in.MaximumRedeliveries != nil {
+		in, out := &in.MaximumRedeliveries, &out.MaximumRedeliveries
+		*out = new(int)
+		**out = **in
+	}
+	if in.RedeliveryDelay != nil {
+		in, out := &in.RedeliveryDelay, &out.RedeliveryDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BackOffMultiplier != nil {
+		in, out := &in.BackOffMultiplier, &out.BackOffMultiplier
+		*out = new(float64)
+		**out = **in
+	}
+	if in.RetryWhile != nil {
+		in, out := &in.RetryWhile, &out.RetryWhile
+		*out = new(PipePredicate)
+		**out = **in
+	}
+	if in.LogExhaustedOnly != nil {
+		in, out := &in.LogExhaustedOnly, &out.LogExhaustedOnly
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorHandlerRedelivery.
+func (in *ErrorHandlerRedelivery) DeepCopy() *ErrorHandlerRedelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorHandlerRedelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ErrorHandlerSink) DeepCopyInto(out *ErrorHandlerSink) {
 	*out = *in
@@ -1034,6 +1119,26 @@ func (in *Flow) DeepCopy() *Flow {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSourceSpec) DeepCopyInto(out *GitSourceSpec) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSourceSpec.
+func (in *GitSourceSpec) DeepCopy() *GitSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HeaderSpec) DeepCopyInto(out *HeaderSpec) {
 	*out = *in
@@ -1222,6 +1327,31 @@ func (in *IntegrationKitList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationKitRetentionSpec) DeepCopyInto(out *IntegrationKitRetentionSpec) {
+	*out = *in
+	if in.KeepPerIntegration != nil {
+		in, out := &in.KeepPerIntegration, &out.KeepPerIntegration
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationKitRetentionSpec.
+func (in *IntegrationKitRetentionSpec) DeepCopy() *IntegrationKitRetentionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationKitRetentionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntegrationKitSpec) DeepCopyInto(out *IntegrationKitSpec) {
 	*out = *in
@@ -1285,6 +1415,10 @@ func (in *IntegrationKitStatus) DeepCopyInto(out *IntegrationKitStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastUsedTimestamp != nil {
+		in, out := &in.LastUsedTimestamp, &out.LastUsedTimestamp
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationKitStatus.
@@ -1421,6 +1555,11 @@ func (in *IntegrationPlatformBuildSpec) DeepCopyInto(out *IntegrationPlatformBui
 			(*out)[key] = val
 		}
 	}
+	if in.KitRetention != nil {
+		in, out := &in.KitRetention, &out.KitRetention
+		*out = new(IntegrationKitRetentionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformBuildSpec.
@@ -1592,6 +1731,11 @@ func (in *IntegrationProfileBuildSpec) DeepCopyInto(out *IntegrationProfileBuild
 		**out = **in
 	}
 	in.Maven.DeepCopyInto(&out.Maven)
+	if in.KitRetention != nil {
+		in, out := &in.KitRetention, &out.KitRetention
+		*out = new(IntegrationKitRetentionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationProfileBuildSpec.
@@ -1729,6 +1873,11 @@ func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Flows != nil {
 		in, out := &in.Flows, &out.Flows
 		*out = make([]Flow, len(*in))
@@ -1794,6 +1943,10 @@ func (in *IntegrationStatus) DeepCopyInto(out *IntegrationStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GitLastCheckTime != nil {
+		in, out := &in.GitLastCheckTime, &out.GitLastCheckTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Configuration != nil {
 		in, out := &in.Configuration, &out.Configuration
 		*out = make([]ConfigurationSpec, len(*in))
@@ -2096,6 +2249,29 @@ func (in *KameletRepositorySpec) DeepCopy() *KameletRepositorySpec {
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KameletSpec) DeepCopyInto(out *KameletSpec) {
+	*out = *in
+	in.KameletSpecBase.DeepCopyInto(&out.KameletSpecBase)
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make(map[string]KameletSpecBase, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletSpec.
+func (in *KameletSpec) DeepCopy() *KameletSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KameletSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KameletSpecBase) DeepCopyInto(out *KameletSpecBase) {
 	*out = *in
 	if in.Definition != nil {
 		in, out := &in.Definition, &out.Definition
@@ -2135,12 +2311,12 @@ func (in *KameletSpec) DeepCopyInto(out *KameletSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletSpec.
-func (in *KameletSpec) DeepCopy() *KameletSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletSpecBase.
+func (in *KameletSpecBase) DeepCopy() *KameletSpecBase {
 	if in == nil {
 		return nil
 	}
-	out := new(KameletSpec)
+	out := new(KameletSpecBase)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2258,6 +2434,36 @@ func (in *MavenBuildSpec) DeepCopy() *MavenBuildSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenCacheSpec) DeepCopyInto(out *MavenCacheSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenCacheSpec.
+func (in *MavenCacheSpec) DeepCopy() *MavenCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenCacheStatus) DeepCopyInto(out *MavenCacheStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenCacheStatus.
+func (in *MavenCacheStatus) DeepCopy() *MavenCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 	*out = *in
@@ -2294,6 +2500,11 @@ func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(MavenCacheSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenSpec.
@@ -2368,6 +2579,34 @@ func (in *Pipe) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipeBranch) DeepCopyInto(out *PipeBranch) {
+	*out = *in
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(PipePredicate)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]Endpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Sink.DeepCopyInto(&out.Sink)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipeBranch.
+func (in *PipeBranch) DeepCopy() *PipeBranch {
+	if in == nil {
+		return nil
+	}
+	out := new(PipeBranch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipeCondition) DeepCopyInto(out *PipeCondition) {
 	*out = *in
@@ -2424,6 +2663,43 @@ func (in *PipeList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipePredicate) DeepCopyInto(out *PipePredicate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipePredicate.
+func (in *PipePredicate) DeepCopy() *PipePredicate {
+	if in == nil {
+		return nil
+	}
+	out := new(PipePredicate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipeRouting) DeepCopyInto(out *PipeRouting) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]PipeBranch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipeRouting.
+func (in *PipeRouting) DeepCopy() *PipeRouting {
+	if in == nil {
+		return nil
+	}
+	out := new(PipeRouting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipeSpec) DeepCopyInto(out *PipeSpec) {
 	*out = *in
@@ -2451,6 +2727,11 @@ func (in *PipeSpec) DeepCopyInto(out *PipeSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Routing != nil {
+		in, out := &in.Routing, &out.Routing
+		*out = new(PipeRouting)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipeSpec.
@@ -3093,6 +3374,11 @@ func (in *Traits) DeepCopyInto(out *Traits) {
 		*out = new(trait.ErrorHandlerTrait)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(trait.GatewayTrait)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.GC != nil {
 		in, out := &in.GC, &out.GC
 		*out = new(trait.GCTrait)
@@ -3103,6 +3389,11 @@ func (in *Traits) DeepCopyInto(out *Traits) {
 		*out = new(trait.HealthTrait)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(trait.HPATrait)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Ingress != nil {
 		in, out := &in.Ingress, &out.Ingress
 		*out = new(trait.IngressTrait)
@@ -3148,6 +3439,11 @@ func (in *Traits) DeepCopyInto(out *Traits) {
 		*out = new(trait.MountTrait)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(trait.NetworkPolicyTrait)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.OpenAPI != nil {
 		in, out := &in.OpenAPI, &out.OpenAPI
 		*out = new(trait.OpenAPITrait)
@@ -148,6 +148,8 @@ type KameletBindingPhase string
 const (
 	// KameletBindingKind --.
 	KameletBindingKind string = "KameletBinding"
+	// KameletBindingMigratedAnnotation marks a KameletBinding migrated to the Pipe with the same name.
+	KameletBindingMigratedAnnotation = "camel.apache.org/migrated"
 
 	// KameletBindingPhaseNone --.
 	KameletBindingPhaseNone KameletBindingPhase = ""
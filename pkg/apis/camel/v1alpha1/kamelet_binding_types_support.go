@@ -73,6 +73,11 @@ func (in *KameletBinding) SetOperatorID(operatorID string) {
 	v1.SetAnnotation(&in.ObjectMeta, v1.OperatorIDAnnotation, operatorID)
 }
 
+// IsMigrated returns true if the KameletBinding has been migrated to a Pipe, which has taken over its Integration.
+func (in *KameletBinding) IsMigrated() bool {
+	return in.Annotations[KameletBindingMigratedAnnotation] == "true"
+}
+
 // GetCondition returns the condition with the provided type.
 func (in *KameletBindingStatus) GetCondition(condType KameletBindingConditionType) *KameletBindingCondition {
 	for i := range in.Conditions {
@@ -117,6 +117,7 @@ steps:
 	result.BaseImage = c.BaseImage
 	result.Artifacts = make([]v1.Artifact, 0, len(c.Artifacts))
 	result.Artifacts = append(result.Artifacts, c.Artifacts...)
+	result.MavenCache = c.MavenCache.Status
 
 	t.log.Debugf("dependencies: %s", t.task.Dependencies)
 	t.log.Debugf("artifacts: %s", artifactIDs(c.Artifacts))
@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+// MavenCacheDir is the path where the Maven cache volume is mounted into the builder Pods.
+const MavenCacheDir = "/var/cache/camel-k/maven"
+
+func init() {
+	registerSteps(MavenCache)
+
+	MavenCache.CommonSteps = []Step{
+		MavenCache.ConfigureMavenCache,
+		MavenCache.PublishMavenCache,
+	}
+}
+
+type mavenCacheSteps struct {
+	ConfigureMavenCache Step
+	PublishMavenCache   Step
+
+	CommonSteps []Step
+}
+
+var MavenCache = mavenCacheSteps{
+	ConfigureMavenCache: NewStep(InitPhase, configureMavenCache),
+	PublishMavenCache:   NewStep(ProjectBuildPhase+2, publishMavenCache),
+}
+
+func configureMavenCache(ctx *builderContext) error {
+	if ctx.Build.Maven.Cache == nil {
+		return nil
+	}
+	// The cache volume is only mounted into the builder Pods
+	if _, err := os.Stat(MavenCacheDir); os.IsNotExist(err) {
+		log.Infof("Maven cache directory %s not found, skipping", MavenCacheDir)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	ctx.MavenCache.Dir = MavenCacheDir
+	ctx.MavenCache.Since = time.Now()
+
+	return nil
+}
+
+// publishMavenCache publishes the artifacts resolved by the build into the cache, and evicts the least recently
+// used ones if needed. Failures are only logged, as they must not fail the build.
+func publishMavenCache(ctx *builderContext) error {
+	if ctx.MavenCache.Dir == "" {
+		return nil
+	}
+
+	status, err := maven.PublishToCache(mavenLocalRepository(ctx), ctx.MavenCache.Dir, ctx.MavenCache.Since)
+	if err != nil {
+		log.Errorf(err, "cannot publish artifacts to Maven cache %s", ctx.MavenCache.Dir)
+		return nil
+	}
+	ctx.MavenCache.Status = &status
+	log.Infof("Maven cache hits: %d, misses: %d", status.Hits, status.Misses)
+
+	if ctx.Build.Maven.Cache == nil || ctx.Build.Maven.Cache.MaxSize == "" {
+		return nil
+	}
+	maxSize, err := k8sresource.ParseQuantity(ctx.Build.Maven.Cache.MaxSize)
+	if err != nil {
+		log.Errorf(err, "invalid Maven cache max size %s", ctx.Build.Maven.Cache.MaxSize)
+		return nil
+	}
+	deleted, err := maven.EvictFromCache(ctx.MavenCache.Dir, maxSize.Value())
+	if err != nil {
+		log.Errorf(err, "cannot evict artifacts from Maven cache %s", ctx.MavenCache.Dir)
+	} else if deleted > 0 {
+		log.Infof("%d artifacts evicted from Maven cache", deleted)
+	}
+
+	return nil
+}
+
+// mavenLocalRepository returns the local repository used by the Maven commands.
+func mavenLocalRepository(ctx *builderContext) string {
+	if localRepository := ctx.Build.Maven.LocalRepository; localRepository != "" {
+		if _, err := os.Stat(localRepository); err == nil {
+			return localRepository
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ctx.Path
+	}
+
+	return filepath.Join(home, ".m2", "repository")
+}
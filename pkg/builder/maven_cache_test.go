@@ -0,0 +1,146 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+func TestConfigureMavenCacheNotMounted(t *testing.T) {
+	ctx := builderContext{
+		Build: v1.BuilderTask{
+			Maven: v1.MavenBuildSpec{
+				MavenSpec: v1.MavenSpec{
+					Cache: &v1.MavenCacheSpec{},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, configureMavenCache(&ctx))
+	assert.Empty(t, ctx.MavenCache.Dir)
+	require.NoError(t, publishMavenCache(&ctx))
+	assert.Nil(t, ctx.MavenCache.Status)
+}
+
+func TestMavenCacheRepositorySettings(t *testing.T) {
+	c, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	ctx := builderContext{
+		Client:    c,
+		C:         context.TODO(),
+		Namespace: "ns",
+	}
+	ctx.MavenCache.Dir = "/cache"
+
+	require.NoError(t, generateProjectSettings(&ctx))
+	assert.Contains(t, string(ctx.Maven.GlobalSettings), "<id>"+maven.CacheRepositoryID+"</id>")
+	assert.Contains(t, string(ctx.Maven.GlobalSettings), "<url>file:///cache</url>")
+}
+
+func TestMavenCacheExcludedFromMirrors(t *testing.T) {
+	c, err := test.NewFakeClient(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "maven-settings",
+			},
+			Data: map[string]string{
+				"settings.xml": "<settings><mirrors><mirror><mirrorOf>*</mirrorOf></mirror></mirrors></settings>",
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	ctx := builderContext{
+		Client:    c,
+		C:         context.TODO(),
+		Namespace: "ns",
+		Build: v1.BuilderTask{
+			Maven: v1.MavenBuildSpec{
+				MavenSpec: v1.MavenSpec{
+					Settings: v1.ValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "maven-settings",
+							},
+							Key: "settings.xml",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, generateProjectSettings(&ctx))
+	assert.Equal(t, "<settings><mirrors><mirror><mirrorOf>*</mirrorOf></mirror></mirrors></settings>", string(ctx.Maven.UserSettings))
+
+	// The mirrors matching all the repositories do not bypass the cache
+	ctx.MavenCache.Dir = "/cache"
+	require.NoError(t, generateProjectSettings(&ctx))
+	assert.Equal(t, "<settings><mirrors><mirror><mirrorOf>*,!"+maven.CacheRepositoryID+"</mirrorOf></mirror></mirrors></settings>", string(ctx.Maven.UserSettings))
+}
+
+func TestPublishMavenCache(t *testing.T) {
+	local := t.TempDir()
+	cache := t.TempDir()
+
+	ctx := builderContext{
+		Build: v1.BuilderTask{
+			Maven: v1.MavenBuildSpec{
+				MavenSpec: v1.MavenSpec{
+					LocalRepository: local,
+					Cache: &v1.MavenCacheSpec{
+						MaxSize: "12",
+					},
+				},
+			},
+		},
+	}
+	ctx.MavenCache.Dir = cache
+	ctx.MavenCache.Since = time.Now()
+
+	dir := filepath.Join(local, "org", "foo", "1.0")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo-1.0.jar"), []byte("foo"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo-1.0.pom"), []byte("<project/>"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "_remote.repositories"), []byte("foo-1.0.jar>central=\nfoo-1.0.pom>central=\n"), 0o600))
+
+	require.NoError(t, publishMavenCache(&ctx))
+	require.NotNil(t, ctx.MavenCache.Status)
+	assert.Equal(t, int32(0), ctx.MavenCache.Status.Hits)
+	assert.Equal(t, int32(2), ctx.MavenCache.Status.Misses)
+	// The artifacts exceed the cache max size
+	files, err := filepath.Glob(filepath.Join(cache, "org", "foo", "1.0", "*"))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
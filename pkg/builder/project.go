@@ -105,7 +105,14 @@ func generateProjectSettings(ctx *builderContext) error {
 		ctx.Maven.UserSettings = []byte(val)
 	}
 
-	settings, err := maven.NewSettings(maven.DefaultRepositories, maven.ProxyFromEnvironment)
+	options := []maven.SettingsOption{maven.DefaultRepositories, maven.ProxyFromEnvironment}
+	if ctx.MavenCache.Dir != "" {
+		options = append(options, maven.CacheRepository(ctx.MavenCache.Dir))
+		if ctx.Maven.UserSettings != nil {
+			ctx.Maven.UserSettings = []byte(maven.ExcludeCacheFromMirrors(string(ctx.Maven.UserSettings)))
+		}
+	}
+	settings, err := maven.NewSettings(options...)
 	if err != nil {
 		return err
 	}
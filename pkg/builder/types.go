@@ -19,6 +19,7 @@ package builder
 
 import (
 	"context"
+	"time"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/v2/pkg/client"
@@ -92,4 +93,9 @@ type builderContext struct {
 		TrustStoreName   string
 		TrustStorePass   string
 	}
+	MavenCache struct {
+		Dir    string
+		Since  time.Time
+		Status *v1.MavenCacheStatus
+	}
 }
@@ -32,6 +32,8 @@ type BuildSpecApplyConfiguration struct {
 	BuilderPodNamespace *string                               `json:"operatorNamespace,omitempty"`
 	Timeout             *metav1.Duration                      `json:"timeout,omitempty"`
 	MaxRunningBuilds    *int32                                `json:"maxRunningBuilds,omitempty"`
+	Priority            *int32                                `json:"priority,omitempty"`
+	Cancel              *bool                                 `json:"cancel,omitempty"`
 }
 
 // BuildSpecApplyConfiguration constructs an declarative configuration of the BuildSpec type for use with
@@ -92,3 +94,19 @@ func (b *BuildSpecApplyConfiguration) WithMaxRunningBuilds(value int32) *BuildSp
 	b.MaxRunningBuilds = &value
 	return b
 }
+
+// WithPriority sets the Priority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Priority field is set to the value of the last call.
+func (b *BuildSpecApplyConfiguration) WithPriority(value int32) *BuildSpecApplyConfiguration {
+	b.Priority = &value
+	return b
+}
+
+// WithCancel sets the Cancel field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cancel field is set to the value of the last call.
+func (b *BuildSpecApplyConfiguration) WithCancel(value bool) *BuildSpecApplyConfiguration {
+	b.Cancel = &value
+	return b
+}
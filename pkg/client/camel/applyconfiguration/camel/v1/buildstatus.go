@@ -27,18 +27,19 @@ import (
 // BuildStatusApplyConfiguration represents an declarative configuration of the BuildStatus type for use
 // with apply.
 type BuildStatusApplyConfiguration struct {
-	ObservedGeneration *int64                             `json:"observedGeneration,omitempty"`
-	Phase              *v1.BuildPhase                     `json:"phase,omitempty"`
-	Image              *string                            `json:"image,omitempty"`
-	Digest             *string                            `json:"digest,omitempty"`
-	RootImage          *string                            `json:"rootImage,omitempty"`
-	BaseImage          *string                            `json:"baseImage,omitempty"`
-	Artifacts          []ArtifactApplyConfiguration       `json:"artifacts,omitempty"`
-	Error              *string                            `json:"error,omitempty"`
-	Failure            *FailureApplyConfiguration         `json:"failure,omitempty"`
-	StartedAt          *metav1.Time                       `json:"startedAt,omitempty"`
-	Conditions         []BuildConditionApplyConfiguration `json:"conditions,omitempty"`
-	Duration           *string                            `json:"duration,omitempty"`
+	ObservedGeneration *int64                              `json:"observedGeneration,omitempty"`
+	Phase              *v1.BuildPhase                      `json:"phase,omitempty"`
+	Image              *string                             `json:"image,omitempty"`
+	Digest             *string                             `json:"digest,omitempty"`
+	RootImage          *string                             `json:"rootImage,omitempty"`
+	BaseImage          *string                             `json:"baseImage,omitempty"`
+	Artifacts          []ArtifactApplyConfiguration        `json:"artifacts,omitempty"`
+	Error              *string                             `json:"error,omitempty"`
+	Failure            *FailureApplyConfiguration          `json:"failure,omitempty"`
+	StartedAt          *metav1.Time                        `json:"startedAt,omitempty"`
+	Conditions         []BuildConditionApplyConfiguration  `json:"conditions,omitempty"`
+	Duration           *string                             `json:"duration,omitempty"`
+	MavenCache         *MavenCacheStatusApplyConfiguration `json:"mavenCache,omitempty"`
 }
 
 // BuildStatusApplyConfiguration constructs an declarative configuration of the BuildStatus type for use with
@@ -152,3 +153,11 @@ func (b *BuildStatusApplyConfiguration) WithDuration(value string) *BuildStatusA
 	b.Duration = &value
 	return b
 }
+
+// WithMavenCache sets the MavenCache field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MavenCache field is set to the value of the last call.
+func (b *BuildStatusApplyConfiguration) WithMavenCache(value *MavenCacheStatusApplyConfiguration) *BuildStatusApplyConfiguration {
+	b.MavenCache = value
+	return b
+}
@@ -29,6 +29,7 @@ import (
 type EndpointApplyConfiguration struct {
 	Ref        *v1.ObjectReference                                          `json:"ref,omitempty"`
 	URI        *string                                                      `json:"uri,omitempty"`
+	Inline     *FlowApplyConfiguration                                      `json:"inline,omitempty"`
 	Properties *EndpointPropertiesApplyConfiguration                        `json:"properties,omitempty"`
 	DataTypes  map[apiscamelv1.TypeSlot]DataTypeReferenceApplyConfiguration `json:"dataTypes,omitempty"`
 }
@@ -55,6 +56,14 @@ func (b *EndpointApplyConfiguration) WithURI(value string) *EndpointApplyConfigu
 	return b
 }
 
+// WithInline sets the Inline field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Inline field is set to the value of the last call.
+func (b *EndpointApplyConfiguration) WithInline(value *FlowApplyConfiguration) *EndpointApplyConfiguration {
+	b.Inline = value
+	return b
+}
+
 // WithProperties sets the Properties field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Properties field is set to the value of the last call.
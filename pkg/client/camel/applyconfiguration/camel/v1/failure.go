@@ -20,15 +20,18 @@ limitations under the License.
 package v1
 
 import (
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // FailureApplyConfiguration represents an declarative configuration of the Failure type for use
 // with apply.
 type FailureApplyConfiguration struct {
 	Reason   *string                            `json:"reason,omitempty"`
-	Time     *v1.Time                           `json:"time,omitempty"`
+	Time     *metav1.Time                       `json:"time,omitempty"`
 	Recovery *FailureRecoveryApplyConfiguration `json:"recovery,omitempty"`
+	Category *v1.FailureCategory                `json:"category,omitempty"`
+	Code     *string                            `json:"code,omitempty"`
 }
 
 // FailureApplyConfiguration constructs an declarative configuration of the Failure type for use with
@@ -48,7 +51,7 @@ func (b *FailureApplyConfiguration) WithReason(value string) *FailureApplyConfig
 // WithTime sets the Time field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Time field is set to the value of the last call.
-func (b *FailureApplyConfiguration) WithTime(value v1.Time) *FailureApplyConfiguration {
+func (b *FailureApplyConfiguration) WithTime(value metav1.Time) *FailureApplyConfiguration {
 	b.Time = &value
 	return b
 }
@@ -60,3 +63,19 @@ func (b *FailureApplyConfiguration) WithRecovery(value *FailureRecoveryApplyConf
 	b.Recovery = value
 	return b
 }
+
+// WithCategory sets the Category field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Category field is set to the value of the last call.
+func (b *FailureApplyConfiguration) WithCategory(value v1.FailureCategory) *FailureApplyConfiguration {
+	b.Category = &value
+	return b
+}
+
+// WithCode sets the Code field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Code field is set to the value of the last call.
+func (b *FailureApplyConfiguration) WithCode(value string) *FailureApplyConfiguration {
+	b.Code = &value
+	return b
+}
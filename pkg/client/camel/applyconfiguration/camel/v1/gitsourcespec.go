@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GitSourceSpecApplyConfiguration represents an declarative configuration of the GitSourceSpec type for use
+// with apply.
+type GitSourceSpecApplyConfiguration struct {
+	URL          *string          `json:"url,omitempty"`
+	Ref          *string          `json:"ref,omitempty"`
+	Path         *string          `json:"path,omitempty"`
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// GitSourceSpecApplyConfiguration constructs an declarative configuration of the GitSourceSpec type for use with
+// apply.
+func GitSourceSpec() *GitSourceSpecApplyConfiguration {
+	return &GitSourceSpecApplyConfiguration{}
+}
+
+// WithURL sets the URL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URL field is set to the value of the last call.
+func (b *GitSourceSpecApplyConfiguration) WithURL(value string) *GitSourceSpecApplyConfiguration {
+	b.URL = &value
+	return b
+}
+
+// WithRef sets the Ref field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ref field is set to the value of the last call.
+func (b *GitSourceSpecApplyConfiguration) WithRef(value string) *GitSourceSpecApplyConfiguration {
+	b.Ref = &value
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *GitSourceSpecApplyConfiguration) WithPath(value string) *GitSourceSpecApplyConfiguration {
+	b.Path = &value
+	return b
+}
+
+// WithPollInterval sets the PollInterval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PollInterval field is set to the value of the last call.
+func (b *GitSourceSpecApplyConfiguration) WithPollInterval(value metav1.Duration) *GitSourceSpecApplyConfiguration {
+	b.PollInterval = &value
+	return b
+}
@@ -21,6 +21,7 @@ package v1
 
 import (
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // IntegrationKitStatusApplyConfiguration represents an declarative configuration of the IntegrationKitStatus type for use
@@ -39,6 +40,7 @@ type IntegrationKitStatusApplyConfiguration struct {
 	Platform           *string                                     `json:"platform,omitempty"`
 	Version            *string                                     `json:"version,omitempty"`
 	Conditions         []IntegrationKitConditionApplyConfiguration `json:"conditions,omitempty"`
+	LastUsedTimestamp  *metav1.Time                                `json:"lastUsedTimestamp,omitempty"`
 }
 
 // IntegrationKitStatusApplyConfiguration constructs an declarative configuration of the IntegrationKitStatus type for use with
@@ -160,3 +162,11 @@ func (b *IntegrationKitStatusApplyConfiguration) WithConditions(values ...*Integ
 	}
 	return b
 }
+
+// WithLastUsedTimestamp sets the LastUsedTimestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUsedTimestamp field is set to the value of the last call.
+func (b *IntegrationKitStatusApplyConfiguration) WithLastUsedTimestamp(value metav1.Time) *IntegrationKitStatusApplyConfiguration {
+	b.LastUsedTimestamp = &value
+	return b
+}
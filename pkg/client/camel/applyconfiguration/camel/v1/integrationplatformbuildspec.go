@@ -27,17 +27,20 @@ import (
 // IntegrationPlatformBuildSpecApplyConfiguration represents an declarative configuration of the IntegrationPlatformBuildSpec type for use
 // with apply.
 type IntegrationPlatformBuildSpecApplyConfiguration struct {
-	BuildConfiguration      *BuildConfigurationApplyConfiguration            `json:"buildConfiguration,omitempty"`
-	PublishStrategy         *camelv1.IntegrationPlatformBuildPublishStrategy `json:"publishStrategy,omitempty"`
-	RuntimeVersion          *string                                          `json:"runtimeVersion,omitempty"`
-	RuntimeProvider         *camelv1.RuntimeProvider                         `json:"runtimeProvider,omitempty"`
-	BaseImage               *string                                          `json:"baseImage,omitempty"`
-	Registry                *RegistrySpecApplyConfiguration                  `json:"registry,omitempty"`
-	BuildCatalogToolTimeout *metav1.Duration                                 `json:"buildCatalogToolTimeout,omitempty"`
-	Timeout                 *metav1.Duration                                 `json:"timeout,omitempty"`
-	Maven                   *MavenSpecApplyConfiguration                     `json:"maven,omitempty"`
-	PublishStrategyOptions  map[string]string                                `json:"PublishStrategyOptions,omitempty"`
-	MaxRunningBuilds        *int32                                           `json:"maxRunningBuilds,omitempty"`
+	BuildConfiguration           *BuildConfigurationApplyConfiguration            `json:"buildConfiguration,omitempty"`
+	PublishStrategy              *camelv1.IntegrationPlatformBuildPublishStrategy `json:"publishStrategy,omitempty"`
+	RuntimeVersion               *string                                          `json:"runtimeVersion,omitempty"`
+	RuntimeProvider              *camelv1.RuntimeProvider                         `json:"runtimeProvider,omitempty"`
+	BaseImage                    *string                                          `json:"baseImage,omitempty"`
+	Registry                     *RegistrySpecApplyConfiguration                  `json:"registry,omitempty"`
+	BuildCatalogToolTimeout      *metav1.Duration                                 `json:"buildCatalogToolTimeout,omitempty"`
+	Timeout                      *metav1.Duration                                 `json:"timeout,omitempty"`
+	Maven                        *MavenSpecApplyConfiguration                     `json:"maven,omitempty"`
+	PublishStrategyOptions       map[string]string                                `json:"PublishStrategyOptions,omitempty"`
+	MaxRunningBuilds             *int32                                           `json:"maxRunningBuilds,omitempty"`
+	KitRetention                 *IntegrationKitRetentionSpecApplyConfiguration   `json:"kitRetention,omitempty"`
+	MaxRunningBuildsPerNamespace *int32                                           `json:"maxRunningBuildsPerNamespace,omitempty"`
+	Priority                     *int32                                           `json:"priority,omitempty"`
 }
 
 // IntegrationPlatformBuildSpecApplyConfiguration constructs an declarative configuration of the IntegrationPlatformBuildSpec type for use with
@@ -139,3 +142,27 @@ func (b *IntegrationPlatformBuildSpecApplyConfiguration) WithMaxRunningBuilds(va
 	b.MaxRunningBuilds = &value
 	return b
 }
+
+// WithKitRetention sets the KitRetention field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KitRetention field is set to the value of the last call.
+func (b *IntegrationPlatformBuildSpecApplyConfiguration) WithKitRetention(value *IntegrationKitRetentionSpecApplyConfiguration) *IntegrationPlatformBuildSpecApplyConfiguration {
+	b.KitRetention = value
+	return b
+}
+
+// WithMaxRunningBuildsPerNamespace sets the MaxRunningBuildsPerNamespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxRunningBuildsPerNamespace field is set to the value of the last call.
+func (b *IntegrationPlatformBuildSpecApplyConfiguration) WithMaxRunningBuildsPerNamespace(value int32) *IntegrationPlatformBuildSpecApplyConfiguration {
+	b.MaxRunningBuildsPerNamespace = &value
+	return b
+}
+
+// WithPriority sets the Priority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Priority field is set to the value of the last call.
+func (b *IntegrationPlatformBuildSpecApplyConfiguration) WithPriority(value int32) *IntegrationPlatformBuildSpecApplyConfiguration {
+	b.Priority = &value
+	return b
+}
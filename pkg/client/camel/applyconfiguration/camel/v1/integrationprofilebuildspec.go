@@ -27,12 +27,14 @@ import (
 // IntegrationProfileBuildSpecApplyConfiguration represents an declarative configuration of the IntegrationProfileBuildSpec type for use
 // with apply.
 type IntegrationProfileBuildSpecApplyConfiguration struct {
-	RuntimeVersion  *string                         `json:"runtimeVersion,omitempty"`
-	RuntimeProvider *v1.RuntimeProvider             `json:"runtimeProvider,omitempty"`
-	BaseImage       *string                         `json:"baseImage,omitempty"`
-	Registry        *RegistrySpecApplyConfiguration `json:"registry,omitempty"`
-	Timeout         *metav1.Duration                `json:"timeout,omitempty"`
-	Maven           *MavenSpecApplyConfiguration    `json:"maven,omitempty"`
+	RuntimeVersion  *string                                        `json:"runtimeVersion,omitempty"`
+	RuntimeProvider *v1.RuntimeProvider                            `json:"runtimeProvider,omitempty"`
+	BaseImage       *string                                        `json:"baseImage,omitempty"`
+	Registry        *RegistrySpecApplyConfiguration                `json:"registry,omitempty"`
+	Timeout         *metav1.Duration                               `json:"timeout,omitempty"`
+	Maven           *MavenSpecApplyConfiguration                   `json:"maven,omitempty"`
+	KitRetention    *IntegrationKitRetentionSpecApplyConfiguration `json:"kitRetention,omitempty"`
+	Priority        *int32                                         `json:"priority,omitempty"`
 }
 
 // IntegrationProfileBuildSpecApplyConfiguration constructs an declarative configuration of the IntegrationProfileBuildSpec type for use with
@@ -88,3 +90,19 @@ func (b *IntegrationProfileBuildSpecApplyConfiguration) WithMaven(value *MavenSp
 	b.Maven = value
 	return b
 }
+
+// WithKitRetention sets the KitRetention field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KitRetention field is set to the value of the last call.
+func (b *IntegrationProfileBuildSpecApplyConfiguration) WithKitRetention(value *IntegrationKitRetentionSpecApplyConfiguration) *IntegrationProfileBuildSpecApplyConfiguration {
+	b.KitRetention = value
+	return b
+}
+
+// WithPriority sets the Priority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Priority field is set to the value of the last call.
+func (b *IntegrationProfileBuildSpecApplyConfiguration) WithPriority(value int32) *IntegrationProfileBuildSpecApplyConfiguration {
+	b.Priority = &value
+	return b
+}
@@ -29,6 +29,7 @@ import (
 type IntegrationSpecApplyConfiguration struct {
 	Replicas           *int32                                `json:"replicas,omitempty"`
 	Sources            []SourceSpecApplyConfiguration        `json:"sources,omitempty"`
+	Git                *GitSourceSpecApplyConfiguration      `json:"git,omitempty"`
 	Flows              []FlowApplyConfiguration              `json:"flows,omitempty"`
 	IntegrationKit     *corev1.ObjectReference               `json:"integrationKit,omitempty"`
 	Dependencies       []string                              `json:"dependencies,omitempty"`
@@ -67,6 +68,14 @@ func (b *IntegrationSpecApplyConfiguration) WithSources(values ...*SourceSpecApp
 	return b
 }
 
+// WithGit sets the Git field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Git field is set to the value of the last call.
+func (b *IntegrationSpecApplyConfiguration) WithGit(value *GitSourceSpecApplyConfiguration) *IntegrationSpecApplyConfiguration {
+	b.Git = value
+	return b
+}
+
 // WithFlows adds the given value to the Flows field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Flows field.
@@ -37,6 +37,8 @@ type IntegrationStatusApplyConfiguration struct {
 	IntegrationKit          *corev1.ObjectReference                  `json:"integrationKit,omitempty"`
 	Platform                *string                                  `json:"platform,omitempty"`
 	GeneratedSources        []SourceSpecApplyConfiguration           `json:"generatedSources,omitempty"`
+	GitCommit               *string                                  `json:"gitCommit,omitempty"`
+	GitLastCheckTime        *metav1.Time                             `json:"gitLastCheckTime,omitempty"`
 	RuntimeVersion          *string                                  `json:"runtimeVersion,omitempty"`
 	RuntimeProvider         *v1.RuntimeProvider                      `json:"runtimeProvider,omitempty"`
 	Configuration           []ConfigurationSpecApplyConfiguration    `json:"configuration,omitempty"`
@@ -133,6 +135,22 @@ func (b *IntegrationStatusApplyConfiguration) WithGeneratedSources(values ...*So
 	return b
 }
 
+// WithGitCommit sets the GitCommit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GitCommit field is set to the value of the last call.
+func (b *IntegrationStatusApplyConfiguration) WithGitCommit(value string) *IntegrationStatusApplyConfiguration {
+	b.GitCommit = &value
+	return b
+}
+
+// WithGitLastCheckTime sets the GitLastCheckTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GitLastCheckTime field is set to the value of the last call.
+func (b *IntegrationStatusApplyConfiguration) WithGitLastCheckTime(value metav1.Time) *IntegrationStatusApplyConfiguration {
+	b.GitLastCheckTime = &value
+	return b
+}
+
 // WithRuntimeVersion sets the RuntimeVersion field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RuntimeVersion field is set to the value of the last call.
@@ -121,6 +121,14 @@ func (b *MavenBuildSpecApplyConfiguration) WithCLIOptions(values ...string) *Mav
 	return b
 }
 
+// WithCache sets the Cache field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cache field is set to the value of the last call.
+func (b *MavenBuildSpecApplyConfiguration) WithCache(value *MavenCacheSpecApplyConfiguration) *MavenBuildSpecApplyConfiguration {
+	b.Cache = value
+	return b
+}
+
 // WithRepositories adds the given value to the Repositories field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Repositories field.
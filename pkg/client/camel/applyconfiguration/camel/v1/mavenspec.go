@@ -34,6 +34,7 @@ type MavenSpecApplyConfiguration struct {
 	CASecrets        []corev1.SecretKeySelector        `json:"caSecrets,omitempty"`
 	Extension        []MavenArtifactApplyConfiguration `json:"extension,omitempty"`
 	CLIOptions       []string                          `json:"cliOptions,omitempty"`
+	Cache            *MavenCacheSpecApplyConfiguration `json:"cache,omitempty"`
 }
 
 // MavenSpecApplyConfiguration constructs an declarative configuration of the MavenSpec type for use with
@@ -125,3 +126,11 @@ func (b *MavenSpecApplyConfiguration) WithCLIOptions(values ...string) *MavenSpe
 	}
 	return b
 }
+
+// WithCache sets the Cache field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cache field is set to the value of the last call.
+func (b *MavenSpecApplyConfiguration) WithCache(value *MavenCacheSpecApplyConfiguration) *MavenSpecApplyConfiguration {
+	b.Cache = value
+	return b
+}
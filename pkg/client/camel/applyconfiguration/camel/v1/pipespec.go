@@ -29,6 +29,7 @@ type PipeSpecApplyConfiguration struct {
 	Steps              []EndpointApplyConfiguration        `json:"steps,omitempty"`
 	Replicas           *int32                              `json:"replicas,omitempty"`
 	ServiceAccountName *string                             `json:"serviceAccountName,omitempty"`
+	Routing            *PipeRoutingApplyConfiguration      `json:"routing,omitempty"`
 }
 
 // PipeSpecApplyConfiguration constructs an declarative configuration of the PipeSpec type for use with
@@ -97,3 +98,11 @@ func (b *PipeSpecApplyConfiguration) WithServiceAccountName(value string) *PipeS
 	b.ServiceAccountName = &value
 	return b
 }
+
+// WithRouting sets the Routing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Routing field is set to the value of the last call.
+func (b *PipeSpecApplyConfiguration) WithRouting(value *PipeRoutingApplyConfiguration) *PipeSpecApplyConfiguration {
+	b.Routing = value
+	return b
+}
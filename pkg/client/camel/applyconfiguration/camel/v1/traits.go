@@ -36,8 +36,10 @@ type TraitsApplyConfiguration struct {
 	Deployment     *trait.DeploymentTrait                  `json:"deployment,omitempty"`
 	Environment    *trait.EnvironmentTrait                 `json:"environment,omitempty"`
 	ErrorHandler   *trait.ErrorHandlerTrait                `json:"error-handler,omitempty"`
+	Gateway        *trait.GatewayTrait                     `json:"gateway,omitempty"`
 	GC             *trait.GCTrait                          `json:"gc,omitempty"`
 	Health         *trait.HealthTrait                      `json:"health,omitempty"`
+	HPA            *trait.HPATrait                         `json:"hpa,omitempty"`
 	Ingress        *trait.IngressTrait                     `json:"ingress,omitempty"`
 	Istio          *trait.IstioTrait                       `json:"istio,omitempty"`
 	Jolokia        *trait.JolokiaTrait                     `json:"jolokia,omitempty"`
@@ -47,6 +49,7 @@ type TraitsApplyConfiguration struct {
 	KnativeService *trait.KnativeServiceTrait              `json:"knative-service,omitempty"`
 	Logging        *trait.LoggingTrait                     `json:"logging,omitempty"`
 	Mount          *trait.MountTrait                       `json:"mount,omitempty"`
+	NetworkPolicy  *trait.NetworkPolicyTrait               `json:"network-policy,omitempty"`
 	OpenAPI        *trait.OpenAPITrait                     `json:"openapi,omitempty"`
 	Owner          *trait.OwnerTrait                       `json:"owner,omitempty"`
 	PDB            *trait.PDBTrait                         `json:"pdb,omitempty"`
@@ -154,6 +157,14 @@ func (b *TraitsApplyConfiguration) WithErrorHandler(value trait.ErrorHandlerTrai
 	return b
 }
 
+// WithGateway sets the Gateway field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Gateway field is set to the value of the last call.
+func (b *TraitsApplyConfiguration) WithGateway(value trait.GatewayTrait) *TraitsApplyConfiguration {
+	b.Gateway = &value
+	return b
+}
+
 // WithGC sets the GC field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the GC field is set to the value of the last call.
@@ -170,6 +181,14 @@ func (b *TraitsApplyConfiguration) WithHealth(value trait.HealthTrait) *TraitsAp
 	return b
 }
 
+// WithHPA sets the HPA field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HPA field is set to the value of the last call.
+func (b *TraitsApplyConfiguration) WithHPA(value trait.HPATrait) *TraitsApplyConfiguration {
+	b.HPA = &value
+	return b
+}
+
 // WithIngress sets the Ingress field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Ingress field is set to the value of the last call.
@@ -242,6 +261,14 @@ func (b *TraitsApplyConfiguration) WithMount(value trait.MountTrait) *TraitsAppl
 	return b
 }
 
+// WithNetworkPolicy sets the NetworkPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NetworkPolicy field is set to the value of the last call.
+func (b *TraitsApplyConfiguration) WithNetworkPolicy(value trait.NetworkPolicyTrait) *TraitsApplyConfiguration {
+	b.NetworkPolicy = &value
+	return b
+}
+
 // WithOpenAPI sets the OpenAPI field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the OpenAPI field is set to the value of the last call.
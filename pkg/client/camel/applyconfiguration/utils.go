@@ -100,6 +100,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &camelv1.FailureRecoveryApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Flow"):
 		return &camelv1.FlowApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GitSourceSpec"):
+		return &camelv1.GitSourceSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HeaderSpec"):
 		return &camelv1.HeaderSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HealthCheckResponse"):
@@ -112,6 +114,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &camelv1.IntegrationKitApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("IntegrationKitCondition"):
 		return &camelv1.IntegrationKitConditionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("IntegrationKitRetentionSpec"):
+		return &camelv1.IntegrationKitRetentionSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("IntegrationKitSpec"):
 		return &camelv1.IntegrationKitSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("IntegrationKitStatus"):
@@ -164,6 +168,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &camelv1.KameletRepositorySpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("KameletSpec"):
 		return &camelv1.KameletSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("KameletSpecBase"):
+		return &camelv1.KameletSpecBaseApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("KameletStatus"):
 		return &camelv1.KameletStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("KanikoTask"):
@@ -174,12 +180,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &camelv1.MavenArtifactApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("MavenBuildSpec"):
 		return &camelv1.MavenBuildSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MavenCacheSpec"):
+		return &camelv1.MavenCacheSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MavenCacheStatus"):
+		return &camelv1.MavenCacheStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("MavenSpec"):
 		return &camelv1.MavenSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Pipe"):
 		return &camelv1.PipeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PipeBranch"):
+		return &camelv1.PipeBranchApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PipeCondition"):
 		return &camelv1.PipeConditionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PipePredicate"):
+		return &camelv1.PipePredicateApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PipeRouting"):
+		return &camelv1.PipeRoutingApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PipeSpec"):
 		return &camelv1.PipeSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PipeStatus"):
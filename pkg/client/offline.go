@@ -0,0 +1,144 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/scale"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/apache/camel-k/v2/pkg/apis"
+	fakecamelclientset "github.com/apache/camel-k/v2/pkg/client/camel/clientset/versioned/fake"
+	camelv1 "github.com/apache/camel-k/v2/pkg/client/camel/clientset/versioned/typed/camel/v1"
+	camelv1alpha1 "github.com/apache/camel-k/v2/pkg/client/camel/clientset/versioned/typed/camel/v1alpha1"
+)
+
+// offlineClient is an in-memory client, used to run the operator logic without any cluster.
+type offlineClient struct {
+	ctrl.Client
+	kubernetes.Interface
+	camel  *fakecamelclientset.Clientset
+	scheme *runtime.Scheme
+}
+
+// Check interface compliance.
+var _ Client = &offlineClient{}
+
+// NewOfflineClient creates a client backed by an in-memory object tracker, pre-populated with the given objects.
+// The client does not discover any optional API group, so the environment it represents is a plain Kubernetes cluster.
+func NewOfflineClient(initObjs ...runtime.Object) (Client, error) {
+	clientScheme := scheme.Scheme
+
+	// Setup Scheme for all resources
+	if err := apis.AddToScheme(clientScheme); err != nil {
+		return nil, err
+	}
+
+	c := fake.
+		NewClientBuilder().
+		WithScheme(clientScheme).
+		WithRuntimeObjects(initObjs...).
+		Build()
+
+	camelObjs := make([]runtime.Object, 0)
+	kubeObjs := make([]runtime.Object, 0)
+	for _, obj := range initObjs {
+		kinds, _, err := clientScheme.ObjectKinds(obj)
+		if err != nil {
+			return nil, err
+		}
+		if isCamelGroup(kinds) {
+			camelObjs = append(camelObjs, obj)
+		} else {
+			kubeObjs = append(kubeObjs, obj)
+		}
+	}
+
+	return &offlineClient{
+		Client:    c,
+		Interface: fakeclientset.NewSimpleClientset(kubeObjs...),
+		camel:     fakecamelclientset.NewSimpleClientset(camelObjs...),
+		scheme:    clientScheme,
+	}, nil
+}
+
+func isCamelGroup(kinds []schema.GroupVersionKind) bool {
+	for _, k := range kinds {
+		if strings.Contains(k.Group, "camel") {
+			return true
+		}
+	}
+	return false
+}
+
+// Patch mimics server-side apply, which is not supported by the in-memory tracker, by creating or updating the object.
+func (c *offlineClient) Patch(ctx context.Context, obj ctrl.Object, patch ctrl.Patch, opts ...ctrl.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	if err := c.Create(ctx, obj); err != nil {
+		if !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+		return c.Update(ctx, obj)
+	}
+	return nil
+}
+
+func (c *offlineClient) CamelV1() camelv1.CamelV1Interface {
+	return c.camel.CamelV1()
+}
+
+func (c *offlineClient) CamelV1alpha1() camelv1alpha1.CamelV1alpha1Interface {
+	return c.camel.CamelV1alpha1()
+}
+
+func (c *offlineClient) GetScheme() *runtime.Scheme {
+	return c.scheme
+}
+
+func (c *offlineClient) GetConfig() *rest.Config {
+	return nil
+}
+
+func (c *offlineClient) GetCurrentNamespace(kubeConfig string) (string, error) {
+	return "", nil
+}
+
+func (c *offlineClient) ServerOrClientSideApplier() ServerOrClientSideApplier {
+	return ServerOrClientSideApplier{
+		Client: c,
+	}
+}
+
+func (c *offlineClient) ScalesClient() (scale.ScalesGetter, error) {
+	return nil, errors.New("scale client is not available in offline mode")
+}
@@ -21,12 +21,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 
 	cclient "github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/kamelet/repository"
 	"github.com/apache/camel-k/v2/pkg/trait"
+	"github.com/apache/camel-k/v2/pkg/util"
+	"github.com/apache/camel-k/v2/pkg/util/bindings"
+	"github.com/apache/camel-k/v2/pkg/util/kamelets"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
 	"github.com/apache/camel-k/v2/pkg/util/reference"
 	"github.com/apache/camel-k/v2/pkg/util/uri"
@@ -54,7 +59,10 @@ func newCmdBind(rootCmdOptions *RootCmdOptions) (*cobra.Command, *bindCmdOptions
 	}
 
 	cmd.Flags().StringArrayP("connect", "c", nil, "A ServiceBinding or Provisioned Service that the integration should bind to, specified as [[apigroup/]version:]kind:[namespace/]name")
-	cmd.Flags().String("error-handler", "", `Add error handler (none|log|sink:<endpoint>). Sink endpoints are expected in the format "[[apigroup/]version:]kind:[namespace/]name", plain Camel URIs or Kamelet name.`)
+	cmd.Flags().String("error-handler", "", `Add error handler (none|log|sink:<endpoint>), optionally followed by comma separated options, e.g. "log,maximumRedeliveries=3,redeliveryDelay=2000". `+
+		`Option values containing commas must be quoted, e.g. "log,retryWhile='${header.code} in 500,503'". `+
+		`Sink endpoints are expected in the format "[[apigroup/]version:]kind:[namespace/]name", plain Camel URIs or Kamelet name. `+
+		`Supported options are maximumRedeliveries, redeliveryDelay, backOffMultiplier, retryWhile, logExhaustedOnly, circuitBreaker.failureThreshold, circuitBreaker.failureWindow and circuitBreaker.halfOpenAfter.`)
 	cmd.Flags().String("name", "", "Name for the binding")
 	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
 	cmd.Flags().StringArrayP("property", "p", nil, `Add a binding property in the form of "source.<key>=<value>", "sink.<key>=<value>", "error-handler.<key>=<value>" or "step-<n>.<key>=<value> where <n> is the step order starting from 1"`)
@@ -140,7 +148,7 @@ func (o *bindCmdOptions) validate(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		if err := o.checkCompliance(cmd, source); err != nil {
+		if err := o.checkCompliance(cmd, source, bindings.EndpointContext{Type: v1.EndpointTypeSource}); err != nil {
 			return err
 		}
 
@@ -148,19 +156,26 @@ func (o *bindCmdOptions) validate(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		if err := o.checkCompliance(cmd, sink); err != nil {
+		if err := o.checkCompliance(cmd, sink, bindings.EndpointContext{Type: v1.EndpointTypeSink}); err != nil {
 			return err
 		}
 
+		steps := make([]v1.Endpoint, 0, len(o.Steps))
 		for idx, stepDesc := range o.Steps {
-			stepKey := fmt.Sprintf("%s%d", stepKeyPrefix, idx)
+			stepKey := fmt.Sprintf("%s%d", stepKeyPrefix, idx+1)
 			step, err := o.decode(stepDesc, stepKey)
 			if err != nil {
 				return err
 			}
-			if err := o.checkCompliance(cmd, step); err != nil {
+			position := idx
+			if err := o.checkCompliance(cmd, step, bindings.EndpointContext{Type: v1.EndpointTypeAction, Position: &position}); err != nil {
 				return err
 			}
+			steps = append(steps, step)
+		}
+
+		if err := o.checkDataTypes(source, sink, steps); err != nil {
+			return err
 		}
 	}
 
@@ -295,26 +310,48 @@ func showPipeOutput(cmd *cobra.Command, binding *v1.Pipe, outputFormat string, s
 
 func (o *bindCmdOptions) parseErrorHandler() (*v1.ErrorHandlerSpec, error) {
 	errHandlMap := make(map[string]interface{})
-	errHandlType, errHandlValue, err := parseErrorHandlerByType(o.ErrorHandler)
+	errHandlDesc, options := splitErrorHandlerOptions(o.ErrorHandler)
+	errHandlType, errHandlValue, err := parseErrorHandlerByType(errHandlDesc)
 	if err != nil {
 		return nil, err
 	}
+	redelivery, circuitBreaker, err := parseErrorHandlerOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	noneErrorHandler := v1.ErrorHandlerNone{CircuitBreaker: circuitBreaker}
+	logErrorHandler := v1.ErrorHandlerLog{ErrorHandlerNone: noneErrorHandler, Redelivery: redelivery}
+	var errorHandler v1.ErrorHandler
 	switch errHandlType {
 	case "none":
+		if redelivery != nil {
+			return nil, fmt.Errorf("error handler type %s does not support redelivery options", errHandlType)
+		}
 		errHandlMap["none"] = nil
+		if circuitBreaker != nil {
+			errHandlMap["none"] = noneErrorHandler
+		}
+		errorHandler = noneErrorHandler
 	case "log":
 		errHandlMap["log"] = nil
+		if len(options) > 0 {
+			errHandlMap["log"] = logErrorHandler
+		}
+		errorHandler = logErrorHandler
 	case "sink":
 		sinkSpec, err := o.decode(errHandlValue, errorHandlerKey)
 		if err != nil {
 			return nil, err
 		}
-		errHandlMap["sink"] = map[string]interface{}{
-			"endpoint": sinkSpec,
-		}
+		sink := v1.ErrorHandlerSink{ErrorHandlerLog: logErrorHandler, DLCEndpoint: &sinkSpec}
+		errHandlMap["sink"] = sink
+		errorHandler = sink
 	default:
 		return nil, fmt.Errorf("invalid error handler type %s", o.ErrorHandler)
 	}
+	if err := errorHandler.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid error handler: %w", err)
+	}
 	errHandlMarshalled, err := json.Marshal(&errHandlMap)
 	if err != nil {
 		return nil, err
@@ -334,6 +371,114 @@ func parseErrorHandlerByType(value string) (string, string, error) {
 	return errHandlSplit[0], "", nil
 }
 
+// errorHandlerOptions are the options that can follow the error handler type, i.e., log,maximumRedeliveries=3.
+var errorHandlerOptions = []string{
+	"maximumRedeliveries", "redeliveryDelay", "backOffMultiplier", "retryWhile", "logExhaustedOnly",
+	"circuitBreaker.failureThreshold", "circuitBreaker.failureWindow", "circuitBreaker.halfOpenAfter",
+}
+
+// splitErrorHandlerOptions splits the trailing options from the error handler description.
+// Only the known options are split, so that the commas in sink endpoint URIs are preserved.
+// Option values containing commas, e.g., retryWhile expressions, can be enclosed in double or single quotes.
+func splitErrorHandlerOptions(value string) (string, map[string]string) {
+	options := make(map[string]string)
+	parts := splitUnquoted(value, ',')
+	for len(parts) > 1 {
+		option := strings.SplitN(parts[len(parts)-1], "=", 2)
+		if len(option) != 2 || !util.StringSliceExists(errorHandlerOptions, option[0]) {
+			break
+		}
+		options[option[0]] = unquote(option[1])
+		parts = parts[:len(parts)-1]
+	}
+	return strings.Join(parts, ","), options
+}
+
+// splitUnquoted splits the value around the separators that are not enclosed in double or single quotes.
+// The quotes are preserved, so that joining the parts back gives the original value.
+func splitUnquoted(value string, sep rune) []string {
+	var parts []string
+	var quote rune
+	start := 0
+	for i, c := range value {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == sep:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+// unquote removes the double or single quotes enclosing the value, if any.
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func parseErrorHandlerOptions(options map[string]string) (*v1.ErrorHandlerRedelivery, *v1.ErrorHandlerCircuitBreaker, error) {
+	var redelivery *v1.ErrorHandlerRedelivery
+	var circuitBreaker *v1.ErrorHandlerCircuitBreaker
+	for key, value := range options {
+		var err error
+		if strings.HasPrefix(key, "circuitBreaker.") {
+			if circuitBreaker == nil {
+				circuitBreaker = &v1.ErrorHandlerCircuitBreaker{}
+			}
+			switch key {
+			case "circuitBreaker.failureThreshold":
+				circuitBreaker.FailureThreshold, err = strconv.Atoi(value)
+			case "circuitBreaker.failureWindow":
+				circuitBreaker.FailureWindow, err = parseInt64Option(value)
+			case "circuitBreaker.halfOpenAfter":
+				circuitBreaker.HalfOpenAfter, err = parseInt64Option(value)
+			}
+		} else {
+			if redelivery == nil {
+				redelivery = &v1.ErrorHandlerRedelivery{}
+			}
+			switch key {
+			case "maximumRedeliveries":
+				var maximumRedeliveries int
+				maximumRedeliveries, err = strconv.Atoi(value)
+				redelivery.MaximumRedeliveries = &maximumRedeliveries
+			case "redeliveryDelay":
+				redelivery.RedeliveryDelay, err = parseInt64Option(value)
+			case "backOffMultiplier":
+				var backOffMultiplier float64
+				backOffMultiplier, err = strconv.ParseFloat(value, 64)
+				redelivery.BackOffMultiplier = &backOffMultiplier
+			case "retryWhile":
+				redelivery.RetryWhile = &v1.PipePredicate{Expression: value}
+				if expression := strings.TrimPrefix(value, "jsonpath:"); expression != value {
+					redelivery.RetryWhile = &v1.PipePredicate{Language: v1.PipePredicateLanguageJSONPath, Expression: expression}
+				}
+			case "logExhaustedOnly":
+				var logExhaustedOnly bool
+				logExhaustedOnly, err = strconv.ParseBool(value)
+				redelivery.LogExhaustedOnly = &logExhaustedOnly
+			}
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid value %q for error handler option %s", value, key)
+		}
+	}
+	return redelivery, circuitBreaker, nil
+}
+
+func parseInt64Option(value string) (*int64, error) {
+	i, err := strconv.ParseInt(value, 10, 64)
+	return &i, err
+}
+
 func (o *bindCmdOptions) decode(res string, key string) (v1.Endpoint, error) {
 	refConverter := reference.NewConverter(reference.KameletPrefix)
 	endpoint := v1.Endpoint{}
@@ -445,7 +590,7 @@ func (o *bindCmdOptions) parseProperty(prop string) (string, string, string, err
 	return keyParts[0], keyParts[1], parts[1], nil
 }
 
-func (o *bindCmdOptions) checkCompliance(cmd *cobra.Command, endpoint v1.Endpoint) error {
+func (o *bindCmdOptions) checkCompliance(cmd *cobra.Command, endpoint v1.Endpoint, endpointContext bindings.EndpointContext) error {
 	if endpoint.Ref != nil && endpoint.Ref.Kind == "Kamelet" {
 		c, err := o.GetCmdClient()
 		if err != nil {
@@ -464,23 +609,87 @@ func (o *bindCmdOptions) checkCompliance(cmd *cobra.Command, endpoint v1.Endpoin
 			}
 			return err
 		}
-		if kamelet.Spec.Definition != nil && len(kamelet.Spec.Definition.Required) > 0 {
-			pMap, err := endpoint.Properties.GetPropertyMap()
+		pMap, err := endpoint.Properties.GetPropertyMap()
+		if err != nil {
+			return err
+		}
+		id, ok := pMap[v1.KameletIDProperty]
+		if !ok {
+			id = endpointContext.GenerateID()
+		}
+		delete(pMap, v1.KameletIDProperty)
+		if version, ok := pMap[v1.KameletVersionProperty]; ok {
+			delete(pMap, v1.KameletVersionProperty)
+			clone, err := kamelet.CloneWithVersion(version)
 			if err != nil {
 				return err
 			}
-			for _, reqProp := range kamelet.Spec.Definition.Required {
-				found := false
-				if endpoint.Properties != nil {
-					if _, contains := pMap[reqProp]; contains {
-						found = true
-					}
-				}
-				if !found && len(o.Connects) == 0 {
-					return fmt.Errorf("binding is missing required property %q for Kamelet %q", reqProp, key.Name)
-				}
+			kamelet = *clone
+		}
+		violations := make([]string, 0)
+		for _, violation := range kamelets.ValidateProperties(&kamelet, pMap, bindings.KameletApplicationProperties(o.applicationPropertyKeys(), key.Name, id)...) {
+			// Required properties may be provided by the connected services
+			if violation.Reason == kamelets.PropertyRequiredReason && len(o.Connects) > 0 {
+				continue
 			}
+			violations = append(violations, violation.String())
+		}
+		if len(violations) > 0 {
+			return fmt.Errorf("binding has invalid properties for Kamelet %q: %s", key.Name, strings.Join(violations, ", "))
 		}
 	}
 	return nil
 }
+
+// checkDataTypes verifies the data produced by each endpoint can be consumed by the next one.
+func (o *bindCmdOptions) checkDataTypes(source, sink v1.Endpoint, steps []v1.Endpoint) error {
+	binding := v1.NewPipe(o.Namespace, "")
+	binding.Spec.Source = source
+	binding.Spec.Sink = sink
+	binding.Spec.Steps = steps
+
+	namespaces := []string{o.Namespace}
+	for _, e := range append([]v1.Endpoint{source, sink}, steps...) {
+		if e.Ref != nil && e.Ref.Kind == v1.KameletKind {
+			namespaces = append(namespaces, e.Ref.Namespace)
+		}
+	}
+	if len(namespaces) == 1 {
+		return nil
+	}
+
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+	repo, err := repository.New(o.Context, c, namespaces...)
+	if err != nil {
+		return err
+	}
+	analysis, err := bindings.AnalyzeDataTypes(o.Context, repo, &binding)
+	if err != nil {
+		return err
+	}
+	if len(analysis.Incompatibilities) > 0 {
+		incompatibilities := make([]string, 0, len(analysis.Incompatibilities))
+		for _, incompatibility := range analysis.Incompatibilities {
+			incompatibilities = append(incompatibilities, incompatibility.String())
+		}
+		return fmt.Errorf("binding has incompatible data types: %s", strings.Join(incompatibilities, ", "))
+	}
+	return nil
+}
+
+// applicationPropertyKeys returns the keys of the application properties configured with the camel trait.
+func (o *bindCmdOptions) applicationPropertyKeys() []string {
+	keys := make([]string, 0)
+	for _, t := range o.Traits {
+		value, ok := strings.CutPrefix(t, "camel.properties=")
+		if !ok {
+			continue
+		}
+		key, _, _ := strings.Cut(value, "=")
+		keys = append(keys, strings.TrimSpace(key))
+	}
+	return keys
+}
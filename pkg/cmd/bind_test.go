@@ -53,6 +53,76 @@ func addTestBindCmd(options RootCmdOptions, rootCmd *cobra.Command) *bindCmdOpti
 	return bindOptions
 }
 
+func TestBindInvalidKameletProperties(t *testing.T) {
+	kamelet := v1.NewKamelet("default", "my-source")
+	kamelet.Spec.Definition = &v1.JSONSchemaProps{
+		Required: []string{"message"},
+		Properties: map[string]v1.JSONSchemaProp{
+			"message": {Type: "string"},
+			"period":  {Type: "integer"},
+		},
+	}
+	fakeClient, err := test.NewFakeClient(&kamelet)
+	require.NoError(t, err)
+	newBindCmd := func() *cobra.Command {
+		options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+		addTestBindCmd(*options, rootCmd)
+		kamelTestPostAddCommandInit(t, rootCmd, options)
+		return rootCmd
+	}
+
+	_, err = test.ExecuteCommand(newBindCmd(), cmdBind, "kamelet:default/my-source", "my:dst", "-o", "yaml",
+		"-p", "source.mesage=hello", "-p", "source.period=1s")
+	require.Error(t, err)
+	assert.Equal(t, `binding has invalid properties for Kamelet "my-source": property "mesage" is not declared by Kamelet my-source, `+
+		`property "message" is required, property "period" value "1s" is not an integer`, err.Error())
+
+	_, err = test.ExecuteCommand(newBindCmd(), cmdBind, "kamelet:default/my-source", "my:dst", "-o", "yaml",
+		"-p", "source.period=1000", "-t", "camel.properties=camel.kamelet.my-source.message=hello")
+	require.NoError(t, err)
+
+	_, err = test.ExecuteCommand(newBindCmd(), cmdBind, "kamelet:default/my-source", "my:dst", "-o", "yaml",
+		"-p", "source.period=1000", "-t", "camel.properties=camel.kamelet.my-source.source.message=hello")
+	require.NoError(t, err)
+
+	_, err = test.ExecuteCommand(newBindCmd(), cmdBind, "kamelet:default/my-source", "my:dst", "-o", "yaml",
+		"-p", "source.period=1000", "-t", "camel.properties=camel.kamelet.my-source.other.message=hello")
+	require.Error(t, err)
+	assert.Equal(t, `binding has invalid properties for Kamelet "my-source": property "message" is required`, err.Error())
+}
+
+func TestBindIncompatibleDataTypes(t *testing.T) {
+	source := v1.NewKamelet("default", "my-source")
+	source.Spec.DataTypes = map[v1.TypeSlot]v1.DataTypesSpec{
+		v1.TypeSlotOut: {
+			Default: "binary",
+			Types: map[string]v1.DataTypeSpec{
+				"binary": {Format: "application-octet-stream", MediaType: "application/octet-stream"},
+			},
+		},
+	}
+	sink := v1.NewKamelet("default", "my-sink")
+	sink.Spec.DataTypes = map[v1.TypeSlot]v1.DataTypesSpec{
+		v1.TypeSlotIn: {
+			Default: "json",
+			Types: map[string]v1.DataTypeSpec{
+				"json": {Format: "application-json", MediaType: "application/json"},
+			},
+		},
+	}
+	fakeClient, err := test.NewFakeClient(&source, &sink)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	addTestBindCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	_, err = test.ExecuteCommand(rootCmd, cmdBind, "kamelet:default/my-source", "kamelet:default/my-sink", "-o", "yaml")
+	require.Error(t, err)
+	assert.Equal(t, "binding has incompatible data types: source -> sink: data type camel:application-octet-stream "+
+		"(application/octet-stream) cannot be converted to any of the data types accepted by Kamelet my-sink "+
+		"[camel:application-json (application/json)]", err.Error())
+}
+
 func TestBindOutputJSON(t *testing.T) {
 	buildCmdOptions, bindCmd, _ := initializeBindCmdOptions(t)
 	output, err := test.ExecuteCommand(bindCmd, cmdBind, "my:src", "my:dst", "-o", "json")
@@ -174,6 +244,105 @@ status: {}
 `, output)
 }
 
+func TestBindErrorHandlerLogWithOptions(t *testing.T) {
+	buildCmdOptions, bindCmd, _ := initializeBindCmdOptions(t)
+	output, err := test.ExecuteCommand(bindCmd, cmdBind, "my:src", "my:dst", "-o", "yaml",
+		"--error-handler", "log,maximumRedeliveries=3,redeliveryDelay=2000,circuitBreaker.failureThreshold=5")
+	assert.Equal(t, "yaml", buildCmdOptions.OutputFormat)
+
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: camel.apache.org/v1
+kind: Pipe
+metadata:
+  annotations:
+    camel.apache.org/operator.id: camel-k
+  creationTimestamp: null
+  name: my-to-my
+spec:
+  errorHandler:
+    log:
+      circuitBreaker:
+        failureThreshold: 5
+      redelivery:
+        maximumRedeliveries: 3
+        redeliveryDelay: 2000
+  sink:
+    uri: my:dst
+  source:
+    uri: my:src
+status: {}
+`, output)
+}
+
+func TestBindErrorHandlerSinkWithOptions(t *testing.T) {
+	buildCmdOptions, bindCmd, _ := initializeBindCmdOptions(t)
+	output, err := test.ExecuteCommand(bindCmd, cmdBind, "my:src", "my:dst", "-o", "yaml",
+		"--error-handler", "sink:log:error?showAll=true,logExhaustedOnly=true,retryWhile=${header.retryable}")
+	assert.Equal(t, "yaml", buildCmdOptions.OutputFormat)
+
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: camel.apache.org/v1
+kind: Pipe
+metadata:
+  annotations:
+    camel.apache.org/operator.id: camel-k
+  creationTimestamp: null
+  name: my-to-my
+spec:
+  errorHandler:
+    sink:
+      endpoint:
+        uri: log:error?showAll=true
+      redelivery:
+        logExhaustedOnly: true
+        retryWhile:
+          expression: ${header.retryable}
+  sink:
+    uri: my:dst
+  source:
+    uri: my:src
+status: {}
+`, output)
+}
+
+func TestBindErrorHandlerQuotedOptions(t *testing.T) {
+	_, bindCmd, _ := initializeBindCmdOptions(t)
+	output, err := test.ExecuteCommand(bindCmd, cmdBind, "my:src", "my:dst", "-o", "yaml",
+		"--error-handler", `log,retryWhile="${header.code} in 500,503 && ${header.name} == 'a,b'",maximumRedeliveries=3`)
+
+	require.NoError(t, err)
+	assert.Contains(t, output, `      redelivery:
+        maximumRedeliveries: 3
+        retryWhile:
+          expression: ${header.code} in 500,503 && ${header.name} == 'a,b'
+`)
+}
+
+func TestSplitErrorHandlerOptions(t *testing.T) {
+	desc, options := splitErrorHandlerOptions("sink:log:error?showAll=true,multiline=true,retryWhile='${header.code} in 500,503'")
+	assert.Equal(t, "sink:log:error?showAll=true,multiline=true", desc)
+	assert.Equal(t, map[string]string{"retryWhile": "${header.code} in 500,503"}, options)
+
+	// Unbalanced quotes are preserved
+	desc, options = splitErrorHandlerOptions(`sink:log:"error,maximumRedeliveries=3`)
+	assert.Equal(t, `sink:log:"error,maximumRedeliveries=3`, desc)
+	assert.Empty(t, options)
+}
+
+func TestBindErrorHandlerInvalidOptions(t *testing.T) {
+	_, bindCmd, _ := initializeBindCmdOptions(t)
+	output, err := test.ExecuteCommand(bindCmd, cmdBind, "my:src", "my:dst", "-o", "yaml",
+		"--error-handler", "none,maximumRedeliveries=3")
+	require.NoError(t, err)
+	assert.Equal(t, "error handler type none does not support redelivery options\n", output)
+
+	_, bindCmd, _ = initializeBindCmdOptions(t)
+	output, err = test.ExecuteCommand(bindCmd, cmdBind, "my:src", "my:dst", "-o", "yaml",
+		"--error-handler", "log,backOffMultiplier=0.5")
+	require.NoError(t, err)
+	assert.Equal(t, "invalid error handler: invalid back-off multiplier 0.5: must be 1 or greater\n", output)
+}
+
 func TestBindTraits(t *testing.T) {
 	buildCmdOptions, bindCmd, _ := initializeBindCmdOptions(t)
 	output, err := test.ExecuteCommand(bindCmd, cmdBind, "my:src", "my:dst", "-o", "yaml", "-t", "mount.configs=configmap:my-cm", "-c", "my-service-binding")
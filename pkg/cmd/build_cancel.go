@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func newBuildCancelCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *buildCancelCommandOptions) {
+	options := buildCancelCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:     "cancel [build1] [build2] ...",
+		Short:   "Cancel builds",
+		Long:    `Cancel one or more builds. The cancelled builds are stopped and are not retried by the operator.`,
+		PreRunE: decode(&options, options.Flags),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+	}
+
+	return &cmd, &options
+}
+
+type buildCancelCommandOptions struct {
+	*RootCmdOptions
+}
+
+func (command *buildCancelCommandOptions) validate(args []string) error {
+	if len(args) == 0 {
+		return errors.New("provide one or several build names")
+	}
+
+	return nil
+}
+
+func (command *buildCancelCommandOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := command.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range args {
+		build := v1.NewBuild(command.Namespace, name)
+		if err := c.Get(command.Context, ctrl.ObjectKeyFromObject(build), build); err != nil {
+			if k8errors.IsNotFound(err) {
+				return fmt.Errorf("no build found with name \"%s\"", name)
+			}
+			return err
+		}
+
+		if build.Status.IsFinished() && build.Status.Phase != v1.BuildPhaseFailed {
+			fmt.Fprintf(cmd.OutOrStdout(), "build \"%s\" is already finished (phase %s)\n", name, build.Status.Phase)
+			continue
+		}
+
+		patch := ctrl.MergeFrom(build.DeepCopy())
+		build.Spec.Cancel = true
+		if err := c.Patch(command.Context, build, patch); err != nil {
+			return fmt.Errorf("error cancelling build \"%s\": %w", name, err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "build \"%s\" has been cancelled\n", name)
+	}
+
+	return nil
+}
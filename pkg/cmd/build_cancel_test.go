@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+const cmdBuild = "build"
+
+// nolint: unparam
+func initializeBuildCancelCmdOptions(t *testing.T, initObjs ...runtime.Object) (*cobra.Command, client.Client) {
+	t.Helper()
+	fakeClient, err := test.NewFakeClient(initObjs...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	rootCmd.AddCommand(newCmdBuild(options))
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return rootCmd, fakeClient
+}
+
+func TestBuildCancel(t *testing.T) {
+	build := v1.NewBuild("default", "my-build")
+	build.Status.Phase = v1.BuildPhaseRunning
+	rootCmd, c := initializeBuildCancelCmdOptions(t, build)
+
+	output, err := test.ExecuteCommand(rootCmd, cmdBuild, "cancel", "my-build")
+	require.NoError(t, err)
+	assert.Contains(t, output, "build \"my-build\" has been cancelled\n")
+
+	cancelled := v1.NewBuild("default", "my-build")
+	require.NoError(t, c.Get(context.TODO(), k8sclient.ObjectKeyFromObject(cancelled), cancelled))
+	assert.True(t, cancelled.Spec.Cancel)
+}
+
+func TestBuildCancelFinished(t *testing.T) {
+	build := v1.NewBuild("default", "my-build")
+	build.Status.Phase = v1.BuildPhaseSucceeded
+	rootCmd, c := initializeBuildCancelCmdOptions(t, build)
+
+	output, err := test.ExecuteCommand(rootCmd, cmdBuild, "cancel", "my-build")
+	require.NoError(t, err)
+	assert.Contains(t, output, "build \"my-build\" is already finished (phase Succeeded)\n")
+
+	finished := v1.NewBuild("default", "my-build")
+	require.NoError(t, c.Get(context.TODO(), k8sclient.ObjectKeyFromObject(finished), finished))
+	assert.False(t, finished.Spec.Cancel)
+}
+
+func TestBuildCancelNotFound(t *testing.T) {
+	rootCmd, _ := initializeBuildCancelCmdOptions(t)
+
+	_, err := test.ExecuteCommand(rootCmd, cmdBuild, "cancel", "my-build")
+	require.Error(t, err)
+	assert.Equal(t, "no build found with name \"my-build\"", err.Error())
+}
+
+func TestBuildCancelMissingName(t *testing.T) {
+	rootCmd, _ := initializeBuildCancelCmdOptions(t)
+
+	_, err := test.ExecuteCommand(rootCmd, cmdBuild, "cancel")
+	require.Error(t, err)
+	assert.Equal(t, "provide one or several build names", err.Error())
+}
@@ -0,0 +1,483 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/controller/pipe"
+	"github.com/apache/camel-k/v2/pkg/trait"
+	"github.com/apache/camel-k/v2/pkg/util/defaults"
+	"github.com/apache/camel-k/v2/pkg/util/digest"
+	"github.com/apache/camel-k/v2/pkg/util/dsl"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+func newCmdDiff(rootCmdOptions *RootCmdOptions) (*cobra.Command, *diffCmdOptions) {
+	options := diffCmdOptions{
+		runCmdOptions: &runCmdOptions{
+			RootCmdOptions: rootCmdOptions,
+		},
+	}
+
+	cmd := cobra.Command{
+		Use:   "diff [file to run | pipe.yaml]",
+		Short: "Show the differences between local sources and the deployed Integration or Pipe",
+		Long: `Build the Integration the same way "kamel run" would, or the same way the operator would for a Pipe definition,
+and show the differences with the Integration deployed in the cluster, together with the digest that determines whether a rebuild is triggered.`,
+		Args:              options.validateArgs,
+		PersistentPreRunE: options.decode,
+		RunE:              options.run,
+		Annotations:       make(map[string]string),
+	}
+
+	configureIntegrationFlags(&cmd)
+
+	// completion support
+	configureKnownCompletions(&cmd)
+
+	return &cmd, &options
+}
+
+type diffCmdOptions struct {
+	*runCmdOptions
+}
+
+// specChange represents a single difference between two Integration specs.
+type specChange struct {
+	// Op is one of +, - or ~ for respectively an added, a removed or a modified element
+	Op   string
+	Path string
+	From string
+	To   string
+	// Detail contains an optional unified diff of the element content
+	Detail string
+}
+
+func (o *diffCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	if len(args) < 1 && o.ContainerImage == "" {
+		return errors.New("diff command expects either an Integration source, a Pipe or the container image (via --image argument)")
+	}
+
+	var local, live *v1.Integration
+	var kind string
+	if p, err := loadPipeFromArgs(args); err != nil {
+		return err
+	} else if p != nil {
+		kind = v1.PipeKind
+		local, live, err = o.buildPipeIntegration(c, p)
+		if err != nil {
+			return err
+		}
+	} else {
+		kind = v1.IntegrationKind
+		local, live, err = o.buildIntegration(cmd, c, args)
+		if err != nil {
+			return err
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if live == nil {
+		fmt.Fprintf(out, "%s %q is not deployed, it would be created\n", kind, local.Name)
+	} else {
+		changes, err := diffIntegrations(live, local)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			fmt.Fprintf(out, "%s %q is up to date\n", kind, local.Name)
+		} else {
+			fmt.Fprintf(out, "%s %q differs from the deployed one:\n", kind, local.Name)
+			printChanges(out, changes)
+		}
+	}
+
+	return o.printDigest(cmd, c, local, live)
+}
+
+func (o *diffCmdOptions) buildPipeIntegration(c client.Client, p *v1.Pipe) (*v1.Integration, *v1.Integration, error) {
+	if p.Namespace == "" {
+		p.Namespace = o.Namespace
+	}
+	dataTypes, err := pipe.AnalyzeDataTypes(o.Context, c, p)
+	if err != nil {
+		return nil, nil, err
+	}
+	local, err := pipe.CreateIntegrationFor(o.Context, c, p, dataTypes.Conversions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	live := v1.NewIntegration(p.Namespace, p.Name)
+	if err := c.Get(o.Context, ctrl.ObjectKeyFromObject(&live), &live); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return local, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	return local, &live, nil
+}
+
+func (o *diffCmdOptions) printDigest(cmd *cobra.Command, c client.Client, local *v1.Integration, live *v1.Integration) error {
+	// The digest depends on the version of the operator that reconciled the Integration
+	local.Status.Version = defaults.Version
+	if live != nil && live.Status.Version != "" {
+		local.Status.Version = live.Status.Version
+	}
+	// The digest depends on the commit the git sources have been checked out from
+	if live != nil {
+		local.Status.GitCommit = live.Status.GitCommit
+	}
+	secrets, configmaps := kubernetes.LookupIntegrationSecretAndConfigmapResourceVersions(o.Context, c, local)
+	d, err := digest.ComputeForIntegration(local, configmaps, secrets)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out, "Digest:")
+	if live != nil {
+		fmt.Fprintf(out, "  deployed: %s\n", live.Status.Digest)
+	}
+	fmt.Fprintf(out, "  local:    %s\n", d)
+	if live != nil {
+		if live.Status.Digest == d {
+			fmt.Fprintln(out, "No rebuild would be triggered")
+		} else {
+			fmt.Fprintln(out, "A rebuild would be triggered")
+		}
+	}
+
+	return nil
+}
+
+// loadPipeFromArgs returns the Pipe defined in the single local file argument, if any.
+func loadPipeFromArgs(args []string) (*v1.Pipe, error) {
+	if len(args) != 1 {
+		return nil, nil
+	}
+	ext := filepath.Ext(args[0])
+	if ext != ".yaml" && ext != ".yml" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		// not a local file, let the source resolution deal with it
+		return nil, nil //nolint:nilerr
+	}
+
+	var meta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &meta); err != nil || meta.Kind != v1.PipeKind {
+		// not a resource, most likely a YAML DSL source
+		return nil, nil //nolint:nilerr
+	}
+
+	p := v1.Pipe{}
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// diffIntegrations computes the semantic differences between the from and to Integration specs.
+func diffIntegrations(from *v1.Integration, to *v1.Integration) (map[string][]specChange, error) {
+	changes := make(map[string][]specChange)
+	add := func(section string, c ...specChange) {
+		if len(c) > 0 {
+			changes[section] = append(changes[section], c...)
+		}
+	}
+
+	add("Sources", diffSources(from.Spec.Sources, to.Spec.Sources)...)
+
+	flows, err := diffFlows(from.Spec.Flows, to.Spec.Flows)
+	if err != nil {
+		return nil, err
+	}
+	add("Flows", flows...)
+
+	traits, err := diffTraits(from.Spec.Traits, to.Spec.Traits)
+	if err != nil {
+		return nil, err
+	}
+	add("Traits", traits...)
+
+	add("Configuration", diffStrings(configurationStrings(from.Spec.Configuration), configurationStrings(to.Spec.Configuration))...)
+	add("Dependencies", diffStrings(from.Spec.Dependencies, to.Spec.Dependencies)...)
+	add("Repositories", diffStrings(from.Spec.Repositories, to.Spec.Repositories)...)
+
+	fromOthers, err := otherSpecValues(from)
+	if err != nil {
+		return nil, err
+	}
+	toOthers, err := otherSpecValues(to)
+	if err != nil {
+		return nil, err
+	}
+	add("Other", diffValues(fromOthers, toOthers)...)
+
+	return changes, nil
+}
+
+func diffSources(from []v1.SourceSpec, to []v1.SourceSpec) []specChange {
+	fromContent := make(map[string]string, len(from))
+	for _, s := range from {
+		fromContent[s.Name] = s.Content
+	}
+	toContent := make(map[string]string, len(to))
+	for _, s := range to {
+		toContent[s.Name] = s.Content
+	}
+
+	changes := make([]specChange, 0)
+	for _, name := range sortedUnion(fromContent, toContent) {
+		before, inFrom := fromContent[name]
+		after, inTo := toContent[name]
+		switch {
+		case !inFrom:
+			changes = append(changes, specChange{Op: "+", Path: name})
+		case !inTo:
+			changes = append(changes, specChange{Op: "-", Path: name})
+		case before != after:
+			changes = append(changes, specChange{Op: "~", Path: name, Detail: unifiedDiff(before, after)})
+		}
+	}
+
+	return changes
+}
+
+func diffFlows(from []v1.Flow, to []v1.Flow) ([]specChange, error) {
+	before, err := dsl.ToYamlDSL(from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := dsl.ToYamlDSL(to)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(from) == 0 && len(to) == 0, string(before) == string(after):
+		return nil, nil
+	case len(from) == 0:
+		return []specChange{{Op: "+", Path: "flows"}}, nil
+	case len(to) == 0:
+		return []specChange{{Op: "-", Path: "flows"}}, nil
+	default:
+		return []specChange{{Op: "~", Path: "flows", Detail: unifiedDiff(string(before), string(after))}}, nil
+	}
+}
+
+func diffTraits(from v1.Traits, to v1.Traits) ([]specChange, error) {
+	fromMap, err := trait.ToTraitMap(from)
+	if err != nil {
+		return nil, err
+	}
+	toMap, err := trait.ToTraitMap(to)
+	if err != nil {
+		return nil, err
+	}
+
+	fromValues := make(map[string]string)
+	for id, props := range fromMap {
+		flattenValue(id, props, fromValues)
+	}
+	toValues := make(map[string]string)
+	for id, props := range toMap {
+		flattenValue(id, props, toValues)
+	}
+
+	return diffValues(fromValues, toValues), nil
+}
+
+// flattenValue turns a nested map into a flat map of dotted paths to JSON encoded values.
+func flattenValue(path string, value interface{}, into map[string]string) {
+	if m, ok := value.(map[string]interface{}); ok {
+		for k, v := range m {
+			flattenValue(path+"."+k, v, into)
+		}
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		into[path] = fmt.Sprintf("%v", value)
+		return
+	}
+	into[path] = string(data)
+}
+
+func configurationStrings(configuration []v1.ConfigurationSpec) []string {
+	values := make([]string, 0, len(configuration))
+	for _, c := range configuration {
+		values = append(values, c.String())
+	}
+	return values
+}
+
+func otherSpecValues(it *v1.Integration) (map[string]string, error) {
+	values := make(map[string]string)
+	if it.Spec.Replicas != nil {
+		values["replicas"] = fmt.Sprintf("%d", *it.Spec.Replicas)
+	}
+	if it.Spec.Profile != "" {
+		values["profile"] = string(it.Spec.Profile)
+	}
+	if it.Spec.ServiceAccountName != "" {
+		values["serviceAccountName"] = it.Spec.ServiceAccountName
+	}
+	if it.Spec.IntegrationKit != nil {
+		values["integrationKit"] = fmt.Sprintf("%s/%s", it.Spec.IntegrationKit.Namespace, it.Spec.IntegrationKit.Name)
+	}
+	if it.Spec.PodTemplate != nil {
+		data, err := json.Marshal(it.Spec.PodTemplate)
+		if err != nil {
+			return nil, err
+		}
+		values["template"] = string(data)
+	}
+	if id := v1.GetOperatorIDAnnotation(it); id != "" {
+		values["operatorId"] = id
+	}
+	if profile := v1.GetIntegrationProfileAnnotation(it); profile != "" {
+		values["integrationProfile"] = profile
+	}
+
+	return values, nil
+}
+
+func diffStrings(from []string, to []string) []specChange {
+	fromSet := make(map[string]string, len(from))
+	for _, s := range from {
+		fromSet[s] = s
+	}
+	toSet := make(map[string]string, len(to))
+	for _, s := range to {
+		toSet[s] = s
+	}
+
+	changes := make([]specChange, 0)
+	for _, s := range sortedUnion(fromSet, toSet) {
+		_, inFrom := fromSet[s]
+		_, inTo := toSet[s]
+		if !inFrom {
+			changes = append(changes, specChange{Op: "+", Path: s})
+		} else if !inTo {
+			changes = append(changes, specChange{Op: "-", Path: s})
+		}
+	}
+
+	return changes
+}
+
+func diffValues(from map[string]string, to map[string]string) []specChange {
+	changes := make([]specChange, 0)
+	for _, k := range sortedUnion(from, to) {
+		before, inFrom := from[k]
+		after, inTo := to[k]
+		switch {
+		case !inFrom:
+			changes = append(changes, specChange{Op: "+", Path: k, To: after})
+		case !inTo:
+			changes = append(changes, specChange{Op: "-", Path: k, From: before})
+		case before != after:
+			changes = append(changes, specChange{Op: "~", Path: k, From: before, To: after})
+		}
+	}
+
+	return changes
+}
+
+func sortedUnion(a map[string]string, b map[string]string) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func unifiedDiff(from string, to string) string {
+	d, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: "deployed",
+		ToFile:   "local",
+		Context:  2,
+	})
+	if err != nil {
+		return ""
+	}
+	return d
+}
+
+func printChanges(out io.Writer, changes map[string][]specChange) {
+	sections := []string{"Sources", "Flows", "Traits", "Configuration", "Dependencies", "Repositories", "Other"}
+	for _, section := range sections {
+		if len(changes[section]) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "%s:\n", section)
+		for _, c := range changes[section] {
+			switch {
+			case c.From != "" && c.To != "":
+				fmt.Fprintf(out, "  %s %s: %s => %s\n", c.Op, c.Path, c.From, c.To)
+			case c.To != "":
+				fmt.Fprintf(out, "  %s %s: %s\n", c.Op, c.Path, c.To)
+			case c.From != "":
+				fmt.Fprintf(out, "  %s %s: %s\n", c.Op, c.Path, c.From)
+			default:
+				fmt.Fprintf(out, "  %s %s\n", c.Op, c.Path)
+			}
+			if c.Detail != "" {
+				for _, line := range strings.Split(strings.TrimSuffix(c.Detail, "\n"), "\n") {
+					fmt.Fprintf(out, "      %s\n", line)
+				}
+			}
+		}
+	}
+}
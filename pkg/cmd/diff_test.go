@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
+	"github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/util/dsl"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+const cmdDiff = "diff"
+
+const diffRoute = `- from:
+    uri: timer:tick
+    steps:
+    - log: Hello
+`
+
+// nolint: unparam
+func initializeDiffCmdOptions(t *testing.T, initObjs ...runtime.Object) (*diffCmdOptions, *cobra.Command, RootCmdOptions) {
+	t.Helper()
+	defaultIntegrationPlatform := v1.NewIntegrationPlatform("default", platform.DefaultPlatformName)
+	fakeClient, err := test.NewFakeClient(append(initObjs, &defaultIntegrationPlatform)...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	diffCmdOptions := addTestDiffCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return diffCmdOptions, rootCmd, *options
+}
+
+func addTestDiffCmd(options RootCmdOptions, rootCmd *cobra.Command) *diffCmdOptions {
+	diffCmd, diffOptions := newCmdDiff(&options)
+	diffCmd.Args = test.ArbitraryArgs
+	rootCmd.AddCommand(diffCmd)
+	return diffOptions
+}
+
+func writeDiffSource(t *testing.T, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "hello.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	return file
+}
+
+func TestDiffNotDeployed(t *testing.T) {
+	_, diffCmd, _ := initializeDiffCmdOptions(t)
+	output, err := test.ExecuteCommand(diffCmd, cmdDiff, writeDiffSource(t, diffRoute))
+	require.NoError(t, err)
+	assert.Contains(t, output, `Integration "hello" is not deployed, it would be created`)
+	assert.Contains(t, output, "local:")
+	assert.NotContains(t, output, "deployed:")
+}
+
+func TestDiffChangedSource(t *testing.T) {
+	deployed := v1.NewIntegration("default", "hello")
+	flows, err := dsl.FromYamlDSLString(strings.Replace(diffRoute, "Hello", "Bye", 1))
+	require.NoError(t, err)
+	deployed.Spec.Flows = flows
+	deployed.SetOperatorID("camel-k")
+	deployed.Spec.Traits.Container = &trait.ContainerTrait{Name: "my-container"}
+	deployed.Status.Digest = "vold"
+
+	_, diffCmd, _ := initializeDiffCmdOptions(t, &deployed)
+	output, err := test.ExecuteCommand(diffCmd, cmdDiff, writeDiffSource(t, diffRoute), "--dependency", "camel:log")
+	require.NoError(t, err)
+	assert.Contains(t, output, `Integration "hello" differs from the deployed one:`)
+	assert.Contains(t, output, "  ~ flows\n")
+	assert.Contains(t, output, "-    - log: Bye")
+	assert.Contains(t, output, "+    - log: Hello")
+	assert.Contains(t, output, `  - container.name: "my-container"`)
+	assert.Contains(t, output, "  + camel:log")
+	assert.Contains(t, output, "deployed: vold")
+	assert.Contains(t, output, "A rebuild would be triggered")
+}
+
+func TestDiffIntegrations(t *testing.T) {
+	from := v1.NewIntegration("default", "hello")
+	from.Spec.Replicas = pointer.Int32(1)
+	from.Spec.Traits.Logging = &trait.LoggingTrait{Level: "INFO"}
+	to := from.DeepCopy()
+	to.Spec.Replicas = pointer.Int32(2)
+	to.Spec.Traits.Logging.Level = "DEBUG"
+
+	changes, err := diffIntegrations(&from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []specChange{{Op: "~", Path: "logging.level", From: `"INFO"`, To: `"DEBUG"`}}, changes["Traits"])
+	assert.Equal(t, []specChange{{Op: "~", Path: "replicas", From: "1", To: "2"}}, changes["Other"])
+
+	changes, err = diffIntegrations(to, to)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
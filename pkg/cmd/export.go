@@ -0,0 +1,396 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/apache/camel-k/v2/pkg/apis"
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/trait"
+	"github.com/apache/camel-k/v2/pkg/util"
+	"github.com/apache/camel-k/v2/pkg/util/camel"
+	"github.com/apache/camel-k/v2/pkg/util/defaults"
+	"github.com/apache/camel-k/v2/pkg/util/digest"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+const kustomizationFileName = "kustomization.yaml"
+
+func newCmdExport(rootCmdOptions *RootCmdOptions) (*cobra.Command, *exportCmdOptions) {
+	options := exportCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "export <integration.yaml> [--platform <platform.yaml>] [--output-dir <dir>]",
+		Short: "Render an Integration into plain Kubernetes manifests",
+		Long: `Render an Integration into the Kubernetes resources the operator would apply, without any cluster access.
+The Integration is read from a file (for example the output of "kamel run -o yaml") and the trait pipeline is executed
+client-side against the given IntegrationPlatform and IntegrationProfile definitions.`,
+		Args:    options.validateArgs,
+		PreRunE: decode(&options, options.Flags),
+		RunE:    options.run,
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+	}
+
+	cmd.Flags().String("platform", "", "Path to the IntegrationPlatform definition used to render the Integration")
+	cmd.Flags().String("integration-profile", "", "Path to the IntegrationProfile definition used to render the Integration")
+	cmd.Flags().String("image", "", "The container image to run. Defaults to the container trait image, if any")
+	cmd.Flags().String("output-dir", "", "Directory where the manifests are written. Print to the standard output if empty")
+	cmd.Flags().Bool("kustomize", false, "Write a Kustomize base, i.e., also generate a kustomization.yaml file in the output directory")
+	cmd.Flags().StringP("output", "o", "yaml", "Output format. One of: json|yaml")
+
+	return &cmd, &options
+}
+
+type exportCmdOptions struct {
+	*RootCmdOptions
+	Platform           string `mapstructure:"platform" yaml:",omitempty"`
+	IntegrationProfile string `mapstructure:"integration-profile" yaml:",omitempty"`
+	Image              string `mapstructure:"image" yaml:",omitempty"`
+	OutputDir          string `mapstructure:"output-dir" yaml:",omitempty"`
+	Kustomize          bool   `mapstructure:"kustomize" yaml:",omitempty"`
+	OutputFormat       string `mapstructure:"output" yaml:",omitempty"`
+}
+
+type kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Resources  []string `json:"resources"`
+}
+
+func (o *exportCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("export expects exactly one Integration file argument")
+	}
+
+	return nil
+}
+
+func (o *exportCmdOptions) validate() error {
+	if o.OutputFormat != "yaml" && o.OutputFormat != "json" {
+		return fmt.Errorf("invalid output format option '%s', should be one of: yaml|json", o.OutputFormat)
+	}
+	if o.Kustomize && o.OutputDir == "" {
+		return errors.New("a Kustomize base requires an output directory, use --output-dir")
+	}
+	if o.Kustomize && o.OutputFormat != "yaml" {
+		return errors.New("a Kustomize base can only be exported in yaml format")
+	}
+
+	return nil
+}
+
+func (o *exportCmdOptions) run(cmd *cobra.Command, args []string) error {
+	if err := o.validate(); err != nil {
+		return err
+	}
+
+	it, err := loadIntegrationFromFile(args[0])
+	if err != nil {
+		return err
+	}
+	if it.Namespace == "" {
+		it.Namespace = o.Namespace
+	}
+	if it.Namespace == "" {
+		it.Namespace = "default"
+	}
+
+	pl, err := o.loadPlatform(it.Namespace)
+	if err != nil {
+		return err
+	}
+
+	var ipr *v1.IntegrationProfile
+	if o.IntegrationProfile != "" {
+		ipr, err = loadIntegrationProfileFromFile(o.IntegrationProfile, it.Namespace)
+		if err != nil {
+			return err
+		}
+		v1.SetAnnotation(&it.ObjectMeta, v1.IntegrationProfileAnnotation, ipr.Name)
+		v1.SetAnnotation(&it.ObjectMeta, v1.IntegrationProfileNamespaceAnnotation, ipr.Namespace)
+	}
+
+	resources, err := exportIntegration(o.Context, it, pl, ipr, o.Image)
+	if err != nil {
+		return err
+	}
+
+	if o.OutputDir == "" {
+		return writeExportedResources(cmd.OutOrStdout(), resources, o.OutputFormat)
+	}
+
+	return o.writeToDirectory(cmd, resources)
+}
+
+func (o *exportCmdOptions) loadPlatform(namespace string) (*v1.IntegrationPlatform, error) {
+	if o.Platform == "" {
+		pl := v1.NewIntegrationPlatform(namespace, platform.DefaultPlatformName)
+		return &pl, nil
+	}
+
+	obj, err := loadResourceFromFile(o.Platform)
+	if err != nil {
+		return nil, err
+	}
+	pl, ok := obj.(*v1.IntegrationPlatform)
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain an IntegrationPlatform", o.Platform)
+	}
+	// The platform must live in the same namespace as the exported Integration
+	pl.Namespace = namespace
+
+	return pl, nil
+}
+
+// exportIntegration runs the trait pipeline against an in-memory cluster made of the given platform and profile,
+// and returns the resources the operator would create for the Integration.
+func exportIntegration(ctx context.Context, it *v1.Integration, pl *v1.IntegrationPlatform,
+	ipr *v1.IntegrationProfile, image string) ([]ctrl.Object, error) {
+	bootstrap, err := client.NewOfflineClient()
+	if err != nil {
+		return nil, err
+	}
+	if err := platform.ConfigureDefaults(ctx, bootstrap, pl, false); err != nil {
+		return nil, err
+	}
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Version = defaults.Version
+
+	runtimeVersion := pl.Status.Build.RuntimeVersion
+	objs := []runtime.Object{pl}
+	if ipr != nil {
+		ipr.ResyncStatusFullConfig()
+		ipr.Status.Phase = v1.IntegrationProfilePhaseReady
+		if ipr.Status.Build.RuntimeVersion != "" {
+			runtimeVersion = ipr.Status.Build.RuntimeVersion
+		}
+		objs = append(objs, ipr)
+	}
+
+	// The catalog cannot be generated without network access, so only the embedded one can be used
+	catalog, err := camel.DefaultCatalog()
+	if err != nil {
+		return nil, err
+	}
+	if runtimeVersion != catalog.Runtime.Version {
+		return nil, fmt.Errorf("runtime version %s is not available offline, only %s is supported", runtimeVersion, catalog.Runtime.Version)
+	}
+	cat := v1.NewCamelCatalog(pl.Namespace, "camel-catalog-"+strings.ToLower(catalog.Runtime.Version))
+	cat.Spec = catalog.CamelCatalogSpec
+	cat.Status = catalog.CamelCatalogStatus
+	objs = append(objs, &cat)
+
+	it.Status = v1.IntegrationStatus{
+		Phase:   v1.IntegrationPhaseInitialization,
+		Version: defaults.Version,
+	}
+	if image == "" && it.Spec.Traits.Container != nil {
+		image = it.Spec.Traits.Container.Image
+	}
+	if image == "" {
+		return nil, errors.New("unable to determine the container image, use --image or the container.image trait")
+	}
+	if it.Spec.Traits.Container != nil {
+		// the kit is provided below, so the container trait must not create another one
+		it.Spec.Traits.Container.Image = ""
+	}
+	it.Spec.IntegrationKit = nil
+	objs = append(objs, it)
+
+	c, err := client.NewOfflineClient(objs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialization phase
+	if _, err := trait.Apply(ctx, c, it, nil); err != nil {
+		return nil, err
+	}
+
+	d, err := digest.ComputeForIntegration(it, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	it.Status.Digest = d
+
+	kit := v1.NewIntegrationKit(it.Namespace, fmt.Sprintf("kit-%s", it.Name))
+	kit.Labels = map[string]string{
+		v1.IntegrationKitTypeLabel: v1.IntegrationKitTypeExternal,
+	}
+	kit.Spec.Image = image
+	kit.Status.Phase = v1.IntegrationKitPhaseReady
+	kit.Status.Image = image
+	kit.Status.RuntimeVersion = it.Status.RuntimeVersion
+	kit.Status.RuntimeProvider = it.Status.RuntimeProvider
+	if err := c.Create(ctx, kit); err != nil {
+		return nil, err
+	}
+
+	// Deployment phase
+	it.SetIntegrationKit(kit)
+	it.Status.Phase = v1.IntegrationPhaseDeploying
+	env, err := trait.Apply(ctx, c, it, kit)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ctrl.Object, 0, env.Resources.Size())
+	for _, res := range env.Resources.Items() {
+		if res.GetObjectKind().GroupVersionKind().Group == v1.SchemeGroupVersion.Group {
+			// Camel K custom resources are of no use without the operator
+			continue
+		}
+		// The resources are standalone, they are not owned by any Integration
+		res.SetOwnerReferences(nil)
+		res.SetResourceVersion("")
+		res.SetUID("")
+		res.SetCreationTimestamp(metav1.Time{})
+		res.SetManagedFields(nil)
+		resources = append(resources, res)
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return exportFileName(resources[i], "") < exportFileName(resources[j], "")
+	})
+
+	return resources, nil
+}
+
+func writeExportedResources(out io.Writer, resources []ctrl.Object, format string) error {
+	printer := kubernetes.CLIPrinter{Format: format}
+	for i, res := range resources {
+		if i > 0 && format == "yaml" {
+			fmt.Fprintln(out, "---")
+		}
+		if err := printer.PrintObj(res, out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *exportCmdOptions) writeToDirectory(cmd *cobra.Command, resources []ctrl.Object) error {
+	if err := util.CreateDirectory(o.OutputDir); err != nil {
+		return err
+	}
+
+	files := make([]string, 0, len(resources))
+	for _, res := range resources {
+		var data []byte
+		var err error
+		if o.OutputFormat == "json" {
+			data, err = kubernetes.ToJSON(res)
+		} else {
+			data, err = kubernetes.ToYAMLNoManagedFields(res)
+		}
+		if err != nil {
+			return err
+		}
+		name := exportFileName(res, o.OutputFormat)
+		if err := util.WriteFileWithContent(filepath.Join(o.OutputDir, name), data); err != nil {
+			return err
+		}
+		files = append(files, name)
+	}
+
+	if o.Kustomize {
+		data, err := yaml.Marshal(kustomization{
+			APIVersion: "kustomize.config.k8s.io/v1beta1",
+			Kind:       "Kustomization",
+			Resources:  files,
+		})
+		if err != nil {
+			return err
+		}
+		if err := util.WriteFileWithContent(filepath.Join(o.OutputDir, kustomizationFileName), data); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%d resources exported to %s\n", len(files), o.OutputDir)
+
+	return nil
+}
+
+func exportFileName(obj ctrl.Object, format string) string {
+	name := fmt.Sprintf("%s-%s", strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind), obj.GetName())
+	if format == "" {
+		return name
+	}
+	return name + "." + format
+}
+
+func loadResourceFromFile(path string) (ctrl.Object, error) {
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		return nil, err
+	}
+	data, err := util.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.LoadResourceFromYaml(scheme.Scheme, string(data))
+}
+
+func loadIntegrationFromFile(path string) (*v1.Integration, error) {
+	obj, err := loadResourceFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	it, ok := obj.(*v1.Integration)
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain an Integration", path)
+	}
+	return it, nil
+}
+
+func loadIntegrationProfileFromFile(path string, namespace string) (*v1.IntegrationProfile, error) {
+	obj, err := loadResourceFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ipr, ok := obj.(*v1.IntegrationProfile)
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain an IntegrationProfile", path)
+	}
+	if ipr.Namespace == "" {
+		ipr.Namespace = namespace
+	}
+	return ipr, nil
+}
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+const cmdExport = "export"
+
+const exportIntegrationYAML = `apiVersion: camel.apache.org/v1
+kind: Integration
+metadata:
+  name: my-it
+spec:
+  flows:
+  - from:
+      uri: platform-http:/hello
+      steps:
+      - setBody:
+          constant: Hello
+`
+
+// nolint: unparam
+func initializeExportCmdOptions(t *testing.T) (*exportCmdOptions, *cobra.Command, RootCmdOptions) {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	exportCmdOptions := addTestExportCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return exportCmdOptions, rootCmd, *options
+}
+
+func addTestExportCmd(options RootCmdOptions, rootCmd *cobra.Command) *exportCmdOptions {
+	exportCmd, exportOptions := newCmdExport(&options)
+	exportCmd.Args = test.ArbitraryArgs
+	rootCmd.AddCommand(exportCmd)
+	return exportOptions
+}
+
+func writeExportIntegration(t *testing.T) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "my-it.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(exportIntegrationYAML), 0o600))
+	return file
+}
+
+func TestExportKustomizeRequiresDirectory(t *testing.T) {
+	_, exportCmd, _ := initializeExportCmdOptions(t)
+	_, err := test.ExecuteCommand(exportCmd, cmdExport, writeExportIntegration(t), "--image", "my-image", "--kustomize")
+	require.Error(t, err)
+	assert.Equal(t, "a Kustomize base requires an output directory, use --output-dir", err.Error())
+}
+
+func TestExportRequiresImage(t *testing.T) {
+	_, exportCmd, _ := initializeExportCmdOptions(t)
+	_, err := test.ExecuteCommand(exportCmd, cmdExport, writeExportIntegration(t))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to determine the container image")
+}
+
+func TestExportToStdout(t *testing.T) {
+	_, exportCmd, _ := initializeExportCmdOptions(t)
+	output, err := test.ExecuteCommand(exportCmd, cmdExport, writeExportIntegration(t), "--image", "my-image")
+	require.NoError(t, err)
+	assert.Contains(t, output, "kind: Deployment")
+	assert.Contains(t, output, "kind: Service")
+	assert.Contains(t, output, "image: my-image")
+	assert.NotContains(t, output, "ownerReferences")
+	assert.NotContains(t, output, "kind: IntegrationKit")
+}
+
+func TestExportKustomize(t *testing.T) {
+	_, exportCmd, _ := initializeExportCmdOptions(t)
+	dir := filepath.Join(t.TempDir(), "base")
+	_, err := test.ExecuteCommand(exportCmd, cmdExport, writeExportIntegration(t), "--image", "my-image", "--output-dir", dir, "--kustomize")
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(dir, "deployment-my-it.yaml"))
+	assert.FileExists(t, filepath.Join(dir, "service-my-it.yaml"))
+	kustomization, err := os.ReadFile(filepath.Join(dir, kustomizationFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(kustomization), "kind: Kustomization")
+	assert.Contains(t, string(kustomization), "- deployment-my-it.yaml")
+}
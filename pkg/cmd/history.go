@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/revision"
+)
+
+func newCmdHistory(rootCmdOptions *RootCmdOptions) (*cobra.Command, *historyCmdOptions) {
+	options := historyCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:     "history <integration|pipe>",
+		Short:   "Show the revision history of an Integration or a Pipe",
+		Long:    `Show the revisions recorded by the operator each time an Integration or a Pipe has been successfully deployed.`,
+		Args:    options.validateArgs,
+		PreRunE: decode(&options, options.Flags),
+		RunE:    options.run,
+	}
+
+	return &cmd, &options
+}
+
+type historyCmdOptions struct {
+	*RootCmdOptions
+}
+
+func (o *historyCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("history command expects exactly one Integration or Pipe name")
+	}
+	return nil
+}
+
+func (o *historyCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	owner, kind, err := getRevisionOwner(o.RootCmdOptions, c, args[0])
+	if err != nil {
+		return err
+	}
+
+	revisions, err := revision.List(o.Context, c, o.Namespace, kind, owner.GetName())
+	if err != nil {
+		return err
+	}
+	if len(revisions) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No revision found for %s %q\n", kind, owner.GetName())
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "REVISION\tCREATED\tDIGEST\tKIT\tIMAGE")
+	for _, r := range revisions {
+		kit := ""
+		if r.IntegrationKit != nil {
+			kit = fmt.Sprintf("%s/%s", r.IntegrationKit.Namespace, r.IntegrationKit.Name)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", r.Number, r.Timestamp.Format(time.RFC3339), r.Digest, kit, r.Image)
+	}
+
+	return w.Flush()
+}
+
+// getRevisionOwner returns the resource the revisions of the given name belong to.
+// Pipes take precedence over Integrations, as the Integration generated for a Pipe has the same name.
+func getRevisionOwner(o *RootCmdOptions, c client.Client, name string) (k8sclient.Object, string, error) {
+	key := k8sclient.ObjectKey{
+		Namespace: o.Namespace,
+		Name:      name,
+	}
+
+	p := v1.NewPipe(o.Namespace, name)
+	if err := c.Get(o.Context, key, &p); err == nil {
+		return &p, v1.PipeKind, nil
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, "", err
+	}
+
+	it := v1.NewIntegration(o.Namespace, name)
+	if err := c.Get(o.Context, key, &it); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, "", fmt.Errorf("no Integration or Pipe named %q found in namespace %s", name, o.Namespace)
+		}
+		return nil, "", err
+	}
+
+	return &it, v1.IntegrationKind, nil
+}
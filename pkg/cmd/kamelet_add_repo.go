@@ -30,7 +30,7 @@ import (
 )
 
 // kameletRepositoryURIRegexp is the regular expression used to validate the URI of a Kamelet repository.
-var kameletRepositoryURIRegexp = regexp.MustCompile(`^github:[^/]+/[^/]+((/[^/]+)*)?$`)
+var kameletRepositoryURIRegexp = regexp.MustCompile(`^(github:[^/]+/[^/]+((/[^/]+)*)?|(dir|file):(//)?/[^@]+(@[^@]+)?|https?://[^/]+/.+|mvn:[^:/]+:[^:/]+:[^:/]+)$`)
 
 func newKameletAddRepoCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *kameletAddRepoCommandOptions) {
 	options := kameletAddRepoCommandOptions{
@@ -40,9 +40,14 @@ func newKameletAddRepoCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *kame
 	}
 
 	cmd := cobra.Command{
-		Use:     "add-repo github:owner/repo[/path_to_kamelets_folder][@version] ...",
-		Short:   "Add a Kamelet repository",
-		Long:    `Add a Kamelet repository.`,
+		Use:   "add-repo github:owner/repo[/path_to_kamelets_folder][@version] | dir:/path_to_kamelets_folder[@ref] | http[s]://host/path_to_index_or_bundle | mvn:groupId:artifactId:version ...",
+		Short: "Add a Kamelet repository",
+		Long: `Add a Kamelet repository.
+
+A repository is either a GitHub repository, a directory of the operator file system, e.g., a mounted volume,
+an index file or a .tar.gz bundle served by an HTTP server, or a Maven artifact containing a kamelets folder,
+e.g., the Kamelet catalog org.apache.camel.kamelets:camel-kamelets, resolved with the Maven configuration of the platform.
+When a ref is set, the directory must belong to a git working tree, and the Kamelets are read from that ref.`,
 		PreRunE: decode(&options, options.Flags),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := options.validate(args); err != nil {
@@ -136,7 +141,7 @@ func (o *kameletUpdateRepoCommandOptions) findIntegrationPlatform(cmd *cobra.Com
 
 func checkURI(uri string, repositories []v1.KameletRepositorySpec) error {
 	if !kameletRepositoryURIRegexp.MatchString(uri) {
-		return fmt.Errorf("malformed Kamelet repository uri %s, the expected format is github:owner/repo[/path_to_kamelets_folder][@version]", uri)
+		return fmt.Errorf("malformed Kamelet repository uri %s, the expected format is github:owner/repo[/path_to_kamelets_folder][@version], dir:/path_to_kamelets_folder[@ref], http[s]://host/path_to_index_or_bundle or mvn:groupId:artifactId:version", uri)
 	}
 	for _, repo := range repositories {
 		if repo.URI == uri {
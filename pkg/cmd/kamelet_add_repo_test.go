@@ -73,6 +73,12 @@ func TestKameletAddRepoInvalidRepositoryURI(t *testing.T) {
 	require.Error(t, checkURI("github:", repositories))
 	require.Error(t, checkURI("github:foo", repositories))
 	require.Error(t, checkURI("github:foo/", repositories))
+	require.Error(t, checkURI("dir:", repositories))
+	require.Error(t, checkURI("dir:relative/path", repositories))
+	require.Error(t, checkURI("dir:/path@", repositories))
+	require.Error(t, checkURI("https://host", repositories))
+	require.Error(t, checkURI("mvn:org.apache.camel.kamelets:camel-kamelets", repositories))
+	require.Error(t, checkURI("mvn:org.apache.camel.kamelets:camel-kamelets:", repositories))
 }
 
 func TestKameletAddRepoValidRepositoryURI(t *testing.T) {
@@ -81,6 +87,12 @@ func TestKameletAddRepoValidRepositoryURI(t *testing.T) {
 	require.NoError(t, checkURI("github:foo/bar/some/path", repositories))
 	require.NoError(t, checkURI("github:foo/bar@1.0", repositories))
 	require.NoError(t, checkURI("github:foo/bar/some/path@1.0", repositories))
+	require.NoError(t, checkURI("dir:/kamelets", repositories))
+	require.NoError(t, checkURI("dir:/mirror/camel-kamelets/kamelets@v4.0.0", repositories))
+	require.NoError(t, checkURI("file:///kamelets", repositories))
+	require.NoError(t, checkURI("https://artifactory.example.com/kamelets/index.yaml", repositories))
+	require.NoError(t, checkURI("http://mirror/kamelets.tar.gz#sha256=0123", repositories))
+	require.NoError(t, checkURI("mvn:org.apache.camel.kamelets:camel-kamelets:4.0.0", repositories))
 }
 
 func TestKameletAddRepoDuplicateRepositoryURI(t *testing.T) {
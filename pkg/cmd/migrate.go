@@ -0,0 +1,208 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/controller/kameletbinding"
+)
+
+const (
+	migratePollInterval = 2 * time.Second
+	migratePollTimeout  = 2 * time.Minute
+)
+
+func newCmdMigrate(rootCmdOptions *RootCmdOptions) (*cobra.Command, *migrateCmdOptions) {
+	options := migrateCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "migrate [kameletbinding1] [kameletbinding2] ...",
+		Short: "Migrate KameletBindings to Pipes.",
+		Long: `Replace one or more KameletBindings with equivalent Pipes. Each KameletBinding is marked as migrated, and released from the ownership ` +
+			`of its Integration, which keeps running, so that it can be safely deleted afterwards. Each Pipe takes over the Integration once it's initialized, ` +
+			`and the Pipes that cannot be initialized are reported.`,
+		PreRunE: decode(&options, options.Flags),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().Bool("all", false, "Migrate all KameletBindings")
+	cmd.Flags().StringP("output", "o", "", "Output format of the Pipes, without migrating the KameletBindings. One of: json|yaml")
+	cmd.Flags().Bool("wait", false, "Wait for the Pipes to be initialized, and report the ones that cannot take over the Integrations")
+
+	return &cmd, &options
+}
+
+type migrateCmdOptions struct {
+	*RootCmdOptions
+	MigrateAll   bool   `mapstructure:"all"`
+	OutputFormat string `mapstructure:"output" yaml:",omitempty"`
+	Wait         bool   `mapstructure:"wait"`
+}
+
+func (o *migrateCmdOptions) validate(args []string) error {
+	if o.MigrateAll && len(args) > 0 {
+		return errors.New("invalid combination: --all flag is set and at least one KameletBinding name is provided")
+	}
+	if !o.MigrateAll && len(args) == 0 {
+		return errors.New("invalid combination: provide one or several KameletBinding names or set --all flag for all KameletBindings")
+	}
+
+	return nil
+}
+
+func (o *migrateCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+	var bindings []v1alpha1.KameletBinding
+	if o.MigrateAll {
+		if bindings, err = o.listAllKameletBindings(c); err != nil {
+			return err
+		}
+	} else if bindings, err = o.getKameletBindings(c, args); err != nil {
+		return err
+	}
+
+	if o.OutputFormat != "" {
+		return o.showPipes(cmd, c, bindings)
+	}
+
+	migrated := 0
+	failed := 0
+	pipes := make([]*v1.Pipe, 0, len(bindings))
+	for i := range bindings {
+		binding := &bindings[i]
+		pipe, created, err := kameletbinding.MigrateToPipe(o.Context, c, binding)
+		switch {
+		case err != nil:
+			fmt.Fprintln(cmd.ErrOrStderr(), err.Error())
+			failed++
+		case created:
+			fmt.Fprintf(cmd.OutOrStdout(), "kameletbinding %q migrated to pipe %q\n", binding.Name, pipe.Name)
+			pipes = append(pipes, pipe)
+			migrated++
+		default:
+			fmt.Fprintf(cmd.OutOrStdout(), "kameletbinding %q already migrated to pipe %q\n", binding.Name, pipe.Name)
+			pipes = append(pipes, pipe)
+		}
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), migrated, "KameletBindings have been migrated")
+	uninitialized, err := o.checkPipes(cmd, c, pipes)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d KameletBindings could not be migrated", failed)
+	}
+	if uninitialized > 0 {
+		return fmt.Errorf("%d Pipes could not be initialized: their Integrations keep running unchanged until the errors are fixed", uninitialized)
+	}
+	return nil
+}
+
+// checkPipes reports the Pipes that cannot be initialized, and therefore cannot take over the Integrations of the
+// KameletBindings they replace, and returns their number. When waiting, the Pipes that are not initialized yet are
+// checked until they are.
+func (o *migrateCmdOptions) checkPipes(cmd *cobra.Command, c client.Client, pipes []*v1.Pipe) (int, error) {
+	failed := 0
+	for _, p := range pipes {
+		pipe := v1.NewPipe(p.Namespace, p.Name)
+		check := func(ctx context.Context) (bool, error) {
+			if err := c.Get(ctx, k8sclient.ObjectKeyFromObject(&pipe), &pipe); err != nil {
+				return false, err
+			}
+			return !o.Wait || pipe.Status.Phase != v1.PipePhaseNone, nil
+		}
+		if err := wait.PollUntilContextTimeout(o.Context, migratePollInterval, migratePollTimeout, true, check); err != nil {
+			if !wait.Interrupted(err) {
+				return failed, err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "pipe %q is not initialized yet\n", pipe.Name)
+			continue
+		}
+		if pipe.Status.Phase == v1.PipePhaseError {
+			message := "unknown reason"
+			if condition := pipe.Status.GetCondition(v1.PipeIntegrationConditionError); condition != nil {
+				message = condition.Message
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "pipe %q cannot be initialized: %s\n", pipe.Name, message)
+			failed++
+		}
+	}
+	return failed, nil
+}
+
+func (o *migrateCmdOptions) showPipes(cmd *cobra.Command, c client.Client, bindings []v1alpha1.KameletBinding) error {
+	for i := range bindings {
+		pipe, err := kameletbinding.ConvertToPipe(&bindings[i])
+		if err != nil {
+			return fmt.Errorf("cannot convert KameletBinding %q: %w", bindings[i].Name, err)
+		}
+		if i > 0 && o.OutputFormat == "yaml" {
+			fmt.Fprintln(cmd.OutOrStdout(), "---")
+		}
+		if err := showPipeOutput(cmd, pipe, o.OutputFormat, c.GetScheme()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *migrateCmdOptions) listAllKameletBindings(c client.Client) ([]v1alpha1.KameletBinding, error) {
+	list := v1alpha1.NewKameletBindingList()
+	if err := c.List(o.Context, &list, k8sclient.InNamespace(o.Namespace)); err != nil {
+		return nil, fmt.Errorf("could not retrieve KameletBindings from namespace %s: %w", o.Namespace, err)
+	}
+	return list.Items, nil
+}
+
+func (o *migrateCmdOptions) getKameletBindings(c client.Client, names []string) ([]v1alpha1.KameletBinding, error) {
+	bindings := make([]v1alpha1.KameletBinding, 0, len(names))
+	for _, n := range names {
+		binding := v1alpha1.NewKameletBinding(o.Namespace, n)
+		key := k8sclient.ObjectKey{
+			Name:      n,
+			Namespace: o.Namespace,
+		}
+		if err := c.Get(o.Context, key, &binding); err != nil {
+			return nil, fmt.Errorf("could not find KameletBinding %s in namespace %s: %w", n, o.Namespace, err)
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings, nil
+}
@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+const cmdMigrate = "migrate"
+
+func initializeMigrateCmdOptions(t *testing.T, initObjs ...runtime.Object) (*migrateCmdOptions, *cobra.Command) {
+	t.Helper()
+
+	defaultIntegrationPlatform := v1.NewIntegrationPlatform("default", platform.DefaultPlatformName)
+	fakeClient, err := test.NewFakeClient(append(initObjs, &defaultIntegrationPlatform)...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	migrateCmd, migrateOptions := newCmdMigrate(options)
+	rootCmd.AddCommand(migrateCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return migrateOptions, rootCmd
+}
+
+func newTestMigrateKameletBinding(name string) *v1alpha1.KameletBinding {
+	binding := v1alpha1.NewKameletBinding("default", name)
+	sourceURI := "timer:tick"
+	sinkURI := "log:info"
+	binding.Spec.Source = v1alpha1.Endpoint{URI: &sourceURI}
+	binding.Spec.Sink = v1alpha1.Endpoint{URI: &sinkURI}
+	return &binding
+}
+
+func TestMigrateInvalidArguments(t *testing.T) {
+	_, rootCmd := initializeMigrateCmdOptions(t)
+	output, err := test.ExecuteCommand(rootCmd, cmdMigrate)
+	require.Error(t, err)
+	assert.Contains(t, output, "provide one or several KameletBinding names or set --all flag")
+
+	_, rootCmd = initializeMigrateCmdOptions(t)
+	output, err = test.ExecuteCommand(rootCmd, cmdMigrate, "--all", "my-binding")
+	require.Error(t, err)
+	assert.Contains(t, output, "--all flag is set and at least one KameletBinding name is provided")
+}
+
+func TestMigrateOutputYaml(t *testing.T) {
+	migrateOptions, rootCmd := initializeMigrateCmdOptions(t, newTestMigrateKameletBinding("my-binding"))
+	output, err := test.ExecuteCommand(rootCmd, cmdMigrate, "my-binding", "-o", "yaml", "-n", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "yaml", migrateOptions.OutputFormat)
+	assert.Equal(t, `apiVersion: camel.apache.org/v1
+kind: Pipe
+metadata:
+  creationTimestamp: null
+  name: my-binding
+  namespace: default
+spec:
+  sink:
+    uri: log:info
+  source:
+    uri: timer:tick
+status: {}
+`, output)
+}
+
+func TestMigrateAll(t *testing.T) {
+	migrateOptions, rootCmd := initializeMigrateCmdOptions(t,
+		newTestMigrateKameletBinding("binding-a"), newTestMigrateKameletBinding("binding-b"))
+	output, err := test.ExecuteCommand(rootCmd, cmdMigrate, "--all", "-n", "default")
+	require.NoError(t, err)
+	assert.True(t, migrateOptions.MigrateAll)
+	assert.Equal(t, `kameletbinding "binding-a" migrated to pipe "binding-a"
+kameletbinding "binding-b" migrated to pipe "binding-b"
+2 KameletBindings have been migrated
+`, output)
+}
+
+func TestMigrateReportsPipeErrors(t *testing.T) {
+	binding := newTestMigrateKameletBinding("my-binding")
+	binding.Annotations = map[string]string{v1alpha1.KameletBindingMigratedAnnotation: "true"}
+	pipe := v1.NewPipe("default", "my-binding")
+	pipe.Status.Phase = v1.PipePhaseError
+	pipe.Status.SetCondition(v1.PipeIntegrationConditionError, corev1.ConditionFalse, v1.PipeKameletsNotReadyReason,
+		"kamelets [timer-source (missing property)] are not ready")
+	_, rootCmd := initializeMigrateCmdOptions(t, binding, &pipe)
+	output, err := test.ExecuteCommand(rootCmd, cmdMigrate, "my-binding", "-n", "default")
+	require.Error(t, err)
+	assert.Contains(t, output, `kameletbinding "my-binding" already migrated to pipe "my-binding"`)
+	assert.Contains(t, output, `pipe "my-binding" cannot be initialized: kamelets [timer-source (missing property)] are not ready`)
+	assert.Contains(t, output, "1 Pipes could not be initialized")
+}
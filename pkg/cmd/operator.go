@@ -42,6 +42,8 @@ func newCmdOperator(rootCmdOptions *RootCmdOptions) (*cobra.Command, *operatorCm
 	cmd.Flags().Int32("monitoring-port", 8080, "The port of the metrics endpoint")
 	cmd.Flags().Bool("leader-election", true, "Use leader election")
 	cmd.Flags().String("leader-election-id", "", "Use the given ID as the leader election Lease name")
+	cmd.Flags().Int32("webhook-port", 0, "The port of the admission webhook server, the webhooks are disabled when not set")
+	cmd.Flags().String("webhook-cert-dir", "", "The directory containing the tls.crt and tls.key files of the admission webhook server")
 
 	return &cmd, &options
 }
@@ -51,6 +53,8 @@ type operatorCmdOptions struct {
 	MonitoringPort   int32  `mapstructure:"monitoring-port"`
 	LeaderElection   bool   `mapstructure:"leader-election"`
 	LeaderElectionID string `mapstructure:"leader-election-id"`
+	WebhookPort      int32  `mapstructure:"webhook-port"`
+	WebhookCertDir   string `mapstructure:"webhook-cert-dir"`
 }
 
 func (o *operatorCmdOptions) run(_ *cobra.Command, _ []string) {
@@ -64,5 +68,5 @@ func (o *operatorCmdOptions) run(_ *cobra.Command, _ []string) {
 		}
 	}
 
-	operator.Run(o.HealthPort, o.MonitoringPort, o.LeaderElection, leaderElectionID)
+	operator.Run(o.HealthPort, o.MonitoringPort, o.LeaderElection, leaderElectionID, o.WebhookPort, o.WebhookCertDir)
 }
@@ -52,6 +52,7 @@ import (
 	zapctrl "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 
@@ -66,6 +67,8 @@ import (
 	"github.com/apache/camel-k/v2/pkg/util/defaults"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
 	logutil "github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/revision"
+	"github.com/apache/camel-k/v2/pkg/webhook"
 )
 
 var log = logutil.Log.WithName("cmd")
@@ -83,7 +86,7 @@ func printVersion() {
 }
 
 // Run starts the Camel K operator.
-func Run(healthPort, monitoringPort int32, leaderElection bool, leaderElectionID string) {
+func Run(healthPort, monitoringPort int32, leaderElection bool, leaderElectionID string, webhookPort int32, webhookCertDir string) {
 
 	flag.Parse()
 
@@ -195,6 +198,12 @@ func Run(healthPort, monitoringPort int32, leaderElection bool, leaderElectionID
 		&servingv1.Service{}: {Label: selector},
 	}
 
+	hasRevisionLabel, err := labels.NewRequirement(revision.KindLabel, selection.Exists, []string{})
+	exitOnError(err, "cannot create revision label selector")
+	selectors[&appsv1.ControllerRevision{}] = cache.ByObject{
+		Label: labels.NewSelector().Add(*hasRevisionLabel),
+	}
+
 	if ok, err := kubernetes.IsAPIResourceInstalled(bootstrapClient, batchv1.SchemeGroupVersion.String(), reflect.TypeOf(batchv1.CronJob{}).Name()); ok && err == nil {
 		selectors[&batchv1.CronJob{}] = cache.ByObject{
 			Label: selector,
@@ -205,7 +214,7 @@ func Run(healthPort, monitoringPort int32, leaderElection bool, leaderElectionID
 		ByObject: selectors,
 	}
 
-	mgr, err := manager.New(cfg, manager.Options{
+	managerOptions := manager.Options{
 		Namespace:                     watchNamespace,
 		EventBroadcaster:              broadcaster,
 		LeaderElection:                leaderElection,
@@ -216,7 +225,15 @@ func Run(healthPort, monitoringPort int32, leaderElection bool, leaderElectionID
 		HealthProbeBindAddress:        ":" + strconv.Itoa(int(healthPort)),
 		MetricsBindAddress:            ":" + strconv.Itoa(int(monitoringPort)),
 		Cache:                         options,
-	})
+	}
+	if webhookPort > 0 {
+		managerOptions.WebhookServer = ctrlwebhook.NewServer(ctrlwebhook.Options{
+			Port:    int(webhookPort),
+			CertDir: webhookCertDir,
+		})
+	}
+
+	mgr, err := manager.New(cfg, managerOptions)
 	exitOnError(err, "")
 
 	log.Info("Configuring manager")
@@ -225,6 +242,10 @@ func Run(healthPort, monitoringPort int32, leaderElection bool, leaderElectionID
 	ctrlClient, err := client.FromManager(mgr)
 	exitOnError(err, "")
 	exitOnError(controller.AddToManager(ctx, mgr, ctrlClient), "")
+	if webhookPort > 0 {
+		log.Infof("Registering admission webhooks on port %d", webhookPort)
+		exitOnError(webhook.AddToManager(mgr, ctrlClient), "cannot register admission webhooks")
+	}
 
 	log.Info("Installing operator resources")
 	installCtx, installCancel := context.WithTimeout(ctx, 1*time.Minute)
@@ -83,3 +83,11 @@ func TestOperatorMonitoringPortFlag(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int32(7172), operatorCmdOptions.MonitoringPort)
 }
+
+func TestOperatorWebhookFlags(t *testing.T) {
+	operatorCmdOptions, rootCmd, _ := initializeOperatorCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdOperator, "--webhook-port", "9443", "--webhook-cert-dir", "/tmp/certs")
+	require.NoError(t, err)
+	assert.Equal(t, int32(9443), operatorCmdOptions.WebhookPort)
+	assert.Equal(t, "/tmp/certs", operatorCmdOptions.WebhookCertDir)
+}
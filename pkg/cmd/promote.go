@@ -539,6 +539,18 @@ func (o *promoteCmdOptions) editPipe(kb *v1.Pipe, it *v1.Integration) *v1.Pipe {
 			}
 		}
 	}
+	if dst.Spec.Routing != nil {
+		for _, branch := range dst.Spec.Routing.Branches {
+			for _, step := range branch.Steps {
+				if step.Ref != nil {
+					step.Ref.Namespace = o.To
+				}
+			}
+			if branch.Sink.Ref != nil {
+				branch.Sink.Ref.Namespace = o.To
+			}
+		}
+	}
 	return &dst
 }
 
@@ -0,0 +1,162 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/revision"
+)
+
+func newCmdRollback(rootCmdOptions *RootCmdOptions) (*cobra.Command, *rollbackCmdOptions) {
+	options := rollbackCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "rollback <integration|pipe> [--to-revision N]",
+		Short: "Roll back an Integration or a Pipe to a previous revision",
+		Long: `Restore the specification recorded for a previous revision of an Integration or a Pipe.
+The IntegrationKit the revision was running with is reused when it still exists, so that no rebuild is needed.`,
+		Args:    options.validateArgs,
+		PreRunE: decode(&options, options.Flags),
+		RunE:    options.run,
+	}
+
+	cmd.Flags().Int64("to-revision", 0, "The revision to roll back to. Defaults to the revision preceding the latest one")
+
+	return &cmd, &options
+}
+
+type rollbackCmdOptions struct {
+	*RootCmdOptions
+	ToRevision int64 `mapstructure:"to-revision"`
+}
+
+func (o *rollbackCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("rollback command expects exactly one Integration or Pipe name")
+	}
+	return nil
+}
+
+func (o *rollbackCmdOptions) run(cmd *cobra.Command, args []string) error {
+	if o.ToRevision < 0 {
+		return errors.New("invalid revision: it must be a positive number")
+	}
+
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	owner, kind, err := getRevisionOwner(o.RootCmdOptions, c, args[0])
+	if err != nil {
+		return err
+	}
+
+	r, err := o.findRevision(c, kind, owner.GetName())
+	if err != nil {
+		return err
+	}
+
+	switch res := owner.(type) {
+	case *v1.Integration:
+		err = o.rollbackIntegration(cmd, c, res, r)
+	case *v1.Pipe:
+		err = o.rollbackPipe(c, res, r)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s %q rolled back to revision %d\n", kind, owner.GetName(), r.Number)
+	return nil
+}
+
+func (o *rollbackCmdOptions) findRevision(c client.Client, kind string, name string) (*revision.Revision, error) {
+	revisions, err := revision.List(o.Context, c, o.Namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.ToRevision == 0 {
+		if len(revisions) < 2 {
+			return nil, fmt.Errorf("no previous revision found for %s %q", kind, name)
+		}
+		return &revisions[len(revisions)-2], nil
+	}
+
+	for i := range revisions {
+		if revisions[i].Number == o.ToRevision {
+			return &revisions[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("revision %d not found for %s %q", o.ToRevision, kind, name)
+}
+
+func (o *rollbackCmdOptions) rollbackIntegration(cmd *cobra.Command, c client.Client, it *v1.Integration, r *revision.Revision) error {
+	spec := v1.IntegrationSpec{}
+	if err := r.DecodeSpec(&spec); err != nil {
+		return err
+	}
+
+	// Pin the kit the revision was running with, when it's still available, to avoid a rebuild
+	if spec.IntegrationKit == nil && r.IntegrationKit != nil {
+		kit, err := kubernetes.GetIntegrationKit(o.Context, c, r.IntegrationKit.Name, r.IntegrationKit.Namespace)
+		switch {
+		case err == nil && kit.Status.Phase == v1.IntegrationKitPhaseReady:
+			spec.IntegrationKit = &corev1.ObjectReference{
+				Namespace: kit.Namespace,
+				Name:      kit.Name,
+			}
+		case err == nil, k8serrors.IsNotFound(err):
+			fmt.Fprintf(cmd.OutOrStdout(), "Integration kit %s/%s is not available anymore, the Integration will be rebuilt\n",
+				r.IntegrationKit.Namespace, r.IntegrationKit.Name)
+		default:
+			return err
+		}
+	}
+
+	patch := k8sclient.MergeFrom(it.DeepCopy())
+	it.Spec = spec
+	return c.Patch(o.Context, it, patch)
+}
+
+func (o *rollbackCmdOptions) rollbackPipe(c client.Client, p *v1.Pipe, r *revision.Revision) error {
+	spec := v1.PipeSpec{}
+	if err := r.DecodeSpec(&spec); err != nil {
+		return err
+	}
+
+	// The operator reuses the kit matching the Integration generated for the restored spec
+	patch := k8sclient.MergeFrom(p.DeepCopy())
+	p.Spec = spec
+	return c.Patch(o.Context, p, patch)
+}
@@ -0,0 +1,136 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/revision"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+const (
+	cmdHistory  = "history"
+	cmdRollback = "rollback"
+)
+
+// nolint: unparam
+func initializeRollbackCmdOptions(t *testing.T, initObjs ...runtime.Object) (*cobra.Command, client.Client) {
+	t.Helper()
+	fakeClient, err := test.NewFakeClient(initObjs...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	addTestRollbackCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return rootCmd, fakeClient
+}
+
+func addTestRollbackCmd(options RootCmdOptions, rootCmd *cobra.Command) {
+	historyCmd, _ := newCmdHistory(&options)
+	historyCmd.Args = test.ArbitraryArgs
+	rootCmd.AddCommand(historyCmd)
+	rollbackCmd, _ := newCmdRollback(&options)
+	rollbackCmd.Args = test.ArbitraryArgs
+	rootCmd.AddCommand(rollbackCmd)
+}
+
+func recordTestRevisions(t *testing.T, c client.Client, it *v1.Integration) {
+	t.Helper()
+
+	for _, dep := range []string{"camel:timer", "camel:log"} {
+		it.Spec.Dependencies = []string{dep}
+		kit := &corev1.ObjectReference{Namespace: "default", Name: "kit-" + dep[6:]}
+		_, err := revision.Record(context.TODO(), c, it, v1.IntegrationKind, it.Spec, "v-"+dep[6:], kit, "image-"+dep[6:])
+		require.NoError(t, err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	it := v1.NewIntegration("default", "my-it")
+	rootCmd, c := initializeRollbackCmdOptions(t, &it)
+	recordTestRevisions(t, c, &it)
+
+	output, err := test.ExecuteCommand(rootCmd, cmdHistory, "my-it")
+	require.NoError(t, err)
+	assert.Contains(t, output, "REVISION")
+	assert.Regexp(t, `1\s+\S+\s+v-timer\s+default/kit-timer\s+image-timer`, output)
+	assert.Regexp(t, `2\s+\S+\s+v-log\s+default/kit-log\s+image-log`, output)
+}
+
+func TestHistoryNotFound(t *testing.T) {
+	rootCmd, _ := initializeRollbackCmdOptions(t)
+	_, err := test.ExecuteCommand(rootCmd, cmdHistory, "my-it")
+	require.Error(t, err)
+	assert.Equal(t, `no Integration or Pipe named "my-it" found in namespace default`, err.Error())
+}
+
+func TestRollbackReusesKit(t *testing.T) {
+	it := v1.NewIntegration("default", "my-it")
+	kit := v1.NewIntegrationKit("default", "kit-timer")
+	kit.Status.Phase = v1.IntegrationKitPhaseReady
+	rootCmd, c := initializeRollbackCmdOptions(t, &it, kit)
+	recordTestRevisions(t, c, &it)
+
+	output, err := test.ExecuteCommand(rootCmd, cmdRollback, "my-it")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Integration \"my-it\" rolled back to revision 1\n")
+
+	rolledBack := v1.NewIntegration("default", "my-it")
+	require.NoError(t, c.Get(context.TODO(), k8sclient.ObjectKeyFromObject(&rolledBack), &rolledBack))
+	assert.Equal(t, []string{"camel:timer"}, rolledBack.Spec.Dependencies)
+	assert.Equal(t, &corev1.ObjectReference{Namespace: "default", Name: "kit-timer"}, rolledBack.Spec.IntegrationKit)
+}
+
+func TestRollbackWithoutKit(t *testing.T) {
+	it := v1.NewIntegration("default", "my-it")
+	rootCmd, c := initializeRollbackCmdOptions(t, &it)
+	recordTestRevisions(t, c, &it)
+
+	output, err := test.ExecuteCommand(rootCmd, cmdRollback, "my-it", "--to-revision", "1")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Integration kit default/kit-timer is not available anymore, the Integration will be rebuilt")
+
+	rolledBack := v1.NewIntegration("default", "my-it")
+	require.NoError(t, c.Get(context.TODO(), k8sclient.ObjectKeyFromObject(&rolledBack), &rolledBack))
+	assert.Equal(t, []string{"camel:timer"}, rolledBack.Spec.Dependencies)
+	assert.Nil(t, rolledBack.Spec.IntegrationKit)
+}
+
+func TestRollbackUnknownRevision(t *testing.T) {
+	it := v1.NewIntegration("default", "my-it")
+	rootCmd, c := initializeRollbackCmdOptions(t, &it)
+	recordTestRevisions(t, c, &it)
+
+	_, err := test.ExecuteCommand(rootCmd, cmdRollback, "my-it", "--to-revision", "5")
+	require.Error(t, err)
+	assert.Equal(t, `revision 5 not found for Integration "my-it"`, err.Error())
+}
@@ -142,9 +142,11 @@ func addKamelSubcommands(cmd *cobra.Command, options *RootCmdOptions) {
 	cmd.AddCommand(cmdOnly(newCmdUninstall(options)))
 	cmd.AddCommand(cmdOnly(newCmdLog(options)))
 	cmd.AddCommand(newCmdKit(options))
+	cmd.AddCommand(newCmdBuild(options))
 	cmd.AddCommand(cmdOnly(newCmdReset(options)))
 	cmd.AddCommand(newCmdDescribe(options))
 	cmd.AddCommand(cmdOnly(newCmdRebuild(options)))
+	cmd.AddCommand(cmdOnly(newCmdMigrate(options)))
 	cmd.AddCommand(cmdOnly(newCmdOperator(options)))
 	cmd.AddCommand(cmdOnly(newCmdBuilder(options)))
 	cmd.AddCommand(cmdOnly(newCmdDebug(options)))
@@ -153,6 +155,10 @@ func addKamelSubcommands(cmd *cobra.Command, options *RootCmdOptions) {
 	cmd.AddCommand(cmdOnly(newCmdPromote(options)))
 	cmd.AddCommand(newCmdKamelet(options))
 	cmd.AddCommand(cmdOnly(newCmdConfig(options)))
+	cmd.AddCommand(cmdOnly(newCmdExport(options)))
+	cmd.AddCommand(cmdOnly(newCmdDiff(options)))
+	cmd.AddCommand(cmdOnly(newCmdHistory(options)))
+	cmd.AddCommand(cmdOnly(newCmdRollback(options)))
 }
 
 func addHelpSubCommands(cmd *cobra.Command) error {
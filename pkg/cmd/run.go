@@ -93,26 +93,39 @@ func newCmdRun(rootCmdOptions *RootCmdOptions) (*cobra.Command, *runCmdOptions)
 		Annotations:       make(map[string]string),
 	}
 
+	cmd.Flags().BoolP("wait", "w", false, "Wait for the integration to be running")
+	cmd.Flags().Bool("logs", false, "Print integration logs")
+	cmd.Flags().Bool("sync", false, "Synchronize the local source file with the cluster, republishing at each change")
+	cmd.Flags().Bool("dev", false, "Enable Dev mode (equivalent to \"-w --logs --sync\")")
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
+
+	cmd.Flags().Bool("save", false, "Save the run parameters into the default kamel configuration file (kamel-config.yaml)")
+
+	configureIntegrationFlags(&cmd)
+
+	// completion support
+	configureKnownCompletions(&cmd)
+
+	return &cmd, &options
+}
+
+// configureIntegrationFlags adds the flags used to build the Integration spec.
+func configureIntegrationFlags(cmd *cobra.Command) {
 	cmd.Flags().String("name", "", "The integration name")
 	cmd.Flags().String("image", "", "An image built externally (ie, via CICD). Enabling it will skip the Integration build phase.")
 	cmd.Flags().StringArrayP("connect", "c", nil, "A Service that the integration should bind to, specified as [[apigroup/]version:]kind:[namespace/]name")
 	cmd.Flags().StringArrayP("dependency", "d", nil, `A dependency that should be included, e.g., "-d camel:mail" for a Camel component, "-d mvn:org.my:app:1.0" for a Maven dependency`)
-	cmd.Flags().BoolP("wait", "w", false, "Wait for the integration to be running")
 	cmd.Flags().StringP("kit", "k", "", "The kit used to run the integration")
 	cmd.Flags().StringArrayP("property", "p", nil, "Add a runtime property or properties file from a path, a config map or a secret (syntax: [my-key=my-value|file:/path/to/my-conf.properties|[configmap|secret]:name])")
 	cmd.Flags().StringArray("build-property", nil, "Add a build time property or properties file from a path, a config map or a secret (syntax: [my-key=my-value|file:/path/to/my-conf.properties|[configmap|secret]:name]])")
 	cmd.Flags().StringArray("config", nil, "Add a runtime configuration from a Configmap or a Secret (syntax: [configmap|secret]:name[/key], where name represents the configmap/secret name and key optionally represents the configmap/secret key to be filtered)")
 	cmd.Flags().StringArray("resource", nil, "Add a runtime resource from a Configmap or a Secret (syntax: [configmap|secret]:name[/key][@path], where name represents the configmap/secret name, key optionally represents the configmap/secret key to be filtered and path represents the destination path)")
 	cmd.Flags().StringArray("maven-repository", nil, "Add a maven repository")
-	cmd.Flags().Bool("logs", false, "Print integration logs")
-	cmd.Flags().Bool("sync", false, "Synchronize the local source file with the cluster, republishing at each change")
-	cmd.Flags().Bool("dev", false, "Enable Dev mode (equivalent to \"-w --logs --sync\")")
 	cmd.Flags().Bool("use-flows", true, "Write yaml sources as Flow objects in the integration custom resource")
 	cmd.Flags().StringP("operator-id", "x", "camel-k", "Operator id selected to manage this integration.")
 	cmd.Flags().String("profile", "", "Trait profile used for deployment")
 	cmd.Flags().String("integration-profile", "", "Integration profile used for deployment")
 	cmd.Flags().StringArrayP("trait", "t", nil, "Configure a trait. E.g. \"-t service.enabled=false\"")
-	cmd.Flags().StringP("output", "o", "", "Output format. One of: json|yaml")
 	cmd.Flags().Bool("compression", false, "Enable storage of sources and resources as a compressed binary blobs")
 	cmd.Flags().StringArray("open-api", nil, "Add an OpenAPI spec (syntax: [configmap|file]:name)")
 	cmd.Flags().StringArrayP("volume", "v", nil, "Mount a volume into the integration container. E.g \"-v pvcname:/container/path\"")
@@ -123,13 +136,6 @@ func newCmdRun(rootCmdOptions *RootCmdOptions) (*cobra.Command, *runCmdOptions)
 	cmd.Flags().String("pod-template", "", "The path of the YAML file containing a PodSpec template to be used for the Integration pods")
 	cmd.Flags().String("service-account", "", "The SA to use to run this Integration")
 	cmd.Flags().Bool("force", false, "Force creation of integration regardless of potential misconfiguration.")
-
-	cmd.Flags().Bool("save", false, "Save the run parameters into the default kamel configuration file (kamel-config.yaml)")
-
-	// completion support
-	configureKnownCompletions(&cmd)
-
-	return &cmd, &options
 }
 
 type runCmdOptions struct {
@@ -503,16 +509,56 @@ func (o *runCmdOptions) syncIntegration(cmd *cobra.Command, c client.Client, sou
 }
 
 func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.Client, sources []string) (*v1.Integration, error) {
+	integration, existing, err := o.buildIntegration(cmd, c, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.OutputFormat != "" {
+		return nil, showIntegrationOutput(cmd, integration, o.OutputFormat)
+	}
+
+	name := integration.Name
+	if existing == nil {
+		err = c.Create(o.Context, integration)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), `Integration "`+name+`" created`)
+	} else {
+		patch := ctrl.MergeFrom(existing)
+		d, err := patch.Data(integration)
+		if err != nil {
+			return nil, err
+		}
+
+		if string(d) == "{}" {
+			fmt.Fprintln(cmd.OutOrStdout(), `Integration "`+name+`" unchanged`)
+			return integration, nil
+		}
+		err = c.Patch(o.Context, integration, patch)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), `Integration "`+name+`" updated`)
+	}
+
+	return integration, nil
+}
+
+// buildIntegration computes the Integration from the command options and the given sources.
+// It also returns the Integration existing in the cluster, if any.
+func (o *runCmdOptions) buildIntegration(cmd *cobra.Command, c client.Client, sources []string) (*v1.Integration, *v1.Integration, error) {
 	namespace := o.Namespace
 	name := o.GetIntegrationName(sources)
 
 	if name == "" {
-		return nil, errors.New("unable to determine integration name")
+		return nil, nil, errors.New("unable to determine integration name")
 	}
 
 	integration, existing, err := o.getIntegration(cmd, c, namespace, name)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var integrationKit *corev1.ObjectReference
@@ -534,13 +580,13 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 	o.applyLabels(integration)
 
 	if err := o.applyAnnotations(cmd, c, integration); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if o.ContainerImage == "" {
 		// Resolve resources
 		if err := o.resolveSources(cmd, sources, integration); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	} else {
 		// Source-less Integration as the user provided a container image built externally
@@ -548,21 +594,21 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 	}
 
 	if err := resolvePodTemplate(context.Background(), cmd, o.PodTemplate, &integration.Spec); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := o.convertOptionsToTraits(cmd, c, integration); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := o.applyDependencies(cmd, c, integration, name); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(o.Traits) > 0 {
 		catalog := trait.NewCatalog(c)
 		if err := configureTraits(o.Traits, &integration.Spec.Traits, catalog); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -570,35 +616,7 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		integration.Spec.ServiceAccountName = o.ServiceAccount
 	}
 
-	if o.OutputFormat != "" {
-		return nil, showIntegrationOutput(cmd, integration, o.OutputFormat)
-	}
-
-	if existing == nil {
-		err = c.Create(o.Context, integration)
-		if err != nil {
-			return nil, err
-		}
-		fmt.Fprintln(cmd.OutOrStdout(), `Integration "`+name+`" created`)
-	} else {
-		patch := ctrl.MergeFrom(existing)
-		d, err := patch.Data(integration)
-		if err != nil {
-			return nil, err
-		}
-
-		if string(d) == "{}" {
-			fmt.Fprintln(cmd.OutOrStdout(), `Integration "`+name+`" unchanged`)
-			return integration, nil
-		}
-		err = c.Patch(o.Context, integration, patch)
-		if err != nil {
-			return nil, err
-		}
-		fmt.Fprintln(cmd.OutOrStdout(), `Integration "`+name+`" updated`)
-	}
-
-	return integration, nil
+	return integration, existing, nil
 }
 
 func showIntegrationOutput(cmd *cobra.Command, integration *v1.Integration, outputFormat string) error {
@@ -150,10 +150,22 @@ func (r *reconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 		maxRunningBuilds:   ip.Status.Build.MaxRunningBuilds,
 		buildOrderStrategy: ip.Status.Build.BuildConfiguration.OrderStrategy,
 	}
+	// The quota per namespace is only defined by the operator platform,
+	// as the platforms of the other namespaces are managed by the namespace owners
+	quota := ip
+	if !platform.IsOperatorNamespace(ip.Namespace) {
+		if quota, err = platform.LookupForOperator(ctx, r.client); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if quota != nil {
+		buildMonitor.maxRunningBuildsPerNamespace = quota.Status.Build.MaxRunningBuildsPerNamespace
+	}
 
 	switch instance.BuilderConfiguration().Strategy {
 	case v1.BuildStrategyPod:
 		actions = []Action{
+			newCancelAction(),
 			newInitializePodAction(r.reader),
 			newScheduleAction(r.reader, buildMonitor),
 			newMonitorPodAction(r.reader),
@@ -162,6 +174,7 @@ func (r *reconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 		}
 	case v1.BuildStrategyRoutine:
 		actions = []Action{
+			newCancelAction(),
 			newInitializeRoutineAction(),
 			newScheduleAction(r.reader, buildMonitor),
 			newMonitorRoutineAction(),
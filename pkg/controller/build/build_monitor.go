@@ -20,6 +20,8 @@ package build
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
@@ -29,6 +31,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/platform"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
 )
 
@@ -37,15 +40,21 @@ const enqueuedMsg = "%s - the build (%s) gets enqueued"
 var runningBuilds sync.Map
 
 type Monitor struct {
-	maxRunningBuilds   int32
-	buildOrderStrategy v1.BuildOrderStrategy
+	maxRunningBuilds             int32
+	maxRunningBuildsPerNamespace int32
+	buildOrderStrategy           v1.BuildOrderStrategy
 }
 
 func (bm *Monitor) canSchedule(ctx context.Context, c ctrl.Reader, build *v1.Build) (bool, *v1.BuildCondition, error) {
 
 	var runningBuildsTotal int32
-	runningBuilds.Range(func(_, v interface{}) bool {
+	runningBuildsPerNamespace := make(map[string]int32)
+	runningBuilds.Range(func(k, v interface{}) bool {
 		runningBuildsTotal++
+		if key, ok := k.(string); ok {
+			namespace, _, _ := strings.Cut(key, string(types.Separator))
+			runningBuildsPerNamespace[namespace]++
+		}
 		return true
 	})
 
@@ -57,10 +66,18 @@ func (bm *Monitor) canSchedule(ctx context.Context, c ctrl.Reader, build *v1.Bui
 		requestNamespace = buildCreator.Namespace
 	}
 
+	// The builds waiting to be scheduled, that take precedence over this build
+	ahead, err := bm.buildsAhead(ctx, c, build, runningBuildsPerNamespace)
+	if err != nil {
+		return false, nil, err
+	}
+	position := len(ahead) + 1
+
 	if runningBuildsTotal >= bm.maxRunningBuilds {
 		reason := fmt.Sprintf(
-			"Maximum number of running builds (%d) exceeded",
+			"Maximum number of running builds (%d) exceeded, queue position %d",
 			runningBuildsTotal,
+			position,
 		)
 		Log.WithValues("request-namespace", requestNamespace, "request-name", requestName, "max-running-builds-limit", runningBuildsTotal).
 			ForBuild(build).Infof(enqueuedMsg, reason, build.Name)
@@ -68,6 +85,32 @@ func (bm *Monitor) canSchedule(ctx context.Context, c ctrl.Reader, build *v1.Bui
 		return false, scheduledWaitingBuildcondition(build.Name, reason), nil
 	}
 
+	if running := runningBuildsPerNamespace[build.Namespace]; bm.maxRunningBuildsPerNamespace > 0 && running >= bm.maxRunningBuildsPerNamespace {
+		reason := fmt.Sprintf(
+			"Maximum number of running builds in namespace %s (%d) exceeded, queue position %d",
+			build.Namespace,
+			running,
+			position,
+		)
+		Log.WithValues("request-namespace", requestNamespace, "request-name", requestName, "max-running-builds-per-namespace-limit", running).
+			ForBuild(build).Infof(enqueuedMsg, reason, build.Name)
+		// max number of running builds per namespace limit exceeded
+		return false, scheduledWaitingBuildcondition(build.Name, reason), nil
+	}
+
+	if runningBuildsTotal+int32(len(ahead)) >= bm.maxRunningBuilds {
+		reason := fmt.Sprintf(
+			"Waiting for build (%s/%s) with a higher precedence, queue position %d",
+			ahead[0].Namespace,
+			ahead[0].Name,
+			position,
+		)
+		Log.WithValues("request-namespace", requestNamespace, "request-name", requestName, "queue-position", position).
+			ForBuild(build).Infof(enqueuedMsg, reason, build.Name)
+		// the remaining capacity is granted to the builds that take precedence
+		return false, scheduledWaitingBuildcondition(build.Name, reason), nil
+	}
+
 	layout := build.Labels[v1.IntegrationKitLayoutLabel]
 
 	// Native builds can be run in parallel, as incremental images is not applicable.
@@ -100,7 +143,11 @@ func (bm *Monitor) canSchedule(ctx context.Context, c ctrl.Reader, build *v1.Bui
 	case v1.BuildOrderStrategyFIFO:
 		// Check on builds that have been created before the current build and grant precedence if any.
 		if hasScheduledBuildsBefore, otherBuild := builds.HasScheduledBuildsBefore(build); hasScheduledBuildsBefore {
-			reason = fmt.Sprintf("Waiting for build (%s) because it has been created before", otherBuild.Name)
+			if otherBuild.Spec.Priority > build.Spec.Priority {
+				reason = fmt.Sprintf("Waiting for build (%s) because it has a higher priority", otherBuild.Name)
+			} else {
+				reason = fmt.Sprintf("Waiting for build (%s) because it has been created before", otherBuild.Name)
+			}
 			allowed = false
 		}
 	case v1.BuildOrderStrategyDependencies:
@@ -131,6 +178,55 @@ func (bm *Monitor) canSchedule(ctx context.Context, c ctrl.Reader, build *v1.Bui
 	return allowed, condition, nil
 }
 
+// buildsAhead returns the builds waiting to be scheduled, that take precedence over the given build.
+// A build takes precedence when it has a higher priority or, with the same priority, when it belongs to another namespace
+// with less running builds, so that the capacity is fairly shared across namespaces.
+// The ordering of the builds within the same namespace, and with the same priority, is left to the build order strategy.
+func (bm *Monitor) buildsAhead(ctx context.Context, c ctrl.Reader, build *v1.Build, running map[string]int32) ([]v1.Build, error) {
+	var options []ctrl.ListOption
+	if !platform.IsCurrentOperatorGlobal() {
+		options = append(options, ctrl.InNamespace(build.Namespace))
+	}
+	builds := &v1.BuildList{}
+	// We use the non-caching client as informers cache is not invalidated nor updated
+	// atomically by write operations
+	if err := c.List(ctx, builds, options...); err != nil {
+		return nil, err
+	}
+
+	var ahead []v1.Build
+	for _, b := range builds.Items {
+		if b.Status.Phase != v1.BuildPhaseScheduling || b.Namespace == build.Namespace && b.Name == build.Name {
+			continue
+		}
+		if !platform.IsOperatorHandler(&b) {
+			continue
+		}
+		if bm.maxRunningBuildsPerNamespace > 0 && running[b.Namespace] >= bm.maxRunningBuildsPerNamespace {
+			// The build cannot be scheduled until a build completes in its namespace
+			continue
+		}
+
+		switch {
+		case b.Spec.Priority != build.Spec.Priority:
+			if b.Spec.Priority < build.Spec.Priority {
+				continue
+			}
+		case b.Namespace == build.Namespace:
+			continue
+		case running[b.Namespace] >= running[build.Namespace]:
+			continue
+		}
+		ahead = append(ahead, b)
+	}
+
+	sort.SliceStable(ahead, func(i, j int) bool {
+		return ahead[i].Spec.Priority > ahead[j].Spec.Priority
+	})
+
+	return ahead, nil
+}
+
 func monitorRunningBuild(build *v1.Build) {
 	runningBuilds.Store(types.NamespacedName{Namespace: build.Namespace, Name: build.Name}.String(), true)
 }
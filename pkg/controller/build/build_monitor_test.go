@@ -92,7 +92,7 @@ func TestMonitorSequentialBuilds(t *testing.T) {
 			build:   newBuild("ns", "my-build"),
 			allowed: false,
 			condition: newCondition(corev1.ConditionFalse, v1.BuildConditionWaitingReason,
-				"Maximum number of running builds (3) exceeded - the build (my-build) gets enqueued"),
+				"Maximum number of running builds (3) exceeded, queue position 1 - the build (my-build) gets enqueued"),
 		},
 		{
 			name: "limitMaxRunningNativeBuilds",
@@ -107,7 +107,7 @@ func TestMonitorSequentialBuilds(t *testing.T) {
 			build:   newNativeBuildInPhase("ns", "my-build", v1.BuildPhaseInitialization),
 			allowed: false,
 			condition: newCondition(corev1.ConditionFalse, v1.BuildConditionWaitingReason,
-				"Maximum number of running builds (3) exceeded - the build (my-build) gets enqueued"),
+				"Maximum number of running builds (3) exceeded, queue position 1 - the build (my-build) gets enqueued"),
 		},
 		{
 			name: "allowParallelBuildsWithDifferentLayout",
@@ -274,7 +274,7 @@ func TestMonitorFIFOBuilds(t *testing.T) {
 			build:   newBuild("ns", "my-build"),
 			allowed: false,
 			condition: newCondition(corev1.ConditionFalse, v1.BuildConditionWaitingReason,
-				"Maximum number of running builds (3) exceeded - the build (my-build) gets enqueued"),
+				"Maximum number of running builds (3) exceeded, queue position 1 - the build (my-build) gets enqueued"),
 		},
 		{
 			name: "limitMaxRunningNativeBuilds",
@@ -289,7 +289,7 @@ func TestMonitorFIFOBuilds(t *testing.T) {
 			build:   newNativeBuildInPhase("ns", "my-build", v1.BuildPhaseInitialization),
 			allowed: false,
 			condition: newCondition(corev1.ConditionFalse, v1.BuildConditionWaitingReason,
-				"Maximum number of running builds (3) exceeded - the build (my-build) gets enqueued"),
+				"Maximum number of running builds (3) exceeded, queue position 1 - the build (my-build) gets enqueued"),
 		},
 		{
 			name: "allowParallelBuildsWithDifferentLayout",
@@ -455,7 +455,7 @@ func TestMonitorDependencyMatchingBuilds(t *testing.T) {
 			build:   newBuild("ns", "my-build", deps...),
 			allowed: false,
 			condition: newCondition(corev1.ConditionFalse, v1.BuildConditionWaitingReason,
-				"Maximum number of running builds (3) exceeded - the build (my-build) gets enqueued"),
+				"Maximum number of running builds (3) exceeded, queue position 1 - the build (my-build) gets enqueued"),
 		},
 		{
 			name: "limitMaxRunningNativeBuilds",
@@ -470,7 +470,7 @@ func TestMonitorDependencyMatchingBuilds(t *testing.T) {
 			build:   newNativeBuildInPhase("ns", "my-build", v1.BuildPhaseInitialization, deps...),
 			allowed: false,
 			condition: newCondition(corev1.ConditionFalse, v1.BuildConditionWaitingReason,
-				"Maximum number of running builds (3) exceeded - the build (my-build) gets enqueued"),
+				"Maximum number of running builds (3) exceeded, queue position 1 - the build (my-build) gets enqueued"),
 		},
 		{
 			name: "allowParallelBuildsWithDifferentLayout",
@@ -633,6 +633,128 @@ func TestMonitorDependencyMatchingBuilds(t *testing.T) {
 	}
 }
 
+func TestMonitorBuildPrioritiesAndQuotas(t *testing.T) {
+	testcases := []struct {
+		name      string
+		running   []*v1.Build
+		builds    []*v1.Build
+		build     *v1.Build
+		allowed   bool
+		condition *v1.BuildCondition
+	}{
+		{
+			name: "queueBuildWhenNamespaceQuotaExceeded",
+			running: []*v1.Build{
+				newBuild("ns", "my-build-1"),
+				newBuild("ns", "my-build-2"),
+			},
+			build:   newBuild("ns", "my-build"),
+			allowed: false,
+			condition: newCondition(corev1.ConditionFalse, v1.BuildConditionWaitingReason,
+				"Maximum number of running builds in namespace ns (2) exceeded, queue position 1 - the build (my-build) gets enqueued"),
+		},
+		{
+			name: "queueBuildWhenHigherPriorityBuildIsScheduled",
+			running: []*v1.Build{
+				newBuild("some-ns", "my-build-1"),
+				newBuild("other-ns", "my-build-2"),
+			},
+			builds: []*v1.Build{
+				withPriority(newBuildInPhase("another-ns", "my-build-prod", v1.BuildPhaseScheduling), 10),
+			},
+			build:   newBuild("ns", "my-build"),
+			allowed: false,
+			condition: newCondition(corev1.ConditionFalse, v1.BuildConditionWaitingReason,
+				"Waiting for build (another-ns/my-build-prod) with a higher precedence, queue position 2 - the build (my-build) gets enqueued"),
+		},
+		{
+			name: "allowHigherPriorityBuild",
+			running: []*v1.Build{
+				newBuild("some-ns", "my-build-1"),
+				newBuild("other-ns", "my-build-2"),
+			},
+			builds: []*v1.Build{
+				newBuildInPhase("another-ns", "my-build-dev", v1.BuildPhaseScheduling),
+			},
+			build:     withPriority(newBuild("ns", "my-build"), 10),
+			allowed:   true,
+			condition: newCondition(corev1.ConditionTrue, v1.BuildConditionReadyReason, "the build (my-build) is scheduled"),
+		},
+		{
+			name: "queueBuildWhenOtherNamespaceHasLessRunningBuilds",
+			running: []*v1.Build{
+				newBuild("ns", "my-build-1"),
+				newBuild("other-ns", "my-build-2"),
+			},
+			builds: []*v1.Build{
+				newBuildInPhase("another-ns", "my-build-new", v1.BuildPhaseScheduling),
+			},
+			build:   newBuild("ns", "my-build"),
+			allowed: false,
+			condition: newCondition(corev1.ConditionFalse, v1.BuildConditionWaitingReason,
+				"Waiting for build (another-ns/my-build-new) with a higher precedence, queue position 2 - the build (my-build) gets enqueued"),
+		},
+		{
+			name: "allowBuildWhenOtherNamespaceQuotaExceeded",
+			running: []*v1.Build{
+				newBuild("other-ns", "my-build-1"),
+				newBuild("other-ns", "my-build-2"),
+			},
+			builds: []*v1.Build{
+				withPriority(newBuildInPhase("other-ns", "my-build-prod", v1.BuildPhaseScheduling), 10),
+			},
+			build:     newBuild("ns", "my-build"),
+			allowed:   true,
+			condition: newCondition(corev1.ConditionTrue, v1.BuildConditionReadyReason, "the build (my-build) is scheduled"),
+		},
+		{
+			name:    "queueBuildWhenHigherPriorityBuildIsScheduledInSameNamespace",
+			running: []*v1.Build{},
+			builds: []*v1.Build{
+				withPriority(newBuildInPhase("ns", "my-build-prod", v1.BuildPhaseScheduling), 10),
+			},
+			build:   newBuild("ns", "my-build"),
+			allowed: false,
+			condition: newCondition(corev1.ConditionFalse, v1.BuildConditionWaitingReason,
+				"Waiting for build (my-build-prod) because it has a higher priority - the build (my-build) gets enqueued"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var initObjs []runtime.Object
+			for _, build := range append(tc.running, tc.builds...) {
+				initObjs = append(initObjs, build)
+			}
+
+			c, err := test.NewFakeClient(initObjs...)
+
+			require.NoError(t, err)
+
+			bm := Monitor{
+				maxRunningBuilds:             3,
+				maxRunningBuildsPerNamespace: 2,
+				buildOrderStrategy:           v1.BuildOrderStrategyFIFO,
+			}
+
+			// reset running builds in memory cache
+			cleanRunningBuildsMonitor()
+			for _, build := range tc.running {
+				monitorRunningBuild(build)
+			}
+
+			allowed, condition, err := bm.canSchedule(context.TODO(), c, tc.build)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.allowed, allowed)
+			assert.Equal(t, tc.condition.Type, condition.Type)
+			assert.Equal(t, tc.condition.Status, condition.Status)
+			assert.Equal(t, tc.condition.Reason, condition.Reason)
+			assert.Equal(t, tc.condition.Message, condition.Message)
+		})
+	}
+}
+
 func cleanRunningBuildsMonitor() {
 	runningBuilds.Range(func(key interface{}, v interface{}) bool {
 		runningBuilds.Delete(key)
@@ -665,6 +787,11 @@ func newNativeBuildInPhase(namespace string, name string, phase v1.BuildPhase, d
 	return newBuildWithLayoutInPhase(namespace, name, v1.IntegrationKitLayoutNativeSources, phase, dependencies...)
 }
 
+func withPriority(build *v1.Build, priority int32) *v1.Build {
+	build.Spec.Priority = priority
+	return build
+}
+
 func newBuildWithLayoutInPhase(namespace string, name string, layout string, phase v1.BuildPhase, dependencies ...string) *v1.Build {
 	return &v1.Build{
 		TypeMeta: metav1.TypeMeta{
@@ -19,15 +19,18 @@ package build
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/builder"
 	"github.com/apache/camel-k/v2/pkg/client"
 	"github.com/apache/camel-k/v2/pkg/platform"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
@@ -37,6 +40,11 @@ import (
 const (
 	builderDir    = "/builder"
 	builderVolume = "camel-k-builder"
+
+	mavenCacheVolume = "camel-k-maven-cache"
+	// The name of the operator managed Maven cache PersistentVolumeClaim
+	defaultMavenCacheClaim = "camel-k-maven-cache"
+	defaultMavenCacheSize  = "10Gi"
 )
 
 func newBuildPod(ctx context.Context, client client.Client, build *v1.Build) *corev1.Pod {
@@ -84,6 +92,9 @@ func newBuildPod(ctx context.Context, client client.Client, build *v1.Build) *co
 		// Builder task
 		case task.Builder != nil:
 			addBuildTaskToPod(ctx, client, build, task.Builder.Name, pod)
+			if task.Builder.Maven.Cache != nil {
+				addMavenCacheToPod(task.Builder.Maven.Cache, pod)
+			}
 		// Custom task
 		case task.Custom != nil:
 			addCustomTaskToPod(build, task.Custom, pod)
@@ -264,6 +275,82 @@ func addContainerToPod(build *v1.Build, container corev1.Container, pod *corev1.
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, container)
 }
 
+// addMavenCacheToPod mounts the Maven cache volume into the last added container.
+func addMavenCacheToPod(cache *v1.MavenCacheSpec, pod *corev1.Pod) {
+	if !hasVolume(pod, mavenCacheVolume) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes,
+			corev1.Volume{
+				Name: mavenCacheVolume,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: mavenCacheClaimName(cache),
+					},
+				},
+			},
+		)
+	}
+
+	container := &pod.Spec.InitContainers[len(pod.Spec.InitContainers)-1]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      mavenCacheVolume,
+		MountPath: builder.MavenCacheDir,
+	})
+}
+
+func mavenCacheClaimName(cache *v1.MavenCacheSpec) string {
+	if cache.PersistentVolumeClaim != "" {
+		return cache.PersistentVolumeClaim
+	}
+	return defaultMavenCacheClaim
+}
+
+// ensureMavenCache creates the operator managed Maven cache PersistentVolumeClaim, if the build requires it.
+func ensureMavenCache(ctx context.Context, c client.Client, build *v1.Build) error {
+	var cache *v1.MavenCacheSpec
+	for _, task := range build.Spec.Tasks {
+		if task.Builder != nil && task.Builder.Maven.Cache != nil {
+			cache = task.Builder.Maven.Cache
+		}
+	}
+	if cache == nil || cache.PersistentVolumeClaim != "" {
+		return nil
+	}
+
+	namespace := build.BuilderPodNamespace()
+	pvc, err := kubernetes.LookupPersistentVolumeClaim(ctx, c, namespace, defaultMavenCacheClaim)
+	if err != nil || pvc != nil {
+		return err
+	}
+
+	size := cache.Size
+	if size == "" {
+		size = defaultMavenCacheSize
+	}
+	if _, err := resource.ParseQuantity(size); err != nil {
+		return fmt.Errorf("invalid Maven cache size %s: %w", size, err)
+	}
+	accessMode := cache.AccessMode
+	if accessMode == "" {
+		// The builder Pods can be scheduled on any node
+		accessMode = corev1.ReadWriteMany
+	}
+
+	pvc = kubernetes.NewPersistentVolumeClaim(namespace, defaultMavenCacheClaim, cache.StorageClassName, size, accessMode)
+	if cache.StorageClassName == "" {
+		// Use the default storage class
+		pvc.Spec.StorageClassName = nil
+	}
+	pvc.Labels = map[string]string{
+		"app":                        "camel-k",
+		"camel.apache.org/component": "maven-cache",
+	}
+	if err := c.Create(ctx, pvc); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("cannot create Maven cache persistent volume claim: %w", err)
+	}
+
+	return nil
+}
+
 func hasVolume(pod *corev1.Pod, name string) bool {
 	for _, volume := range pod.Spec.Volumes {
 		if volume.Name == name {
@@ -22,10 +22,14 @@ import (
 	"testing"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/builder"
 	"github.com/apache/camel-k/v2/pkg/util/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestNewBuildPodConfiguration(t *testing.T) {
@@ -67,3 +71,106 @@ func TestNewBuildPodConfiguration(t *testing.T) {
 	assert.Equal(t, map[string]string{"node": "selector"}, pod.Spec.NodeSelector)
 	assert.Equal(t, map[string]string{"annotation": "value"}, pod.Annotations)
 }
+
+func TestNewBuildPodMavenCache(t *testing.T) {
+	ctx := context.TODO()
+	c, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	build := v1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "theBuildName",
+			Namespace: "theNamespace",
+		},
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{
+							Name: "builder",
+						},
+						Maven: v1.MavenBuildSpec{
+							MavenSpec: v1.MavenSpec{
+								Cache: &v1.MavenCacheSpec{},
+							},
+						},
+					},
+				},
+				{
+					Package: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{
+							Name: "package",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pod := newBuildPod(ctx, c, &build)
+	require.Len(t, pod.Spec.Volumes, 2)
+	assert.Equal(t, mavenCacheVolume, pod.Spec.Volumes[1].Name)
+	assert.Equal(t, defaultMavenCacheClaim, pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName)
+	require.Len(t, pod.Spec.InitContainers, 1)
+	assert.Contains(t, pod.Spec.InitContainers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      mavenCacheVolume,
+		MountPath: builder.MavenCacheDir,
+	})
+	require.Len(t, pod.Spec.Containers, 1)
+	assert.Len(t, pod.Spec.Containers[0].VolumeMounts, 1)
+
+	build.Spec.Tasks[0].Builder.Maven.Cache.PersistentVolumeClaim = "my-cache"
+	pod = newBuildPod(ctx, c, &build)
+	assert.Equal(t, "my-cache", pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName)
+}
+
+func TestEnsureMavenCache(t *testing.T) {
+	ctx := context.TODO()
+	c, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	build := v1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "theBuildName",
+			Namespace: "theNamespace",
+		},
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{
+							Name: "builder",
+							Configuration: v1.BuildConfiguration{
+								BuilderPodNamespace: "theNamespace",
+							},
+						},
+						Maven: v1.MavenBuildSpec{
+							MavenSpec: v1.MavenSpec{
+								Cache: &v1.MavenCacheSpec{
+									Size: "5Gi",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, ensureMavenCache(ctx, c, &build))
+	// Idempotent
+	require.NoError(t, ensureMavenCache(ctx, c, &build))
+
+	pvc := corev1.PersistentVolumeClaim{}
+	require.NoError(t, c.Get(ctx, ctrl.ObjectKey{Namespace: "theNamespace", Name: defaultMavenCacheClaim}, &pvc))
+	assert.Nil(t, pvc.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+	assert.Equal(t, resource.MustParse("5Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	build.Spec.Tasks[0].Builder.Maven.Cache = &v1.MavenCacheSpec{PersistentVolumeClaim: "my-cache"}
+	build.Spec.Tasks[0].Builder.Configuration.BuilderPodNamespace = "otherNamespace"
+	require.NoError(t, ensureMavenCache(ctx, c, &build))
+	pvcs := corev1.PersistentVolumeClaimList{}
+	require.NoError(t, c.List(ctx, &pvcs, ctrl.InNamespace("otherNamespace")))
+	assert.Empty(t, pvcs.Items)
+}
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+var errBuildCancelled = errors.New("build cancelled")
+
+func newCancelAction() Action {
+	return &cancelAction{}
+}
+
+type cancelAction struct {
+	baseAction
+}
+
+// Name returns a common name of the action.
+func (action *cancelAction) Name() string {
+	return "cancel"
+}
+
+// CanHandle tells whether this action can handle the build.
+func (action *cancelAction) CanHandle(build *v1.Build) bool {
+	// A failed build is still eligible, so that it does not get recovered
+	return build.Spec.Cancel && (!build.Status.IsFinished() || build.Status.Phase == v1.BuildPhaseFailed)
+}
+
+// Handle handles the builds.
+func (action *cancelAction) Handle(ctx context.Context, build *v1.Build) (*v1.Build, error) {
+	running := build.Status.Phase == v1.BuildPhasePending || build.Status.Phase == v1.BuildPhaseRunning
+	if running {
+		switch build.BuilderConfiguration().Strategy {
+		case v1.BuildStrategyRoutine:
+			if cancelRoutine(build.Name, errBuildCancelled) {
+				// The build routine reports the cancellation once it has stopped
+				return nil, nil
+			}
+		case v1.BuildStrategyPod:
+			if err := deleteBuilderPod(ctx, action.client, build); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	build.Status.Phase = v1.BuildPhaseCancelled
+	build.Status.Error = errBuildCancelled.Error()
+
+	if running {
+		var duration time.Duration
+		if build.Status.StartedAt != nil {
+			duration = metav1.Now().Sub(build.Status.StartedAt.Time)
+			build.Status.Duration = duration.String()
+		}
+		monitorFinishedBuild(build)
+
+		buildCreator := kubernetes.GetCamelCreator(build)
+		// Account for the Build metrics
+		observeBuildResult(build, build.Status.Phase, buildCreator, duration)
+	}
+
+	return build, nil
+}
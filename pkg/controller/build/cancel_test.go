@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCancelActionCanHandle(t *testing.T) {
+	action := newCancelAction()
+
+	build := newBuildInPhase("ns", "my-build", v1.BuildPhaseRunning)
+	assert.False(t, action.CanHandle(build))
+
+	build.Spec.Cancel = true
+	assert.True(t, action.CanHandle(build))
+
+	build.Status.Phase = v1.BuildPhaseFailed
+	assert.True(t, action.CanHandle(build))
+
+	build.Status.Phase = v1.BuildPhaseSucceeded
+	assert.False(t, action.CanHandle(build))
+
+	build.Status.Phase = v1.BuildPhaseCancelled
+	assert.False(t, action.CanHandle(build))
+}
+
+func TestCancelScheduledBuild(t *testing.T) {
+	c, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	action := newCancelAction()
+	action.InjectClient(c)
+
+	build := newBuildInPhase("ns", "my-build", v1.BuildPhaseScheduling)
+	build.Spec.Cancel = true
+
+	target, err := action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.BuildPhaseCancelled, target.Status.Phase)
+	assert.Equal(t, "build cancelled", target.Status.Error)
+}
+
+func TestCancelRoutineBuild(t *testing.T) {
+	c, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	action := newCancelAction()
+	action.InjectClient(c)
+
+	build := newBuildInPhase("ns", "my-build", v1.BuildPhaseRunning)
+	build.Spec.Tasks[0].Builder.Name = "builder"
+	build.Spec.Cancel = true
+
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	routines.Store(build.Name, cancel)
+	defer routines.Delete(build.Name)
+
+	target, err := action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	// The build routine is in charge of reporting the cancellation
+	assert.Nil(t, target)
+	require.ErrorIs(t, context.Cause(ctx), errBuildCancelled)
+}
+
+func TestCancelPodBuild(t *testing.T) {
+	build := newBuildInPhase("ns", "my-build", v1.BuildPhaseRunning)
+	build.Spec.Tasks[0].Builder.Name = "builder"
+	build.Spec.Tasks[0].Builder.Configuration.Strategy = v1.BuildStrategyPod
+	build.Spec.Cancel = true
+	build.Status.StartedAt = &metav1.Time{Time: metav1.Now().Add(-time.Minute)}
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: build.BuilderPodNamespace(),
+			Name:      buildPodName(build),
+		},
+	}
+	c, err := test.NewFakeClient(&pod)
+	require.NoError(t, err)
+
+	action := newCancelAction()
+	action.InjectClient(c)
+
+	cleanRunningBuildsMonitor()
+	monitorRunningBuild(build)
+
+	target, err := action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.BuildPhaseCancelled, target.Status.Phase)
+	assert.NotEmpty(t, target.Status.Duration)
+
+	err = c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&pod), &pod)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	_, running := runningBuilds.Load("ns/my-build")
+	assert.False(t, running)
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+type failurePattern struct {
+	category v1.FailureCategory
+	code     string
+	pattern  *regexp.Regexp
+}
+
+// failurePatterns are evaluated in order. The transient ones come first, as Maven for instance
+// reports network errors as dependency resolution failures.
+var failurePatterns = []failurePattern{
+	{
+		category: v1.FailureCategoryTransient,
+		code:     v1.BuildFailureCodeOutOfMemory,
+		pattern:  regexp.MustCompile(`OOMKilled|OutOfMemoryError`),
+	},
+	{
+		category: v1.FailureCategoryTransient,
+		code:     v1.BuildFailureCodeRegistryUnavailable,
+		pattern:  regexp.MustCompile(`(?i)status(?: code)?:? 5\d\d\b|\b5\d\d (?:internal server error|bad gateway|service unavailable|gateway timeout)`),
+	},
+	{
+		category: v1.FailureCategoryTransient,
+		code:     v1.BuildFailureCodeNetworkError,
+		pattern:  regexp.MustCompile(`(?i)timed out|i/o timeout|tls handshake timeout|connection (?:reset|refused)|no route to host|no such host|temporary failure in name resolution|could not transfer artifact`),
+	},
+	{
+		category: v1.FailureCategoryPermanent,
+		code:     v1.BuildFailureCodeCompilationError,
+		pattern:  regexp.MustCompile(`(?i)compilation (?:error|failure)|cannot find symbol`),
+	},
+	{
+		category: v1.FailureCategoryPermanent,
+		code:     v1.BuildFailureCodeUnresolvableDependency,
+		pattern:  regexp.MustCompile(`(?i)could not resolve dependencies|could not find artifact|failure to find|non-resolvable`),
+	},
+	{
+		category: v1.FailureCategoryPermanent,
+		code:     v1.BuildFailureCodeInvalidSource,
+		pattern:  regexp.MustCompile(`(?i)invalid source|unsupported language|failed to parse|cannot parse|syntax error`),
+	},
+}
+
+// classifyFailure classifies the failure of the build, from its error and the conditions reported by the builder containers.
+// A failure that cannot be classified is considered transient, so that it is recovered.
+func classifyFailure(build *v1.Build) (v1.FailureCategory, string) {
+	messages := []string{build.Status.Error}
+	for _, condition := range build.Status.Conditions {
+		if condition.Type != v1.BuildConditionScheduled && condition.Status == corev1.ConditionFalse {
+			messages = append(messages, condition.Reason, condition.Message)
+		}
+	}
+	message := strings.Join(messages, "\n")
+
+	for _, p := range failurePatterns {
+		if p.pattern.MatchString(message) {
+			return p.category, p.code
+		}
+	}
+
+	return v1.FailureCategoryTransient, v1.BuildFailureCodeUnknown
+}
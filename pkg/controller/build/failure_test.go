@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"testing"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	testcases := []struct {
+		name       string
+		err        string
+		conditions []v1.BuildCondition
+		category   v1.FailureCategory
+		code       string
+	}{
+		{
+			name:     "registryUnavailable",
+			err:      "PUT https://registry.example.com/v2/test/blobs/uploads/: unexpected status code 503 Service Unavailable",
+			category: v1.FailureCategoryTransient,
+			code:     v1.BuildFailureCodeRegistryUnavailable,
+		},
+		{
+			name:     "mavenNetworkTimeout",
+			err:      "[ERROR] Failed to execute goal on project camel-k-integration: Could not resolve dependencies for project org.apache.camel.k.integration:camel-k-integration:jar:1.0: Could not transfer artifact org.apache.camel:camel-core:jar:4.0.0 from/to central (https://repo.maven.apache.org/maven2): Connect to repo.maven.apache.org:443 timed out",
+			category: v1.FailureCategoryTransient,
+			code:     v1.BuildFailureCodeNetworkError,
+		},
+		{
+			name: "builderPodOOMKilled",
+			err:  "Builder Pod camel-k-my-build-builder failed (see conditions for more details)",
+			conditions: []v1.BuildCondition{
+				{
+					Type:    "Container builder succeeded",
+					Status:  corev1.ConditionFalse,
+					Reason:  "OOMKilled (137)",
+					Message: "",
+				},
+			},
+			category: v1.FailureCategoryTransient,
+			code:     v1.BuildFailureCodeOutOfMemory,
+		},
+		{
+			name:     "compilationError",
+			err:      "[ERROR] COMPILATION ERROR : : exit status 1",
+			category: v1.FailureCategoryPermanent,
+			code:     v1.BuildFailureCodeCompilationError,
+		},
+		{
+			name:     "unresolvableDependency",
+			err:      "[ERROR] Failed to execute goal on project camel-k-integration: Could not resolve dependencies for project org.apache.camel.k.integration:camel-k-integration:jar:1.0: Could not find artifact org.acme:missing:jar:1.0 in central (https://repo.maven.apache.org/maven2): exit status 1",
+			category: v1.FailureCategoryPermanent,
+			code:     v1.BuildFailureCodeUnresolvableDependency,
+		},
+		{
+			name:     "invalidSource",
+			err:      "failed to parse the integration source: yaml: line 3: mapping values are not allowed in this context",
+			category: v1.FailureCategoryPermanent,
+			code:     v1.BuildFailureCodeInvalidSource,
+		},
+		{
+			name: "unknown",
+			err:  "context deadline exceeded",
+			conditions: []v1.BuildCondition{
+				{
+					Type:    v1.BuildConditionScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  v1.BuildConditionWaitingReason,
+					Message: "Waiting for build (my-build-1) because it has been created before",
+				},
+			},
+			category: v1.FailureCategoryTransient,
+			code:     v1.BuildFailureCodeUnknown,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			build := newBuildInPhase("ns", "my-build", v1.BuildPhaseFailed)
+			build.Status.Error = tc.err
+			build.Status.Conditions = tc.conditions
+
+			category, code := classifyFailure(build)
+
+			assert.Equal(t, tc.category, category)
+			assert.Equal(t, tc.code, code)
+		})
+	}
+}
@@ -30,8 +30,10 @@ import (
 )
 
 const (
-	buildResultLabel = "result"
-	buildTypeLabel   = "type"
+	buildResultLabel     = "result"
+	buildTypeLabel       = "type"
+	failureCategoryLabel = "category"
+	failureCodeLabel     = "code"
 )
 
 var (
@@ -82,11 +84,43 @@ var (
 			buildTypeLabel,
 		},
 	)
+
+	mavenCacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "camel_k_build_maven_cache_hits_total",
+			Help: "Camel K build Maven artifacts resolved from the Maven cache",
+		},
+		[]string{
+			buildTypeLabel,
+		},
+	)
+
+	mavenCacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "camel_k_build_maven_cache_misses_total",
+			Help: "Camel K build Maven artifacts downloaded from the remote repositories",
+		},
+		[]string{
+			buildTypeLabel,
+		},
+	)
+
+	buildFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "camel_k_build_failures_total",
+			Help: "Camel K build failures",
+		},
+		[]string{
+			failureCategoryLabel,
+			failureCodeLabel,
+			buildTypeLabel,
+		},
+	)
 )
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(buildDuration, buildRecovery, queueDuration)
+	metrics.Registry.MustRegister(buildDuration, buildRecovery, queueDuration, mavenCacheHits, mavenCacheMisses, buildFailures)
 }
 
 func observeBuildQueueDuration(build *v1.Build, creator *corev1.ObjectReference) {
@@ -128,6 +162,15 @@ func observeBuildResult(build *v1.Build, phase v1.BuildPhase, creator *corev1.Ob
 		ForBuild(build).Infof("Build duration %s", duration)
 	buildRecovery.WithLabelValues(resultLabel, typeLabel).Observe(float64(attempt))
 	buildDuration.WithLabelValues(resultLabel, typeLabel).Observe(duration.Seconds())
+
+	if cache := build.Status.MavenCache; cache != nil {
+		mavenCacheHits.WithLabelValues(typeLabel).Add(float64(cache.Hits))
+		mavenCacheMisses.WithLabelValues(typeLabel).Add(float64(cache.Misses))
+	}
+}
+
+func observeBuildFailure(build *v1.Build, failure *v1.Failure) {
+	buildFailures.WithLabelValues(string(failure.Category), failure.Code, build.Labels[v1.IntegrationKitLayoutLabel]).Inc()
 }
 
 func getBuildAttemptFor(build *v1.Build) (int, int) {
@@ -75,9 +75,10 @@ func (action *monitorRoutineAction) Handle(ctx context.Context, build *v1.Build)
 			return nil, err
 		}
 		// Start the build asynchronously to avoid blocking the reconciliation loop
-		routines.Store(build.Name, true)
+		buildCtx, cancel := context.WithCancelCause(ctx)
+		routines.Store(build.Name, cancel)
 
-		go action.runBuild(ctx, build)
+		go action.runBuild(ctx, buildCtx, build)
 
 	case v1.BuildPhaseRunning:
 		if _, ok := routines.Load(build.Name); !ok {
@@ -96,10 +97,24 @@ func (action *monitorRoutineAction) Handle(ctx context.Context, build *v1.Build)
 	return nil, nil
 }
 
-func (action *monitorRoutineAction) runBuild(ctx context.Context, build *v1.Build) {
+// cancelRoutine cancels the context of the routine running the build, with the given cause.
+// It returns false if no routine is running the build.
+func cancelRoutine(name string, cause error) bool {
+	v, ok := routines.Load(name)
+	if !ok {
+		return false
+	}
+	if cancel, ok := v.(context.CancelCauseFunc); ok {
+		cancel(cause)
+	}
+	return true
+}
+
+func (action *monitorRoutineAction) runBuild(ctx context.Context, buildCtx context.Context, build *v1.Build) {
 	defer routines.Delete(build.Name)
+	defer cancelRoutine(build.Name, nil)
 
-	ctxWithTimeout, cancel := context.WithDeadline(ctx, build.Status.StartedAt.Add(build.Spec.Timeout.Duration))
+	ctxWithTimeout, cancel := context.WithDeadline(buildCtx, build.Status.StartedAt.Add(build.Spec.Timeout.Duration))
 	defer cancel()
 
 	status := v1.BuildStatus{}
@@ -188,6 +203,11 @@ tasks:
 		}
 	}
 
+	if status.Phase != v1.BuildPhaseSucceeded && errors.Is(context.Cause(ctxWithTimeout), errBuildCancelled) {
+		status.Phase = v1.BuildPhaseCancelled
+		status.Error = errBuildCancelled.Error()
+	}
+
 	duration := metav1.Now().Sub(build.Status.StartedAt.Time)
 	status.Duration = duration.String()
 
@@ -19,6 +19,9 @@ package build
 
 import (
 	"context"
+	"hash/fnv"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/jpillora/backoff"
@@ -35,6 +38,7 @@ func newErrorRecoveryAction() Action {
 			Min:    5 * time.Second,
 			Max:    1 * time.Minute,
 			Factor: 2,
+			// The jitter is computed by the action, so that it remains stable across reconciliations
 			Jitter: false,
 		},
 	}
@@ -54,14 +58,28 @@ func (action *errorRecoveryAction) CanHandle(build *v1.Build) bool {
 }
 
 func (action *errorRecoveryAction) Handle(ctx context.Context, build *v1.Build) (*v1.Build, error) {
-	if build.Status.Failure == nil {
-		build.Status.Failure = &v1.Failure{
-			Reason: build.Status.Error,
-			Time:   metav1.Now(),
-			Recovery: v1.FailureRecovery{
-				Attempt:    0,
-				AttemptMax: 5,
-			},
+	failure := build.Status.Failure
+	// Either the first failure, or a failure that happened after the last recovery attempt
+	if failure == nil || failure.Time.Before(&failure.Recovery.AttemptTime) {
+		if failure == nil {
+			failure = &v1.Failure{
+				Recovery: v1.FailureRecovery{
+					Attempt:    0,
+					AttemptMax: 5,
+				},
+			}
+			build.Status.Failure = failure
+		}
+		failure.Reason = build.Status.Error
+		failure.Time = metav1.Now()
+		failure.Category, failure.Code = classifyFailure(build)
+
+		observeBuildFailure(build, failure)
+
+		if failure.Category == v1.FailureCategoryPermanent {
+			// Do not attempt to recover a failure that would happen again
+			build.Status.Phase = v1.BuildPhaseError
+			action.L.Infof("Permanent failure (%s), no recovery attempted", failure.Code)
 		}
 		return build, nil
 	}
@@ -77,7 +95,7 @@ func (action *errorRecoveryAction) Handle(ctx context.Context, build *v1.Build)
 	}
 
 	elapsed := time.Since(lastAttempt).Seconds()
-	elapsedMin := action.backOff.ForAttempt(float64(build.Status.Failure.Recovery.Attempt)).Seconds()
+	elapsedMin := action.delay(build).Seconds()
 
 	if elapsed < elapsedMin {
 		return nil, nil
@@ -94,3 +112,16 @@ func (action *errorRecoveryAction) Handle(ctx context.Context, build *v1.Build)
 
 	return build, nil
 }
+
+// delay returns the exponential backoff delay before the next recovery attempt, with a jitter
+// of up to half the delay, derived from the build and the attempt number.
+func (action *errorRecoveryAction) delay(build *v1.Build) time.Duration {
+	attempt := build.Status.Failure.Recovery.Attempt
+	d := action.backOff.ForAttempt(float64(attempt))
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(string(build.UID) + "/" + build.Namespace + "/" + build.Name + "/" + strconv.Itoa(attempt)))
+	jitter := float64(h.Sum32()) / math.MaxUint32
+
+	return d/2 + time.Duration(jitter*float64(d/2))
+}
@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestErrorRecoveryAction() Action {
+	action := newErrorRecoveryAction()
+	action.InjectLogger(log.Log)
+	return action
+}
+
+func TestRecoverTransientFailure(t *testing.T) {
+	action := newTestErrorRecoveryAction()
+
+	build := newBuildInPhase("ns", "my-build", v1.BuildPhaseFailed)
+	build.Status.Error = "unexpected status code 502 Bad Gateway"
+
+	target, err := action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.BuildPhaseFailed, target.Status.Phase)
+	require.NotNil(t, target.Status.Failure)
+	assert.Equal(t, v1.FailureCategoryTransient, target.Status.Failure.Category)
+	assert.Equal(t, v1.BuildFailureCodeRegistryUnavailable, target.Status.Failure.Code)
+	assert.Equal(t, "unexpected status code 502 Bad Gateway", target.Status.Failure.Reason)
+
+	// Waiting for the backoff delay
+	target, err = action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	assert.Nil(t, target)
+
+	build.Status.Failure.Time = metav1.NewTime(time.Now().Add(-time.Minute))
+	target, err = action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.BuildPhaseInitialization, target.Status.Phase)
+	assert.Equal(t, 1, target.Status.Failure.Recovery.Attempt)
+}
+
+func TestRecoverPermanentFailure(t *testing.T) {
+	action := newTestErrorRecoveryAction()
+
+	build := newBuildInPhase("ns", "my-build", v1.BuildPhaseFailed)
+	build.Status.Error = "[ERROR] COMPILATION ERROR : : exit status 1"
+
+	target, err := action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.BuildPhaseError, target.Status.Phase)
+	require.NotNil(t, target.Status.Failure)
+	assert.Equal(t, v1.FailureCategoryPermanent, target.Status.Failure.Category)
+	assert.Equal(t, v1.BuildFailureCodeCompilationError, target.Status.Failure.Code)
+	assert.Equal(t, 0, target.Status.Failure.Recovery.Attempt)
+}
+
+func TestRecoverFailureAfterAttempt(t *testing.T) {
+	action := newTestErrorRecoveryAction()
+
+	build := newBuildInPhase("ns", "my-build", v1.BuildPhaseFailed)
+	// The build has been recovered after a network failure, then failed again
+	build.Status.Error = "Could not find artifact org.acme:missing:jar:1.0 in central"
+	build.Status.Failure = &v1.Failure{
+		Reason:   "connection reset by peer",
+		Time:     metav1.NewTime(time.Now().Add(-time.Minute)),
+		Category: v1.FailureCategoryTransient,
+		Code:     v1.BuildFailureCodeNetworkError,
+		Recovery: v1.FailureRecovery{
+			Attempt:     1,
+			AttemptMax:  5,
+			AttemptTime: metav1.NewTime(time.Now().Add(-30 * time.Second)),
+		},
+	}
+
+	target, err := action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.BuildPhaseError, target.Status.Phase)
+	assert.Equal(t, v1.FailureCategoryPermanent, target.Status.Failure.Category)
+	assert.Equal(t, v1.BuildFailureCodeUnresolvableDependency, target.Status.Failure.Code)
+	assert.Equal(t, 1, target.Status.Failure.Recovery.Attempt)
+}
+
+func TestRecoveryDelay(t *testing.T) {
+	action, ok := newErrorRecoveryAction().(*errorRecoveryAction)
+	require.True(t, ok)
+
+	build := newBuildInPhase("ns", "my-build", v1.BuildPhaseFailed)
+	build.Status.Failure = &v1.Failure{}
+
+	for attempt, maxDelay := range []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute} {
+		build.Status.Failure.Recovery.Attempt = attempt
+		delay := action.delay(build)
+		assert.GreaterOrEqual(t, delay, maxDelay/2)
+		assert.LessOrEqual(t, delay, maxDelay)
+		// The jitter is stable across reconciliations
+		assert.Equal(t, delay, action.delay(build))
+	}
+}
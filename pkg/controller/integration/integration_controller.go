@@ -589,6 +589,18 @@ func (r *reconcileIntegration) Reconcile(ctx context.Context, request reconcile.
 			// handle one action at time so the resource
 			// is always at its latest state
 			camelevent.NotifyIntegrationUpdated(ctx, r.client, r.recorder, &instance, newTarget)
+
+			// The promotion of a progressive rollout is time based,
+			// so it's not triggered by any change in the watched resources
+			if newTarget != nil {
+				if c := newTarget.Status.GetCondition(v1.IntegrationConditionRollout); c != nil && c.Reason == v1.IntegrationConditionRolloutAnalysisReason {
+					return reconcile.Result{RequeueAfter: rolloutAnalysisRequeueInterval}, nil
+				}
+			}
+			// The git ref of the sources is polled, as it's not a watched resource
+			if interval := target.Spec.Git.GetPollInterval(); interval > 0 && target.Status.GitCommit != "" {
+				return reconcile.Result{RequeueAfter: interval}, nil
+			}
 			break
 		}
 	}
@@ -597,7 +609,7 @@ func (r *reconcileIntegration) Reconcile(ctx context.Context, request reconcile.
 }
 
 func (r *reconcileIntegration) update(ctx context.Context, base *v1.Integration, target *v1.Integration, log *log.Logger) error {
-	secrets, configmaps := getIntegrationSecretAndConfigmapResourceVersions(ctx, r.client, target)
+	secrets, configmaps := kubernetes.LookupIntegrationSecretAndConfigmapResourceVersions(ctx, r.client, target)
 	d, err := digest.ComputeForIntegration(target, configmaps, secrets)
 	if err != nil {
 		return err
@@ -23,26 +23,26 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
-	"k8s.io/utils/pointer"
 
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
-	"github.com/apache/camel-k/v2/pkg/client"
 	"github.com/apache/camel-k/v2/pkg/trait"
 	"github.com/apache/camel-k/v2/pkg/util/digest"
+	"github.com/apache/camel-k/v2/pkg/util/git"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
-	utilResource "github.com/apache/camel-k/v2/pkg/util/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/apache/camel-k/v2/pkg/util/revision"
 )
 
 // NewMonitorAction is an action used to monitor manager Integrations.
@@ -96,6 +96,9 @@ func (action *monitorAction) Handle(ctx context.Context, integration *v1.Integra
 	} else if changed != nil {
 		return changed, nil
 	}
+	if changed := action.checkGitRefAndRebuild(ctx, integration); changed != nil {
+		return changed, nil
+	}
 
 	// Check if an IntegrationKit with higher priority is ready
 	priority, ok := kit.Labels[v1.IntegrationKitPriorityLabel]
@@ -128,7 +131,20 @@ func (action *monitorAction) Handle(ctx context.Context, integration *v1.Integra
 		return nil, err
 	}
 
-	return action.monitorPods(ctx, environment, integration)
+	target, err := action.monitorPods(ctx, environment, integration)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep track of the successfully deployed specs, so that the Integration can be rolled back
+	if target.Status.Phase == v1.IntegrationPhaseRunning && target.IsConditionTrue(v1.IntegrationConditionReady) && target.IsRolledOut() {
+		if _, err := revision.Record(ctx, action.client, target, v1.IntegrationKind, target.Spec,
+			target.Status.Digest, target.Status.IntegrationKit, target.Status.Image); err != nil {
+			action.L.Error(err, "Unable to record Integration revision")
+		}
+	}
+
+	return target, nil
 }
 
 func (action *monitorAction) monitorPods(ctx context.Context, environment *trait.Environment, integration *v1.Integration) (*v1.Integration, error) {
@@ -181,14 +197,19 @@ func (action *monitorAction) monitorPods(ctx context.Context, environment *trait
 	if err != nil {
 		return nil, err
 	}
+	// The Pods of the candidate version of a progressive rollout are evaluated separately
+	pending, running, err := action.monitorRollout(ctx, environment, integration, pendingPods.Items, runningPods.Items)
+	if err != nil {
+		return nil, err
+	}
 	nonTerminatingPods := 0
-	for _, pod := range runningPods.Items {
+	for _, pod := range running {
 		if pod.DeletionTimestamp != nil {
 			continue
 		}
 		nonTerminatingPods++
 	}
-	podCount := int32(len(pendingPods.Items) + nonTerminatingPods)
+	podCount := int32(len(pending) + nonTerminatingPods)
 	integration.Status.Replicas = &podCount
 
 	// Reconcile Integration phase and ready condition
@@ -196,10 +217,16 @@ func (action *monitorAction) monitorPods(ctx context.Context, environment *trait
 		integration.Status.Phase = v1.IntegrationPhaseRunning
 	}
 	if err = action.updateIntegrationPhaseAndReadyCondition(
-		ctx, controller, environment, integration, pendingPods.Items, runningPods.Items,
+		ctx, controller, environment, integration, pending, running,
 	); err != nil {
 		return nil, err
 	}
+	if !integration.IsRolledOut() {
+		if condition := integration.Status.GetCondition(v1.IntegrationConditionRollout); condition.Reason == v1.IntegrationConditionRolloutAbortedReason {
+			integration.Status.Phase = v1.IntegrationPhaseError
+			integration.SetReadyConditionError(condition.Message)
+		}
+	}
 
 	return integration, nil
 }
@@ -230,7 +257,7 @@ func isInIntegrationKitFailed(status v1.IntegrationStatus) bool {
 }
 
 func (action *monitorAction) checkDigestAndRebuild(ctx context.Context, integration *v1.Integration, kit *v1.IntegrationKit) (*v1.Integration, error) {
-	secrets, configmaps := getIntegrationSecretAndConfigmapResourceVersions(ctx, action.client, integration)
+	secrets, configmaps := kubernetes.LookupIntegrationSecretAndConfigmapResourceVersions(ctx, action.client, integration)
 	hash, err := digest.ComputeForIntegration(integration, configmaps, secrets)
 	if err != nil {
 		return nil, err
@@ -250,6 +277,36 @@ func (action *monitorAction) checkDigestAndRebuild(ctx context.Context, integrat
 	return nil, nil
 }
 
+// checkGitRefAndRebuild resets the Integration when the git ref of its sources points to another commit than
+// the one they have been checked out from. The ref is checked at most once per poll interval.
+func (action *monitorAction) checkGitRefAndRebuild(ctx context.Context, integration *v1.Integration) *v1.Integration {
+	interval := integration.Spec.Git.GetPollInterval()
+	if interval <= 0 || integration.Status.GitCommit == "" {
+		return nil
+	}
+	if last := integration.Status.GitLastCheckTime; last != nil && time.Since(last.Time) < interval {
+		return nil
+	}
+
+	commit, err := git.ResolveRef(ctx, integration.Spec.Git.URL, integration.Spec.Git.Ref)
+	now := metav1.Now().Rfc3339Copy()
+	integration.Status.GitLastCheckTime = &now
+	if err != nil {
+		// The Integration keeps running the sources it has been built from until the next check
+		action.L.Error(err, "Unable to check the git ref of the Integration sources")
+		return nil
+	}
+
+	if commit != integration.Status.GitCommit {
+		action.L.Infof("Integration %s git ref has moved to commit %s: resetting its status. Will check if it needs to be rebuilt and restarted.", integration.Name, commit)
+		integration.Initialize()
+
+		return integration
+	}
+
+	return nil
+}
+
 func isIntegrationKitResetRequired(integration *v1.Integration, kit *v1.IntegrationKit) bool {
 	if kit == nil {
 		return false
@@ -279,47 +336,6 @@ func isIntegrationKitResetRequired(integration *v1.Integration, kit *v1.Integrat
 	return false
 }
 
-// getIntegrationSecretAndConfigmapResourceVersions returns the list of resource versions only useful to watch for changes.
-func getIntegrationSecretAndConfigmapResourceVersions(ctx context.Context, client client.Client, integration *v1.Integration) ([]string, []string) {
-	configmaps := make([]string, 0)
-	secrets := make([]string, 0)
-	if integration.Spec.Traits.Mount != nil && pointer.BoolDeref(integration.Spec.Traits.Mount.HotReload, false) {
-		mergedResources := make([]string, 0)
-		mergedResources = append(mergedResources, integration.Spec.Traits.Mount.Configs...)
-		mergedResources = append(mergedResources, integration.Spec.Traits.Mount.Resources...)
-		for _, c := range mergedResources {
-			if conf, parseErr := utilResource.ParseConfig(c); parseErr == nil {
-				if conf.StorageType() == utilResource.StorageTypeConfigmap {
-					cm := corev1.ConfigMap{
-						TypeMeta: metav1.TypeMeta{
-							Kind:       "ConfigMap",
-							APIVersion: corev1.SchemeGroupVersion.String(),
-						},
-						ObjectMeta: metav1.ObjectMeta{
-							Namespace: integration.Namespace,
-							Name:      conf.Name(),
-						},
-					}
-					configmaps = append(configmaps, kubernetes.LookupResourceVersion(ctx, client, &cm))
-				} else if conf.StorageType() == utilResource.StorageTypeSecret {
-					sec := corev1.Secret{
-						TypeMeta: metav1.TypeMeta{
-							Kind:       "Secret",
-							APIVersion: corev1.SchemeGroupVersion.String(),
-						},
-						ObjectMeta: metav1.ObjectMeta{
-							Namespace: integration.Namespace,
-							Name:      conf.Name(),
-						},
-					}
-					secrets = append(secrets, kubernetes.LookupResourceVersion(ctx, client, &sec))
-				}
-			}
-		}
-	}
-	return secrets, configmaps
-}
-
 type controller interface {
 	checkReadyCondition(ctx context.Context) (bool, error)
 	getPodSpec() corev1.PodSpec
@@ -375,9 +391,10 @@ func (action *monitorAction) newController(env *trait.Environment, integration *
 }
 
 // getUpdatedController retrieves the controller updated from the deployer trait execution.
+// The candidate Deployment of a progressive rollout is monitored separately.
 func getUpdatedController(env *trait.Environment, obj ctrl.Object) ctrl.Object {
 	return env.Resources.GetController(func(object ctrl.Object) bool {
-		return reflect.TypeOf(obj) == reflect.TypeOf(object)
+		return reflect.TypeOf(obj) == reflect.TypeOf(object) && !isRolloutCandidate(object)
 	})
 }
 
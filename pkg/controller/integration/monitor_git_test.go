@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/git"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+func newGitTestIntegration(repo *test.GitRepository, commit string, lastCheck time.Time) *v1.Integration {
+	it := v1.NewIntegration("default", "my-it")
+	it.Spec.Git = &v1.GitSourceSpec{URL: repo.URL}
+	it.Status.Phase = v1.IntegrationPhaseRunning
+	it.Status.GitCommit = commit
+	it.Status.GitLastCheckTime = &metav1.Time{Time: lastCheck}
+
+	return &it
+}
+
+func TestMonitorGitRefMoved(t *testing.T) {
+	allowed := git.AllowedProtocols
+	git.AllowedProtocols = append([]string{"file"}, allowed...)
+	t.Cleanup(func() { git.AllowedProtocols = allowed })
+	repo := test.NewGitRepository(t)
+	first := repo.Commit(t, map[string]string{"route.yaml": "- from:\n    uri: timer:tick\n"})
+
+	action := monitorAction{}
+	action.InjectLogger(log.Log)
+
+	// The ref has not moved
+	it := newGitTestIntegration(repo, first, time.Now().Add(-10*time.Minute))
+	assert.Nil(t, action.checkGitRefAndRebuild(context.TODO(), it))
+	assert.True(t, it.Status.GitLastCheckTime.After(time.Now().Add(-time.Minute)))
+
+	second := repo.Commit(t, map[string]string{"route.yaml": "- from:\n    uri: timer:tock\n"})
+	require.NotEqual(t, first, second)
+
+	// The ref is not checked before the poll interval has elapsed
+	it = newGitTestIntegration(repo, first, time.Now().Add(-time.Minute))
+	assert.Nil(t, action.checkGitRefAndRebuild(context.TODO(), it))
+
+	// The check is disabled
+	it = newGitTestIntegration(repo, first, time.Now().Add(-10*time.Minute))
+	it.Spec.Git.PollInterval = &metav1.Duration{}
+	assert.Nil(t, action.checkGitRefAndRebuild(context.TODO(), it))
+
+	it = newGitTestIntegration(repo, first, time.Now().Add(-10*time.Minute))
+	changed := action.checkGitRefAndRebuild(context.TODO(), it)
+	require.NotNil(t, changed)
+	assert.Equal(t, v1.IntegrationPhaseInitialization, changed.Status.Phase)
+	assert.Empty(t, changed.Status.GitCommit)
+}
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/trait"
+)
+
+// rolloutAnalysisRequeueInterval is the interval at which an Integration is reconciled during the analysis of a rollout candidate.
+const rolloutAnalysisRequeueInterval = 10 * time.Second
+
+// monitorRollout evaluates the candidate Pods of a progressive rollout, and reports the outcome in the Rollout condition.
+// It returns the pending and running Pods of the stable version, that the Integration readiness is computed from.
+func (action *monitorAction) monitorRollout(
+	ctx context.Context, environment *trait.Environment, integration *v1.Integration,
+	pendingPods []corev1.Pod, runningPods []corev1.Pod,
+) ([]corev1.Pod, []corev1.Pod, error) {
+	stablePending, candidatePending := splitRolloutPods(pendingPods)
+	stableRunning, candidateRunning := splitRolloutPods(runningPods)
+
+	candidate := getRolloutCandidate(environment)
+	if candidate == nil || integration.IsRolledOut() {
+		return stablePending, stableRunning, nil
+	}
+	if condition := integration.Status.GetCondition(v1.IntegrationConditionRollout); condition.Reason == v1.IntegrationConditionRolloutAbortedReason {
+		return stablePending, stableRunning, nil
+	}
+
+	// The candidate is evaluated on a copy of the Integration, so that it does not alter the readiness of the stable version
+	probe := integration.DeepCopy()
+	probe.Status.Phase = v1.IntegrationPhaseRunning
+	probe.Status.RemoveCondition(v1.IntegrationConditionReady)
+
+	readyPods := 0
+	controller := &deploymentController{obj: candidate, integration: probe}
+	done, err := controller.checkReadyCondition(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !done && !arePodsFailingStatuses(probe, candidatePending, candidateRunning) {
+		if readyPods, _, err = action.probeReadiness(ctx, environment, probe, candidateRunning); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	replicas := int32(1)
+	if candidate.Spec.Replicas != nil {
+		replicas = *candidate.Spec.Replicas
+	}
+	ready := probe.Status.GetCondition(v1.IntegrationConditionReady)
+	switch {
+	case probe.Status.Phase == v1.IntegrationPhaseError || (ready != nil && ready.Reason == v1.IntegrationConditionErrorReason):
+		message := fmt.Sprintf("candidate deployment %s failed", candidate.Name)
+		if ready != nil && ready.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, ready.Message)
+		}
+		integration.Status.SetCondition(v1.IntegrationConditionRollout, corev1.ConditionFalse,
+			v1.IntegrationConditionRolloutAbortedReason, message)
+	case int32(readyPods) >= replicas:
+		integration.Status.SetCondition(v1.IntegrationConditionRollout, corev1.ConditionFalse,
+			v1.IntegrationConditionRolloutAnalysisReason, fmt.Sprintf("%d/%d candidate replicas ready", readyPods, replicas))
+	default:
+		integration.Status.SetCondition(v1.IntegrationConditionRollout, corev1.ConditionFalse,
+			v1.IntegrationConditionRolloutProgressingReason, fmt.Sprintf("%d/%d candidate replicas ready", readyPods, replicas))
+	}
+
+	return stablePending, stableRunning, nil
+}
+
+// getRolloutCandidate returns the candidate Deployment of a progressive rollout, if any.
+func getRolloutCandidate(environment *trait.Environment) *appsv1.Deployment {
+	var candidate *appsv1.Deployment
+	environment.Resources.VisitDeployment(func(d *appsv1.Deployment) {
+		if isRolloutCandidate(d) {
+			candidate = d
+		}
+	})
+	return candidate
+}
+
+func isRolloutCandidate(obj ctrl.Object) bool {
+	return obj.GetLabels()[v1.IntegrationRolloutLabel] == v1.IntegrationRolloutCandidate
+}
+
+// splitRolloutPods separates the Pods of the stable version from the Pods of the candidate version.
+func splitRolloutPods(pods []corev1.Pod) ([]corev1.Pod, []corev1.Pod) {
+	stable := make([]corev1.Pod, 0, len(pods))
+	var candidate []corev1.Pod
+	for _, pod := range pods {
+		if pod.Labels[v1.IntegrationRolloutLabel] == v1.IntegrationRolloutCandidate {
+			candidate = append(candidate, pod)
+		} else {
+			stable = append(stable, pod)
+		}
+	}
+	return stable, candidate
+}
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/trait"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+func newRolloutTestEnvironment() (*trait.Environment, *v1.Integration) {
+	it := v1.NewIntegration("default", "my-it")
+	it.Status.Phase = v1.IntegrationPhaseRunning
+	it.Status.SetCondition(v1.IntegrationConditionRollout, corev1.ConditionFalse,
+		v1.IntegrationConditionRolloutProgressingReason, "rolling out version v2")
+
+	replicas := int32(1)
+	environment := &trait.Environment{
+		Integration: &it,
+		Resources:   kubernetes.NewCollection(),
+	}
+	environment.Resources.Add(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-it-candidate",
+			Namespace: "default",
+			Labels: map[string]string{
+				v1.IntegrationLabel:        "my-it",
+				v1.IntegrationRolloutLabel: v1.IntegrationRolloutCandidate,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+	})
+
+	return environment, &it
+}
+
+func newRolloutTestPod(name string, version string, ready corev1.ConditionStatus) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				v1.IntegrationLabel:        "my-it",
+				v1.IntegrationRolloutLabel: version,
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: ready},
+			},
+		},
+	}
+}
+
+func TestMonitorRolloutCandidateReady(t *testing.T) {
+	environment, it := newRolloutTestEnvironment()
+	running := []corev1.Pod{
+		newRolloutTestPod("stable", v1.IntegrationRolloutStable, corev1.ConditionTrue),
+		newRolloutTestPod("candidate", v1.IntegrationRolloutCandidate, corev1.ConditionTrue),
+	}
+
+	action := monitorAction{}
+	pending, stable, err := action.monitorRollout(context.TODO(), environment, it, nil, running)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	require.Len(t, stable, 1)
+	assert.Equal(t, "stable", stable[0].Name)
+
+	condition := it.Status.GetCondition(v1.IntegrationConditionRollout)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1.IntegrationConditionRolloutAnalysisReason, condition.Reason)
+	assert.Equal(t, "1/1 candidate replicas ready", condition.Message)
+	// The readiness of the candidate does not leak into the Integration
+	assert.Nil(t, it.Status.GetCondition(v1.IntegrationConditionReady))
+	assert.Equal(t, v1.IntegrationPhaseRunning, it.Status.Phase)
+}
+
+func TestMonitorRolloutCandidateFailing(t *testing.T) {
+	environment, it := newRolloutTestEnvironment()
+	candidate := newRolloutTestPod("candidate", v1.IntegrationRolloutCandidate, corev1.ConditionFalse)
+	candidate.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{
+					Reason:  "CrashLoopBackOff",
+					Message: "back-off restarting failed container",
+				},
+			},
+		},
+	}
+
+	action := monitorAction{}
+	_, _, err := action.monitorRollout(context.TODO(), environment, it, nil, []corev1.Pod{candidate})
+	require.NoError(t, err)
+
+	condition := it.Status.GetCondition(v1.IntegrationConditionRollout)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1.IntegrationConditionRolloutAbortedReason, condition.Reason)
+	assert.Equal(t, "candidate deployment my-it-candidate failed: back-off restarting failed container", condition.Message)
+	assert.False(t, it.IsRolledOut())
+}
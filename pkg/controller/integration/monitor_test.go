@@ -71,12 +71,12 @@ func TestGetIntegrationSecretAndConfigmapResourceVersions(t *testing.T) {
 	c, err := test.NewFakeClient(cm, sec)
 	assert.Nil(t, err)
 	// Default hot reload (false)
-	configmaps, secrets := getIntegrationSecretAndConfigmapResourceVersions(context.TODO(), c, it)
+	configmaps, secrets := kubernetes.LookupIntegrationSecretAndConfigmapResourceVersions(context.TODO(), c, it)
 	assert.Len(t, configmaps, 0)
 	assert.Len(t, secrets, 0)
 	// Enabled hot reload (true)
 	it.Spec.Traits.Mount.HotReload = pointer.Bool(true)
-	configmaps, secrets = getIntegrationSecretAndConfigmapResourceVersions(context.TODO(), c, it)
+	configmaps, secrets = kubernetes.LookupIntegrationSecretAndConfigmapResourceVersions(context.TODO(), c, it)
 	assert.Len(t, configmaps, 1)
 	assert.Len(t, secrets, 1)
 	// We cannot guess resource version value. It should be enough to have any non empty value though.
@@ -108,6 +108,11 @@ func (action *buildAction) handleBuildSubmitted(ctx context.Context, kit *v1.Int
 			annotations[v1.OperatorIDAnnotation] = operatorID
 		}
 
+		priority, err := platform.GetBuildPriority(ctx, action.client, env.Platform, kit)
+		if err != nil {
+			return nil, err
+		}
+
 		timeout := env.Platform.Status.Build.GetTimeout()
 		if layout := labels[v1.IntegrationKitLayoutLabel]; env.Platform.Spec.Build.Timeout == nil && layout == v1.IntegrationKitLayoutNativeSources {
 			// Increase the timeout to a sensible default
@@ -159,8 +164,9 @@ func (action *buildAction) handleBuildSubmitted(ctx context.Context, kit *v1.Int
 				Annotations: annotations,
 			},
 			Spec: v1.BuildSpec{
-				Tasks:   env.Pipeline,
-				Timeout: timeout,
+				Tasks:    env.Pipeline,
+				Timeout:  timeout,
+				Priority: priority,
 			},
 		}
 
@@ -185,6 +191,10 @@ func (action *buildAction) handleBuildSubmitted(ctx context.Context, kit *v1.Int
 		return kit, nil
 	}
 
+	if build.Status.Phase == v1.BuildPhaseCancelled {
+		return buildCancelled(kit), nil
+	}
+
 	return nil, nil
 }
 
@@ -255,7 +265,21 @@ func (action *buildAction) handleBuildRunning(ctx context.Context, kit *v1.Integ
 		kit.Status.Phase = v1.IntegrationKitPhaseError
 
 		return kit, nil
+	case v1.BuildPhaseCancelled:
+		return buildCancelled(kit), nil
 	}
 
 	return nil, nil
 }
+
+// buildCancelled moves the kit to the error phase, without any recovery, as the cancelled build must not be retried.
+func buildCancelled(kit *v1.IntegrationKit) *v1.IntegrationKit {
+	kit.Status.Phase = v1.IntegrationKitPhaseError
+	// Adding the failure in order to include this info in the Integration as well
+	kit.Status.Failure = &v1.Failure{
+		Reason: "Build cancelled",
+		Time:   metav1.Now(),
+	}
+
+	return kit
+}
@@ -0,0 +1,295 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationkit
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/registry"
+	"github.com/apache/camel-k/v2/pkg/util/revision"
+)
+
+// gcInterval is the interval at which the ready IntegrationKits are checked against the kit retention policy.
+const gcInterval = 10 * time.Minute
+
+// kitUsageTTL is the duration during which the usage of the kits of a namespace is shared by the checks of all its
+// kits, so that the resources referencing the kits are listed once per namespace, rather than once per kit.
+const kitUsageTTL = time.Minute
+
+// collectGarbage enforces the kit retention policy of the platform on the given ready kit, created by the operator.
+// The kit is either deleted, or its last use is recorded. It returns the duration after which the kit has to be checked
+// again, that is zero when the kit has been deleted or no retention policy applies.
+func collectGarbage(ctx context.Context, c client.Client, l log.Logger, usages *kitUsageCache, kit *v1.IntegrationKit) (time.Duration, error) {
+	if kit.Labels[v1.IntegrationKitTypeLabel] != v1.IntegrationKitTypePlatform {
+		return 0, nil
+	}
+
+	pl, err := platform.GetForResource(ctx, c, kit)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := platform.ApplyIntegrationProfile(ctx, c, pl, kit); err != nil {
+		return 0, err
+	}
+	policy := pl.Status.Build.KitRetention
+	if policy == nil || (policy.KeepPerIntegration == nil && policy.TTL == nil) {
+		return 0, nil
+	}
+
+	now := time.Now()
+	usage, err := usages.get(ctx, c, kit.Namespace, now)
+	if err != nil {
+		return 0, err
+	}
+	if !usage.inUse(kit) && kit.Status.LastUsedTimestamp != nil && removable(policy, usage, kit, now) && usage.computed.Before(now) {
+		// the shared usage may be outdated, it's computed again before the kit gets deleted
+		if usage, err = usages.refresh(ctx, c, kit.Namespace, now); err != nil {
+			return 0, err
+		}
+	}
+
+	if usage.inUse(kit) || kit.Status.LastUsedTimestamp == nil {
+		// the retention starts from the last time the kit is found in use, or from when it gets ready
+		target := kit.DeepCopy()
+		target.Status.LastUsedTimestamp = &metav1.Time{Time: now}
+		if err := c.Status().Patch(ctx, target, ctrl.MergeFrom(kit)); err != nil {
+			return 0, err
+		}
+		return gcInterval, nil
+	}
+
+	if !removable(policy, usage, kit, now) {
+		return gcInterval, nil
+	}
+
+	l.Infof("Deleting IntegrationKit %s, last used at %s, according to the kit retention policy", kit.Name, kit.Status.LastUsedTimestamp)
+	if policy.DeleteImages && pl.Status.Build.PublishStrategy != v1.IntegrationPlatformBuildPublishStrategyS2I && kit.Status.Image != "" {
+		// the image deletion is best effort, not to retain the kit forever when the registry does not support it
+		if err := registry.DeleteImage(ctx, c, kit.Namespace, pl.Status.Build.Registry.Secret, kit.Status.Image, pl.Status.Build.Registry.Insecure); err != nil {
+			l.Errorf(err, "Cannot delete the image %s of IntegrationKit %s from the registry", kit.Status.Image, kit.Name)
+		}
+	}
+	if err := c.Delete(ctx, kit); err != nil && !k8serrors.IsNotFound(err) {
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+// removable returns whether the given kit, that is not in use, has to be deleted according to the kit retention policy.
+func removable(policy *v1.IntegrationKitRetentionSpec, usage *kitUsage, kit *v1.IntegrationKit, now time.Time) bool {
+	expired := policy.TTL != nil && now.Sub(kit.Status.LastUsedTimestamp.Time) > policy.TTL.Duration
+	exceeding := policy.KeepPerIntegration != nil && usage.rank(kit, now) >= int(*policy.KeepPerIntegration)
+	return expired || exceeding
+}
+
+// kitUsageCache shares the usage of the kits of each namespace between the checks of its kits.
+type kitUsageCache struct {
+	lock   sync.Mutex
+	usages map[string]*kitUsage
+}
+
+func newKitUsageCache() *kitUsageCache {
+	return &kitUsageCache{
+		usages: make(map[string]*kitUsage),
+	}
+}
+
+// get returns the usage of the kits of the given namespace, that is computed again once it has expired.
+func (u *kitUsageCache) get(ctx context.Context, c client.Client, namespace string, now time.Time) (*kitUsage, error) {
+	u.lock.Lock()
+	usage, ok := u.usages[namespace]
+	u.lock.Unlock()
+	if ok && now.Sub(usage.computed) < kitUsageTTL {
+		return usage, nil
+	}
+
+	return u.refresh(ctx, c, namespace, now)
+}
+
+// refresh computes the usage of the kits of the given namespace, and shares it with the next checks.
+func (u *kitUsageCache) refresh(ctx context.Context, c client.Client, namespace string, now time.Time) (*kitUsage, error) {
+	usage, err := newKitUsage(ctx, c, namespace, now)
+	if err != nil {
+		return nil, err
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	for ns, cached := range u.usages {
+		if now.Sub(cached.computed) >= kitUsageTTL {
+			delete(u.usages, ns)
+		}
+	}
+	u.usages[namespace] = usage
+	return usage, nil
+}
+
+// kitUsage records which IntegrationKits of a namespace are in use.
+type kitUsage struct {
+	// the kits of the namespace
+	kits []v1.IntegrationKit
+	// the names of the kits referenced by an Integration, or by the revision history of an Integration or a Pipe
+	referenced map[string]bool
+	// the images referenced by the revision history of the Integrations and the Pipes, or of their Deployments,
+	// or by the kits being built
+	images map[string]bool
+	// the time the usage has been computed
+	computed time.Time
+}
+
+func newKitUsage(ctx context.Context, c client.Client, namespace string, now time.Time) (*kitUsage, error) {
+	usage := kitUsage{
+		referenced: make(map[string]bool),
+		images:     make(map[string]bool),
+		computed:   now,
+	}
+
+	kits := v1.NewIntegrationKitList()
+	if err := c.List(ctx, &kits, ctrl.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	usage.kits = kits.Items
+	for _, kit := range kits.Items {
+		if kit.Status.Phase != v1.IntegrationKitPhaseReady && kit.Status.Phase != v1.IntegrationKitPhaseError && kit.Status.BaseImage != "" {
+			// the kit is used as base image for an incremental build
+			usage.images[kit.Status.BaseImage] = true
+		}
+	}
+
+	// Integrations may use kits from another namespace
+	integrations := v1.NewIntegrationList()
+	if err := c.List(ctx, &integrations); err != nil {
+		return nil, err
+	}
+	namespaces := map[string]bool{namespace: true}
+	for _, it := range integrations.Items {
+		// the kit set in the specification is used when the Integration is initialized again
+		for _, ref := range []*corev1.ObjectReference{it.Status.IntegrationKit, it.Spec.IntegrationKit} {
+			if usage.reference(namespace, it.Namespace, ref) {
+				namespaces[it.Namespace] = true
+			}
+		}
+	}
+
+	// The Integrations and the Pipes can be rolled back to a revision, that reuses the kit it was running with
+	revisions, err := revision.ListAll(ctx, c, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range revisions {
+		usage.reference(namespace, r.Namespace, r.IntegrationKit)
+		if r.Image != "" {
+			usage.images[r.Image] = true
+		}
+	}
+
+	// The Deployments of the Integrations keep the previous ReplicaSets, so that they can be rolled back
+	for ns := range namespaces {
+		replicaSets, err := c.AppsV1().ReplicaSets(ns).List(ctx, metav1.ListOptions{LabelSelector: v1.IntegrationLabel})
+		if err != nil {
+			return nil, err
+		}
+		for _, rs := range replicaSets.Items {
+			for _, container := range rs.Spec.Template.Spec.Containers {
+				usage.images[container.Image] = true
+			}
+			for _, container := range rs.Spec.Template.Spec.InitContainers {
+				usage.images[container.Image] = true
+			}
+		}
+	}
+
+	return &usage, nil
+}
+
+// reference records the kit referenced from the given namespace, if it belongs to the namespace of the usage.
+func (u *kitUsage) reference(namespace string, from string, ref *corev1.ObjectReference) bool {
+	if ref == nil || ref.Name == "" {
+		return false
+	}
+	if ref.Namespace == namespace || (ref.Namespace == "" && from == namespace) {
+		u.referenced[ref.Name] = true
+		return true
+	}
+	return false
+}
+
+func (u *kitUsage) inUse(kit *v1.IntegrationKit) bool {
+	return u.referenced[kit.Name] || (kit.Status.Image != "" && u.images[kit.Status.Image])
+}
+
+// rank returns the position of the given kit amongst the ready kits created for the same Integration, the most
+// recently used first. Kits which are not created for an Integration are always ranked first.
+func (u *kitUsage) rank(kit *v1.IntegrationKit, now time.Time) int {
+	if kit.Labels[kubernetes.CamelCreatorLabelKind] != v1.IntegrationKind {
+		return 0
+	}
+
+	var siblings []*v1.IntegrationKit
+	for i := range u.kits {
+		k := &u.kits[i]
+		if k.Status.Phase != v1.IntegrationKitPhaseReady || k.Labels[v1.IntegrationKitTypeLabel] != v1.IntegrationKitTypePlatform {
+			continue
+		}
+		if k.Labels[kubernetes.CamelCreatorLabelKind] == v1.IntegrationKind &&
+			k.Labels[kubernetes.CamelCreatorLabelName] == kit.Labels[kubernetes.CamelCreatorLabelName] &&
+			k.Labels[kubernetes.CamelCreatorLabelNamespace] == kit.Labels[kubernetes.CamelCreatorLabelNamespace] {
+			siblings = append(siblings, k)
+		}
+	}
+
+	lastUsed := func(k *v1.IntegrationKit) time.Time {
+		switch {
+		case u.inUse(k):
+			return now
+		case k.Status.LastUsedTimestamp != nil:
+			return k.Status.LastUsedTimestamp.Time
+		default:
+			return k.CreationTimestamp.Time
+		}
+	}
+	sort.SliceStable(siblings, func(i, j int) bool {
+		ti, tj := lastUsed(siblings[i]), lastUsed(siblings[j])
+		if ti.Equal(tj) {
+			return siblings[i].Name < siblings[j].Name
+		}
+		return ti.After(tj)
+	})
+
+	for i, k := range siblings {
+		if k.Name == kit.Name {
+			return i
+		}
+	}
+	return 0
+}
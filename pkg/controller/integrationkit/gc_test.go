@@ -0,0 +1,277 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationkit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/revision"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+func newGCTestPlatform(policy *v1.IntegrationKitRetentionSpec) *v1.IntegrationPlatform {
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1.IntegrationPlatformPhaseReady
+	pl.Status.Build.KitRetention = policy
+	return &pl
+}
+
+func newGCTestKit(name string, integration string, lastUsed *time.Time) *v1.IntegrationKit {
+	kit := v1.NewIntegrationKit("ns", name)
+	kit.Labels = map[string]string{
+		v1.IntegrationKitTypeLabel:            v1.IntegrationKitTypePlatform,
+		kubernetes.CamelCreatorLabelKind:      v1.IntegrationKind,
+		kubernetes.CamelCreatorLabelName:      integration,
+		kubernetes.CamelCreatorLabelNamespace: "ns",
+	}
+	kit.Status.Phase = v1.IntegrationKitPhaseReady
+	kit.Status.Platform = "camel-k"
+	kit.Status.Image = "registry/ns/" + name + "@sha256:0123"
+	if lastUsed != nil {
+		kit.Status.LastUsedTimestamp = &metav1.Time{Time: *lastUsed}
+	}
+	return kit
+}
+
+func newGCTestIntegration(name string, kit string) *v1.Integration {
+	it := v1.NewIntegration("ns", name)
+	it.Status.IntegrationKit = &corev1.ObjectReference{
+		Namespace: "ns",
+		Name:      kit,
+	}
+	return &it
+}
+
+func assertKitExists(t *testing.T, c client.Client, name string, exists bool) {
+	t.Helper()
+	kit := v1.NewIntegrationKit("ns", name)
+	err := c.Get(context.TODO(), ctrl.ObjectKeyFromObject(kit), kit)
+	if exists {
+		require.NoError(t, err)
+	} else {
+		assert.True(t, k8serrors.IsNotFound(err))
+	}
+}
+
+func collectGarbageForTest(t *testing.T, c client.Client, name string) time.Duration {
+	t.Helper()
+	kit := v1.NewIntegrationKit("ns", name)
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(kit), kit))
+	requeueAfter, err := collectGarbage(context.TODO(), c, log.Log, newKitUsageCache(), kit)
+	require.NoError(t, err)
+	return requeueAfter
+}
+
+func TestCollectGarbageWithoutPolicy(t *testing.T) {
+	lastUsed := time.Now().Add(-24 * time.Hour)
+	c, err := test.NewFakeClient(newGCTestPlatform(nil), newGCTestKit("kit-1", "my-it", &lastUsed))
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Duration(0), collectGarbageForTest(t, c, "kit-1"))
+	assertKitExists(t, c, "kit-1", true)
+}
+
+func TestCollectGarbageKeepPerIntegration(t *testing.T) {
+	keep := int32(2)
+	hourAgo := time.Now().Add(-time.Hour)
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	c, err := test.NewFakeClient(
+		newGCTestPlatform(&v1.IntegrationKitRetentionSpec{KeepPerIntegration: &keep}),
+		newGCTestKit("kit-1", "my-it", &twoHoursAgo),
+		newGCTestKit("kit-2", "my-it", &hourAgo),
+		newGCTestKit("kit-3", "my-it", &twoHoursAgo),
+		newGCTestKit("kit-4", "other-it", &twoHoursAgo),
+		newGCTestIntegration("my-it", "kit-3"),
+	)
+	require.NoError(t, err)
+
+	// kit-3 is in use, kit-2 is the most recently used one
+	assert.Equal(t, gcInterval, collectGarbageForTest(t, c, "kit-3"))
+	assert.Equal(t, gcInterval, collectGarbageForTest(t, c, "kit-2"))
+	assert.Equal(t, time.Duration(0), collectGarbageForTest(t, c, "kit-1"))
+	assert.Equal(t, gcInterval, collectGarbageForTest(t, c, "kit-4"))
+
+	assertKitExists(t, c, "kit-1", false)
+	assertKitExists(t, c, "kit-2", true)
+	assertKitExists(t, c, "kit-3", true)
+	assertKitExists(t, c, "kit-4", true)
+
+	kit := v1.NewIntegrationKit("ns", "kit-3")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(kit), kit))
+	assert.True(t, kit.Status.LastUsedTimestamp.After(hourAgo))
+}
+
+func TestCollectGarbageTTL(t *testing.T) {
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	tenMinutesAgo := time.Now().Add(-10 * time.Minute)
+	revision := appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-it-5d8b9f",
+			Labels: map[string]string{
+				v1.IntegrationLabel: "my-it",
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Image: "registry/ns/kit-2@sha256:0123"}},
+				},
+			},
+		},
+	}
+	c, err := test.NewFakeClient(
+		newGCTestPlatform(&v1.IntegrationKitRetentionSpec{TTL: &metav1.Duration{Duration: time.Hour}}),
+		newGCTestKit("kit-1", "my-it", &twoHoursAgo),
+		newGCTestKit("kit-2", "my-it", &twoHoursAgo),
+		newGCTestKit("kit-3", "my-it", &tenMinutesAgo),
+		newGCTestKit("kit-4", "my-it", nil),
+		&revision,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Duration(0), collectGarbageForTest(t, c, "kit-1"))
+	// kit-2 is still referenced by the revision history
+	assert.Equal(t, gcInterval, collectGarbageForTest(t, c, "kit-2"))
+	assert.Equal(t, gcInterval, collectGarbageForTest(t, c, "kit-3"))
+	// the retention of kit-4 starts now
+	assert.Equal(t, gcInterval, collectGarbageForTest(t, c, "kit-4"))
+
+	assertKitExists(t, c, "kit-1", false)
+	assertKitExists(t, c, "kit-2", true)
+	assertKitExists(t, c, "kit-3", true)
+	assertKitExists(t, c, "kit-4", true)
+
+	kit := v1.NewIntegrationKit("ns", "kit-4")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(kit), kit))
+	assert.NotNil(t, kit.Status.LastUsedTimestamp)
+}
+
+func TestCollectGarbageIgnoresUserKits(t *testing.T) {
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	kit := newGCTestKit("kit-1", "my-it", &twoHoursAgo)
+	kit.Labels[v1.IntegrationKitTypeLabel] = v1.IntegrationKitTypeUser
+	c, err := test.NewFakeClient(
+		newGCTestPlatform(&v1.IntegrationKitRetentionSpec{TTL: &metav1.Duration{Duration: time.Hour}}),
+		kit,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Duration(0), collectGarbageForTest(t, c, "kit-1"))
+	assertKitExists(t, c, "kit-1", true)
+}
+
+func TestCollectGarbageKeepsKitsOfRevisions(t *testing.T) {
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	it := newGCTestIntegration("my-it", "kit-3")
+	pipe := v1.NewPipe("other", "my-pipe")
+	c, err := test.NewFakeClient(
+		newGCTestPlatform(&v1.IntegrationKitRetentionSpec{TTL: &metav1.Duration{Duration: time.Hour}}),
+		newGCTestKit("kit-1", "my-it", &twoHoursAgo),
+		newGCTestKit("kit-2", "my-pipe", &twoHoursAgo),
+		newGCTestKit("kit-3", "my-it", nil),
+		newGCTestKit("kit-4", "my-it", &twoHoursAgo),
+		it,
+		&pipe,
+	)
+	require.NoError(t, err)
+
+	_, err = revision.Record(context.TODO(), c, it, v1.IntegrationKind, it.Spec, "v1", &corev1.ObjectReference{Namespace: "ns", Name: "kit-1"}, "")
+	require.NoError(t, err)
+	_, err = revision.Record(context.TODO(), c, &pipe, v1.PipeKind, pipe.Spec, "v1", nil, "registry/ns/kit-2@sha256:0123")
+	require.NoError(t, err)
+
+	// kit-1 and kit-2 can be rolled back to
+	assert.Equal(t, gcInterval, collectGarbageForTest(t, c, "kit-1"))
+	assert.Equal(t, gcInterval, collectGarbageForTest(t, c, "kit-2"))
+	assert.Equal(t, time.Duration(0), collectGarbageForTest(t, c, "kit-4"))
+
+	assertKitExists(t, c, "kit-1", true)
+	assertKitExists(t, c, "kit-2", true)
+	assertKitExists(t, c, "kit-4", false)
+}
+
+func TestCollectGarbageKeepsKitsOfIntegrationSpec(t *testing.T) {
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	it := v1.NewIntegration("ns", "my-it")
+	it.Spec.IntegrationKit = &corev1.ObjectReference{Name: "kit-1"}
+	c, err := test.NewFakeClient(
+		newGCTestPlatform(&v1.IntegrationKitRetentionSpec{TTL: &metav1.Duration{Duration: time.Hour}}),
+		newGCTestKit("kit-1", "my-it", &twoHoursAgo),
+		newGCTestKit("kit-2", "my-it", &twoHoursAgo),
+		&it,
+	)
+	require.NoError(t, err)
+
+	// kit-1 is used when the Integration is initialized again
+	assert.Equal(t, gcInterval, collectGarbageForTest(t, c, "kit-1"))
+	assert.Equal(t, time.Duration(0), collectGarbageForTest(t, c, "kit-2"))
+
+	assertKitExists(t, c, "kit-1", true)
+	assertKitExists(t, c, "kit-2", false)
+}
+
+func TestCollectGarbageSharesKitUsage(t *testing.T) {
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	c, err := test.NewFakeClient(
+		newGCTestPlatform(&v1.IntegrationKitRetentionSpec{TTL: &metav1.Duration{Duration: time.Hour}}),
+		newGCTestKit("kit-1", "my-it", &twoHoursAgo),
+		newGCTestKit("kit-2", "my-it", nil),
+	)
+	require.NoError(t, err)
+
+	ctx := context.TODO()
+	usages := newKitUsageCache()
+	now := time.Now()
+	usage, err := usages.get(ctx, c, "ns", now)
+	require.NoError(t, err)
+	cached, err := usages.get(ctx, c, "ns", now.Add(kitUsageTTL/2))
+	require.NoError(t, err)
+	assert.Same(t, usage, cached)
+	cached, err = usages.get(ctx, c, "ns", now.Add(kitUsageTTL))
+	require.NoError(t, err)
+	assert.NotSame(t, usage, cached)
+
+	// The shared usage does not know that kit-1 is now in use, it's computed again before deleting it
+	usages = newKitUsageCache()
+	_, err = usages.get(ctx, c, "ns", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, c.Create(ctx, newGCTestIntegration("my-it", "kit-1")))
+	kit := v1.NewIntegrationKit("ns", "kit-1")
+	require.NoError(t, c.Get(ctx, ctrl.ObjectKeyFromObject(kit), kit))
+	requeueAfter, err := collectGarbage(ctx, c, log.Log, usages, kit)
+	require.NoError(t, err)
+	assert.Equal(t, gcInterval, requeueAfter)
+	assertKitExists(t, c, "kit-1", true)
+}
@@ -54,9 +54,10 @@ func Add(ctx context.Context, mgr manager.Manager, c client.Client) error {
 func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
 	return monitoring.NewInstrumentedReconciler(
 		&reconcileIntegrationKit{
-			client:   c,
-			scheme:   mgr.GetScheme(),
-			recorder: mgr.GetEventRecorderFor("camel-k-integration-kit-controller"),
+			client:    c,
+			scheme:    mgr.GetScheme(),
+			recorder:  mgr.GetEventRecorderFor("camel-k-integration-kit-controller"),
+			kitUsages: newKitUsageCache(),
 		},
 		schema.GroupVersionKind{
 			Group:   v1.SchemeGroupVersion.Group,
@@ -192,6 +193,8 @@ type reconcileIntegrationKit struct {
 	client   client.Client
 	scheme   *runtime.Scheme
 	recorder record.EventRecorder
+	// the usage of the kits, shared by the checks against the kit retention policy
+	kitUsages *kitUsageCache
 }
 
 // Reconcile reads that state of the cluster for a IntegrationKit object and makes changes based on the state read
@@ -320,6 +323,17 @@ func (r *reconcileIntegrationKit) Reconcile(ctx context.Context, request reconci
 		}, nil
 	}
 
+	if targetPhase == v1.IntegrationKitPhaseReady {
+		// Periodically check the ready kit against the kit retention policy
+		requeueAfter, err := collectGarbage(ctx, r.client, targetLog, r.kitUsages, target)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{
+			RequeueAfter: requeueAfter,
+		}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
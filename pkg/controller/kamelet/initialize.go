@@ -0,0 +1,137 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/kamelets"
+)
+
+// NewInitializeAction returns a action that validates the Kamelet when it's first reconciled.
+func NewInitializeAction() Action {
+	return &initializeAction{}
+}
+
+type initializeAction struct {
+	baseAction
+}
+
+func (action *initializeAction) Name() string {
+	return "initialize"
+}
+
+func (action *initializeAction) CanHandle(kamelet *v1.Kamelet) bool {
+	return kamelet.Status.Phase == v1.KameletPhaseNone
+}
+
+func (action *initializeAction) Handle(ctx context.Context, kamelet *v1.Kamelet) (*v1.Kamelet, error) {
+	action.L.Info("Initializing Kamelet")
+
+	return updateStatus(kamelet)
+}
+
+// updateStatus validates the Kamelet, and sets its phase and Ready condition accordingly,
+// as well as the default values of its properties.
+func updateStatus(kamelet *v1.Kamelet) (*v1.Kamelet, error) {
+	target := kamelet.DeepCopy()
+
+	properties, err := recomputeProperties(kamelet)
+	if err != nil {
+		target.Status.Phase = v1.KameletPhaseError
+		target.Status.SetErrorCondition(v1.KameletConditionReady, v1.KameletConditionReasonInvalidProperty, err)
+		return target, nil
+	}
+	target.Status.Properties = properties
+
+	if reason, err := validate(kamelet); err != nil {
+		target.Status.Phase = v1.KameletPhaseError
+		target.Status.SetErrorCondition(v1.KameletConditionReady, reason, err)
+		return target, nil
+	}
+
+	target.Status.Phase = v1.KameletPhaseReady
+	target.Status.SetCondition(v1.KameletConditionReady, corev1.ConditionTrue, "", "")
+
+	return target, nil
+}
+
+// validate checks the Kamelet, and returns the reason of the Ready condition along with the error if it's invalid.
+func validate(kamelet *v1.Kamelet) (string, error) {
+	if !v1.ValidKameletName(kamelet.Name) {
+		return v1.KameletConditionReasonInvalidName, fmt.Errorf("name %q is reserved", kamelet.Name)
+	}
+	if reason, err := validateSpec(kamelet); err != nil {
+		return reason, err
+	}
+	for _, version := range kamelet.SortedVersions() {
+		clone, err := kamelet.CloneWithVersion(version)
+		if err != nil {
+			return v1.KameletConditionReasonInvalidSchema, err
+		}
+		if reason, err := validateSpec(clone); err != nil {
+			return reason, fmt.Errorf("version %q: %w", version, err)
+		}
+	}
+
+	return "", nil
+}
+
+// validateSpec checks the specification of the Kamelet, i.e., the selected version.
+func validateSpec(kamelet *v1.Kamelet) (string, error) {
+	if !v1.ValidKameletProperties(kamelet) {
+		return v1.KameletConditionReasonInvalidProperty, fmt.Errorf("property %q is reserved and cannot be part of the schema definition", v1.KameletIDProperty)
+	}
+	if err := kamelets.ValidateKamelet(kamelet); err != nil {
+		return v1.KameletConditionReasonInvalidSchema, err
+	}
+	if err := kamelets.ValidateTemplate(kamelet); err != nil {
+		return v1.KameletConditionReasonInvalidTemplate, fmt.Errorf("invalid template: %w", err)
+	}
+
+	return "", nil
+}
+
+// recomputeProperties returns the properties of the Kamelet definition, sorted by name, along with their default values.
+func recomputeProperties(kamelet *v1.Kamelet) ([]v1.KameletProperty, error) {
+	if kamelet.Spec.Definition == nil {
+		return nil, nil
+	}
+
+	properties := make([]v1.KameletProperty, 0, len(kamelet.Spec.Definition.Properties))
+	for _, name := range kamelet.SortedDefinitionPropertiesKeys() {
+		property := v1.KameletProperty{
+			Name: name,
+		}
+		if d := kamelet.Spec.Definition.Properties[name].Default; d != nil {
+			var value interface{}
+			if err := json.Unmarshal(d.RawMessage, &value); err != nil {
+				return nil, fmt.Errorf("cannot decode default value of property %q: %w", name, err)
+			}
+			property.Default = fmt.Sprintf("%v", value)
+		}
+		properties = append(properties, property)
+	}
+
+	return properties, nil
+}
@@ -0,0 +1,171 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+)
+
+func nominalKamelet() *v1.Kamelet {
+	kamelet := v1.NewKamelet("default", "timer-source")
+	kamelet.Labels = map[string]string{
+		v1.KameletTypeLabel: v1.KameletTypeSource,
+	}
+	kamelet.Spec.Definition = &v1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"message"},
+		Properties: map[string]v1.JSONSchemaProp{
+			"period": {
+				Type:    "integer",
+				Default: &v1.JSON{RawMessage: v1.RawMessage("1000")},
+			},
+			"message": {
+				Type: "string",
+			},
+		},
+	}
+	kamelet.Spec.Template = &v1.Template{
+		RawMessage: v1.RawMessage(`{"from":{"uri":"timer:tick","parameters":{"period":"{{period}}"},"steps":[{"setBody":{"constant":"{{message}}"}},{"to":"kamelet:sink"}]}}`),
+	}
+	return &kamelet
+}
+
+func handle(t *testing.T, a Action, kamelet *v1.Kamelet) *v1.Kamelet {
+	t.Helper()
+
+	a.InjectLogger(log.Log)
+	require.True(t, a.CanHandle(kamelet))
+	target, err := a.Handle(context.TODO(), kamelet)
+	require.NoError(t, err)
+	return target
+}
+
+func TestInitializeValidKamelet(t *testing.T) {
+	target := handle(t, NewInitializeAction(), nominalKamelet())
+
+	require.NotNil(t, target)
+	assert.Equal(t, v1.KameletPhaseReady, target.Status.Phase)
+	condition := target.Status.GetCondition(v1.KameletConditionReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, []v1.KameletProperty{
+		{Name: "message"},
+		{Name: "period", Default: "1000"},
+	}, target.Status.Properties)
+}
+
+func TestInitializeInvalidKamelet(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(kamelet *v1.Kamelet)
+		reason  string
+		message string
+	}{
+		{
+			name: "reserved name",
+			mutate: func(kamelet *v1.Kamelet) {
+				kamelet.Name = "source"
+			},
+			reason:  v1.KameletConditionReasonInvalidName,
+			message: `name "source" is reserved`,
+		},
+		{
+			name: "reserved property",
+			mutate: func(kamelet *v1.Kamelet) {
+				kamelet.Spec.Definition.Properties[v1.KameletIDProperty] = v1.JSONSchemaProp{Type: "string"}
+			},
+			reason:  v1.KameletConditionReasonInvalidProperty,
+			message: `property "id" is reserved and cannot be part of the schema definition`,
+		},
+		{
+			name: "invalid schema",
+			mutate: func(kamelet *v1.Kamelet) {
+				kamelet.Spec.Definition.Required = append(kamelet.Spec.Definition.Required, "delay")
+			},
+			reason:  v1.KameletConditionReasonInvalidSchema,
+			message: `invalid definition: required property "delay" is not declared`,
+		},
+		{
+			name: "invalid template",
+			mutate: func(kamelet *v1.Kamelet) {
+				kamelet.Spec.Template = &v1.Template{
+					RawMessage: v1.RawMessage(`{"from":{"uri":"timer:tick","steps":[{"to":"log:info"}]}}`),
+				}
+			},
+			reason:  v1.KameletConditionReasonInvalidTemplate,
+			message: "invalid template: a source Kamelet must produce to kamelet:sink",
+		},
+		{
+			name: "invalid version",
+			mutate: func(kamelet *v1.Kamelet) {
+				version := kamelet.Spec.KameletSpecBase.DeepCopy()
+				version.Template = &v1.Template{
+					RawMessage: v1.RawMessage(`{"from":{"uri":"timer:tick","steps":[{"to":"log:info"}]}}`),
+				}
+				kamelet.Spec.Versions = map[string]v1.KameletSpecBase{
+					"v1": kamelet.Spec.KameletSpecBase,
+					"v2": *version,
+				}
+			},
+			reason:  v1.KameletConditionReasonInvalidTemplate,
+			message: `version "v2": invalid template: a source Kamelet must produce to kamelet:sink`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kamelet := nominalKamelet()
+			test.mutate(kamelet)
+
+			target := handle(t, NewInitializeAction(), kamelet)
+
+			require.NotNil(t, target)
+			assert.Equal(t, v1.KameletPhaseError, target.Status.Phase)
+			condition := target.Status.GetCondition(v1.KameletConditionReady)
+			require.NotNil(t, condition)
+			assert.Equal(t, corev1.ConditionFalse, condition.Status)
+			assert.Equal(t, test.reason, condition.Reason)
+			assert.Equal(t, test.message, condition.Message)
+		})
+	}
+}
+
+func TestMonitorKamelet(t *testing.T) {
+	kamelet := handle(t, NewInitializeAction(), nominalKamelet())
+	kamelet.Generation = 1
+	kamelet.Status.ObservedGeneration = 1
+
+	// The Kamelet is only validated again when its specification changes
+	assert.Nil(t, handle(t, NewMonitorAction(), kamelet))
+
+	kamelet.Generation = 2
+	kamelet.Spec.Template = nil
+	target := handle(t, NewMonitorAction(), kamelet)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.KameletPhaseError, target.Status.Phase)
+	assert.Equal(t, "invalid template: no template specified", target.Status.GetCondition(v1.KameletConditionReady).Message)
+}
@@ -19,6 +19,7 @@ package kameletbinding
 
 import (
 	"context"
+	"os"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -56,6 +57,7 @@ func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
 			client:   c,
 			scheme:   mgr.GetScheme(),
 			recorder: mgr.GetEventRecorderFor("camel-k-kamelet-binding-controller"),
+			migrate:  os.Getenv(MigrateKameletBindingsEnvVariable) == "true",
 		},
 		schema.GroupVersionKind{
 			Group:   v1alpha1.SchemeGroupVersion.Group,
@@ -138,6 +140,8 @@ type ReconcileKameletBinding struct {
 	client   client.Client
 	scheme   *runtime.Scheme
 	recorder record.EventRecorder
+	// migrate converts the KameletBindings to Pipes instead of reconciling them
+	migrate bool
 }
 
 // Reconcile reads that state of the cluster for a KameletBinding object and makes changes based
@@ -179,6 +183,24 @@ func (r *ReconcileKameletBinding) Reconcile(ctx context.Context, request reconci
 		return reconcile.Result{}, nil
 	}
 
+	if r.migrate {
+		pipe, created, err := MigrateToPipe(ctx, r.client, &instance)
+		if err != nil {
+			camelevent.NotifyKameletBindingError(ctx, r.client, r.recorder, &instance, nil, err)
+			return reconcile.Result{}, err
+		}
+		if created {
+			rlog.Infof("KameletBinding migrated to Pipe %q", pipe.Name)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// The Pipe the KameletBinding has been migrated to manages the Integration
+	if instance.IsMigrated() {
+		rlog.Info("Ignoring request because KameletBinding has been migrated to a Pipe")
+		return reconcile.Result{}, nil
+	}
+
 	actions := []Action{
 		NewInitializeAction(),
 		NewMonitorAction(),
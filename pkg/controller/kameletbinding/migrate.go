@@ -0,0 +1,196 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kameletbinding
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util"
+)
+
+// MigrateKameletBindingsEnvVariable enables the migration of the KameletBindings to Pipes by the operator.
+const MigrateKameletBindingsEnvVariable = "CAMEL_K_MIGRATE_KAMELET_BINDINGS"
+
+// lastAppliedConfigurationAnnotation is set by kubectl with the KameletBinding manifest, which is meaningless for the Pipe.
+const lastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// ConvertToPipe returns the Pipe equivalent to the given KameletBinding.
+func ConvertToPipe(binding *v1alpha1.KameletBinding) (*v1.Pipe, error) {
+	pipe := v1.NewPipe(binding.Namespace, binding.Name)
+	pipe.Annotations = util.CopyMap(binding.Annotations)
+	delete(pipe.Annotations, v1alpha1.KameletBindingMigratedAnnotation)
+	delete(pipe.Annotations, lastAppliedConfigurationAnnotation)
+	pipe.Labels = util.CopyMap(binding.Labels)
+
+	if binding.Spec.Integration != nil {
+		pipe.Spec.Integration = binding.Spec.Integration.DeepCopy()
+	}
+	if binding.Spec.Replicas != nil {
+		replicas := *binding.Spec.Replicas
+		pipe.Spec.Replicas = &replicas
+	}
+	pipe.Spec.ServiceAccountName = binding.Spec.ServiceAccountName
+
+	var err error
+	if pipe.Spec.Source, err = convertEndpoint("source", binding.Spec.Source); err != nil {
+		return nil, err
+	}
+	if pipe.Spec.Sink, err = convertEndpoint("sink", binding.Spec.Sink); err != nil {
+		return nil, err
+	}
+	for idx, step := range binding.Spec.Steps {
+		s, err := convertEndpoint(fmt.Sprintf("steps[%d]", idx), step)
+		if err != nil {
+			return nil, err
+		}
+		pipe.Spec.Steps = append(pipe.Spec.Steps, s)
+	}
+	if binding.Spec.ErrorHandler != nil {
+		// the error handler specification is the same in both APIs
+		pipe.Spec.ErrorHandler = &v1.ErrorHandlerSpec{
+			RawMessage: v1.RawMessage(append([]byte(nil), binding.Spec.ErrorHandler.RawMessage...)),
+		}
+	}
+
+	return &pipe, nil
+}
+
+func convertEndpoint(name string, endpoint v1alpha1.Endpoint) (v1.Endpoint, error) {
+	if len(endpoint.Types) > 0 {
+		return v1.Endpoint{}, fmt.Errorf("%s uses the deprecated types field, which cannot be migrated: use dataTypes instead", name)
+	}
+
+	e := v1.Endpoint{}
+	if endpoint.Ref != nil {
+		ref := *endpoint.Ref
+		e.Ref = &ref
+	}
+	if endpoint.URI != nil {
+		uri := *endpoint.URI
+		e.URI = &uri
+	}
+	if endpoint.Properties != nil {
+		e.Properties = &v1.EndpointProperties{
+			RawMessage: v1.RawMessage(append([]byte(nil), endpoint.Properties.RawMessage...)),
+		}
+	}
+	if len(endpoint.DataTypes) > 0 {
+		e.DataTypes = make(map[v1.TypeSlot]v1.DataTypeReference, len(endpoint.DataTypes))
+		for slot, ref := range endpoint.DataTypes {
+			e.DataTypes[v1.TypeSlot(slot)] = v1.DataTypeReference{
+				Scheme: ref.Scheme,
+				Format: ref.Format,
+			}
+		}
+	}
+
+	return e, nil
+}
+
+// MigrateToPipe replaces the given KameletBinding with an equivalent Pipe. The KameletBinding is marked as migrated
+// first, so that it stops being reconciled, and removed from the owners of its Integration, so that it can be deleted
+// without deleting the Integration, then the Pipe is created and takes over the running Integration, which is updated
+// in place once the Pipe is initialized. The migration can be resumed if it has been interrupted. It returns the Pipe,
+// and whether it has been created, or it already existed because the KameletBinding had already been migrated.
+func MigrateToPipe(ctx context.Context, c client.Client, binding *v1alpha1.KameletBinding) (*v1.Pipe, bool, error) {
+	pipe, err := ConvertToPipe(binding)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot migrate KameletBinding %q: %w", binding.Name, err)
+	}
+
+	existing := v1.NewPipe(binding.Namespace, binding.Name)
+	if err := c.Get(ctx, ctrl.ObjectKeyFromObject(&existing), &existing); err == nil {
+		if binding.IsMigrated() {
+			if err := releaseIntegration(ctx, c, binding); err != nil {
+				return nil, false, err
+			}
+			return &existing, false, nil
+		}
+		return nil, false, fmt.Errorf("cannot migrate KameletBinding %q: a Pipe with the same name already exists", binding.Name)
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, false, err
+	}
+
+	if !binding.IsMigrated() {
+		if err := setMigratedAnnotation(ctx, c, binding, true); err != nil {
+			return nil, false, fmt.Errorf("cannot mark KameletBinding %q as migrated: %w", binding.Name, err)
+		}
+	}
+	if err := releaseIntegration(ctx, c, binding); err != nil {
+		return nil, false, err
+	}
+
+	if err := c.Create(ctx, pipe); err != nil {
+		// let the KameletBinding manage its Integration again
+		if rollbackErr := setMigratedAnnotation(ctx, c, binding, false); rollbackErr != nil {
+			return nil, false, fmt.Errorf("cannot create Pipe %q, and cannot unmark KameletBinding as migrated (%s): %w", pipe.Name, rollbackErr.Error(), err)
+		}
+		return nil, false, fmt.Errorf("cannot create Pipe %q: %w", pipe.Name, err)
+	}
+
+	return pipe, true, nil
+}
+
+func setMigratedAnnotation(ctx context.Context, c client.Client, binding *v1alpha1.KameletBinding, migrated bool) error {
+	target := binding.DeepCopy()
+	if migrated {
+		v1.SetAnnotation(&target.ObjectMeta, v1alpha1.KameletBindingMigratedAnnotation, "true")
+	} else {
+		delete(target.Annotations, v1alpha1.KameletBindingMigratedAnnotation)
+	}
+	if err := c.Patch(ctx, target, ctrl.MergeFrom(binding)); err != nil {
+		return err
+	}
+	binding.Annotations = target.Annotations
+	return nil
+}
+
+// releaseIntegration removes the KameletBinding from the owners of its Integration, if any, so that deleting the
+// KameletBinding does not delete the Integration before the Pipe has taken it over.
+func releaseIntegration(ctx context.Context, c client.Client, binding *v1alpha1.KameletBinding) error {
+	it := v1.NewIntegration(binding.Namespace, binding.Name)
+	if err := c.Get(ctx, ctrl.ObjectKeyFromObject(&it), &it); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot get the Integration of KameletBinding %q: %w", binding.Name, err)
+	}
+
+	target := it.DeepCopy()
+	target.OwnerReferences = nil
+	for _, ref := range it.OwnerReferences {
+		if ref.Kind == v1alpha1.KameletBindingKind && ref.Name == binding.Name {
+			continue
+		}
+		target.OwnerReferences = append(target.OwnerReferences, ref)
+	}
+	if len(target.OwnerReferences) == len(it.OwnerReferences) {
+		return nil
+	}
+	if err := c.Patch(ctx, target, ctrl.MergeFrom(&it)); err != nil {
+		return fmt.Errorf("cannot release the Integration of KameletBinding %q: %w", binding.Name, err)
+	}
+	return nil
+}
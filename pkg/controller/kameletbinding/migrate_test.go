@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kameletbinding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+func newTestKameletBinding() *v1alpha1.KameletBinding {
+	binding := v1alpha1.NewKameletBinding("ns", "my-binding")
+	binding.Annotations = map[string]string{
+		"my.annotation":                    "value",
+		lastAppliedConfigurationAnnotation: "{}",
+	}
+	binding.Labels = map[string]string{"my.label": "value"}
+	replicas := int32(2)
+	binding.Spec.Replicas = &replicas
+	binding.Spec.Source = v1alpha1.Endpoint{
+		Ref: &corev1.ObjectReference{
+			Kind:       "Kamelet",
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Name:       "timer-source",
+		},
+		Properties: &v1alpha1.EndpointProperties{
+			RawMessage: []byte(`{"message":"Hello"}`),
+		},
+		DataTypes: map[v1alpha1.TypeSlot]v1alpha1.DataTypeReference{
+			v1alpha1.TypeSlotOut: {Format: "text-plain"},
+		},
+	}
+	uri := "log:info"
+	binding.Spec.Sink = v1alpha1.Endpoint{URI: &uri}
+	binding.Spec.ErrorHandler = &v1alpha1.ErrorHandlerSpec{
+		RawMessage: []byte(`{"log":null}`),
+	}
+	return &binding
+}
+
+func TestConvertToPipe(t *testing.T) {
+	pipe, err := ConvertToPipe(newTestKameletBinding())
+	require.NoError(t, err)
+
+	assert.Equal(t, "ns", pipe.Namespace)
+	assert.Equal(t, "my-binding", pipe.Name)
+	assert.Equal(t, map[string]string{"my.annotation": "value"}, pipe.Annotations)
+	assert.Equal(t, map[string]string{"my.label": "value"}, pipe.Labels)
+	assert.Equal(t, int32(2), *pipe.Spec.Replicas)
+	assert.Equal(t, "timer-source", pipe.Spec.Source.Ref.Name)
+	assert.Equal(t, `{"message":"Hello"}`, string(pipe.Spec.Source.Properties.RawMessage))
+	assert.Equal(t, "text-plain", pipe.Spec.Source.DataTypes[v1.TypeSlotOut].Format)
+	assert.Equal(t, "log:info", *pipe.Spec.Sink.URI)
+	assert.Equal(t, `{"log":null}`, string(pipe.Spec.ErrorHandler.RawMessage))
+}
+
+func TestConvertToPipeWithDeprecatedTypes(t *testing.T) {
+	binding := newTestKameletBinding()
+	binding.Spec.Sink.Types = map[v1alpha1.TypeSlot]v1alpha1.EventTypeSpec{
+		v1alpha1.TypeSlotIn: {MediaType: "application/json"},
+	}
+
+	_, err := ConvertToPipe(binding)
+	require.EqualError(t, err, "sink uses the deprecated types field, which cannot be migrated: use dataTypes instead")
+}
+
+func TestMigrateToPipe(t *testing.T) {
+	binding := newTestKameletBinding()
+	c, err := test.NewFakeClient(binding)
+	require.NoError(t, err)
+
+	pipe, created, err := MigrateToPipe(context.TODO(), c, binding)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "my-binding", pipe.Name)
+	assert.True(t, binding.IsMigrated())
+
+	stored := v1alpha1.NewKameletBinding("ns", "my-binding")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&stored), &stored))
+	assert.True(t, stored.IsMigrated())
+	storedPipe := v1.NewPipe("ns", "my-binding")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&storedPipe), &storedPipe))
+	assert.Equal(t, "timer-source", storedPipe.Spec.Source.Ref.Name)
+
+	// migrating again is a no-op
+	pipe, created, err = MigrateToPipe(context.TODO(), c, &stored)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "my-binding", pipe.Name)
+}
+
+func TestMigrateToPipeReleasesIntegration(t *testing.T) {
+	binding := newTestKameletBinding()
+	it := v1.NewIntegration("ns", "my-binding")
+	controller := true
+	it.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.KameletBindingKind,
+			Name:       "my-binding",
+			Controller: &controller,
+		},
+		{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Name:       "my-owner",
+		},
+	}
+	c, err := test.NewFakeClient(binding, &it)
+	require.NoError(t, err)
+
+	_, created, err := MigrateToPipe(context.TODO(), c, binding)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	// The KameletBinding can be deleted before the Pipe has taken the Integration over
+	stored := v1.NewIntegration("ns", "my-binding")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&stored), &stored))
+	require.Len(t, stored.OwnerReferences, 1)
+	assert.Equal(t, "my-owner", stored.OwnerReferences[0].Name)
+}
+
+func TestMigrateToPipeWithExistingPipe(t *testing.T) {
+	binding := newTestKameletBinding()
+	existing := v1.NewPipe("ns", "my-binding")
+	c, err := test.NewFakeClient(binding, &existing)
+	require.NoError(t, err)
+
+	_, _, err = MigrateToPipe(context.TODO(), c, binding)
+	require.EqualError(t, err, `cannot migrate KameletBinding "my-binding": a Pipe with the same name already exists`)
+	assert.False(t, binding.IsMigrated())
+}
@@ -108,3 +108,69 @@ func TestParseErrorHandlerSinkFail(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, "missing endpoint in Error Handler Sink", err.Error())
 }
+
+func TestParseErrorHandlerLogWithRedeliveryDoesSucceed(t *testing.T) {
+	logErrorHandler, err := parseErrorHandler(
+		[]byte(`{
+			"log": {
+				"parameters": {"maximumRedeliveries": 1, "param1": "value1"},
+				"redelivery": {
+					"maximumRedeliveries": 3,
+					"redeliveryDelay": 2000,
+					"backOffMultiplier": 2,
+					"retryWhile": {"expression": "${header.retryable}"},
+					"logExhaustedOnly": true
+				}
+			}
+		}`),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, v1.ErrorHandlerTypeLog, logErrorHandler.Type())
+	parameters, err := logErrorHandler.Configuration()
+	require.NoError(t, err)
+	assert.Equal(t, "value1", parameters["camel.beans.defaultErrorHandler.param1"])
+	assert.Equal(t, 3, parameters["camel.beans.defaultErrorHandler.maximumRedeliveries"])
+	assert.Equal(t, int64(2000), parameters["camel.beans.defaultErrorHandler.redeliveryDelay"])
+	assert.Equal(t, float64(2), parameters["camel.beans.defaultErrorHandler.backOffMultiplier"])
+	assert.Equal(t, true, parameters["camel.beans.defaultErrorHandler.useExponentialBackOff"])
+	assert.Equal(t, "#bean:errorHandlerRetryWhile", parameters["camel.beans.defaultErrorHandler.retryWhile"])
+	assert.Equal(t, "#class:org.apache.camel.model.language.SimpleExpression", parameters["camel.beans.errorHandlerRetryWhile"])
+	assert.Equal(t, "${header.retryable}", parameters["camel.beans.errorHandlerRetryWhile.expression"])
+	assert.Equal(t, false, parameters["camel.beans.defaultErrorHandler.logRetryAttempted"])
+	assert.Equal(t, true, parameters["camel.beans.defaultErrorHandler.logExhausted"])
+}
+
+func TestParseErrorHandlerNoneWithCircuitBreakerDoesSucceed(t *testing.T) {
+	noErrorHandler, err := parseErrorHandler(
+		[]byte(`{"none": {"circuitBreaker": {"failureThreshold": 5, "halfOpenAfter": 10000}}}`),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, v1.ErrorHandlerTypeNone, noErrorHandler.Type())
+	parameters, err := noErrorHandler.Configuration()
+	require.NoError(t, err)
+	assert.Equal(t, "#class:org.apache.camel.builder.NoErrorHandlerBuilder", parameters[v1.ErrorHandlerAppPropertiesPrefix])
+	assert.Equal(t, "#class:org.apache.camel.throttling.ThrottlingExceptionRoutePolicy", parameters["camel.beans.errorHandlerCircuitBreaker"])
+	assert.Equal(t, 5, parameters["camel.beans.errorHandlerCircuitBreaker.threshold"])
+	assert.Equal(t, int64(60000), parameters["camel.beans.errorHandlerCircuitBreaker.failureWindow"])
+	assert.Equal(t, int64(10000), parameters["camel.beans.errorHandlerCircuitBreaker.halfOpenAfter"])
+}
+
+func TestParseErrorHandlerInvalidRedeliveryFail(t *testing.T) {
+	_, err := parseErrorHandler(
+		[]byte(`{"log": {"redelivery": {"backOffMultiplier": 0.5}}}`),
+	)
+	require.Error(t, err)
+	assert.Equal(t, "invalid back-off multiplier 0.5: must be 1 or greater", err.Error())
+
+	_, err = parseErrorHandler(
+		[]byte(`{"sink": {"endpoint": {"uri": "someUri"}, "redelivery": {"retryWhile": {"language": "groovy", "expression": "true"}}}}`),
+	)
+	require.Error(t, err)
+	assert.Equal(t, "unsupported retry while language groovy", err.Error())
+
+	_, err = parseErrorHandler(
+		[]byte(`{"sink": {"endpoint": {"uri": "someUri"}, "circuitBreaker": {"failureThreshold": 0}}}`),
+	)
+	require.Error(t, err)
+	assert.Equal(t, "invalid circuit breaker failure threshold 0: must be 1 or greater", err.Error())
+}
@@ -19,13 +19,14 @@ package pipe
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 
 	"github.com/apache/camel-k/v2/pkg/kamelet/repository"
-	"github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/util/bindings"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
 	"github.com/apache/camel-k/v2/pkg/util/patch"
 
@@ -48,7 +49,8 @@ func (action *initializeAction) Name() string {
 }
 
 func (action *initializeAction) CanHandle(binding *v1.Pipe) bool {
-	return binding.Status.Phase == v1.PipePhaseNone
+	return binding.Status.Phase == v1.PipePhaseNone || waitsForKamelets(binding) || hasInvalidProperties(binding) ||
+		hasIncompatibleDataTypes(binding)
 }
 
 func (action *initializeAction) Handle(ctx context.Context, binding *v1.Pipe) (*v1.Pipe, error) {
@@ -63,7 +65,47 @@ func (action *initializeAction) Handle(ctx context.Context, binding *v1.Pipe) (*
 			".spec.integration parameter is deprecated. Use annotation traits instead",
 		)
 	}
-	it, err := CreateIntegrationFor(ctx, action.client, binding)
+	// The Kamelets are looked up from the same repository during the whole initialization
+	repo, err := kameletRepository(ctx, action.client, binding)
+	if err != nil {
+		return nil, err
+	}
+	notReady, err := notReadyKamelets(ctx, repo, binding)
+	if err != nil {
+		return nil, err
+	}
+	if notReady != "" {
+		target := binding.DeepCopy()
+		target.Status.Phase = v1.PipePhaseError
+		target.Status.SetErrorCondition(v1.PipeIntegrationConditionError, v1.PipeKameletsNotReadyReason, errors.New(notReady))
+		// The Pipe is initialized again when the Kamelets become ready
+		return target, nil
+	}
+	invalid, err := validateProperties(ctx, repo, binding)
+	if err != nil {
+		return nil, err
+	}
+	if invalid != "" {
+		target := binding.DeepCopy()
+		target.Status.Phase = v1.PipePhaseError
+		target.Status.SetErrorCondition(v1.PipeIntegrationConditionError, v1.PipeInvalidPropertiesReason, errors.New(invalid))
+		// The Pipe is initialized again when its properties are updated
+		return target, nil
+	}
+	dataTypes, err := bindings.AnalyzeDataTypes(ctx, repo, binding)
+	if err != nil {
+		return nil, err
+	}
+	if incompatible := checkDataTypes(binding, dataTypes); incompatible != "" {
+		target := binding.DeepCopy()
+		target.Status.Phase = v1.PipePhaseError
+		target.Status.SetErrorCondition(v1.PipeIntegrationConditionError, v1.PipeIncompatibleDataTypesReason, errors.New(incompatible))
+		// The Pipe is initialized again when its endpoints are updated
+		return target, nil
+	}
+	binding.Status.RemoveCondition(v1.PipeIntegrationConditionError)
+
+	it, err := CreateIntegrationFor(ctx, action.client, binding, dataTypes.Conversions)
 	if err != nil {
 		binding.Status.Phase = v1.PipePhaseError
 		binding.Status.SetErrorCondition(v1.PipeIntegrationConditionError,
@@ -76,15 +118,15 @@ func (action *initializeAction) Handle(ctx context.Context, binding *v1.Pipe) (*
 	}
 
 	// propagate Kamelet icon (best effort)
-	action.propagateIcon(ctx, binding)
+	action.propagateIcon(ctx, repo, binding)
 
 	target := binding.DeepCopy()
 	target.Status.Phase = v1.PipePhaseCreating
 	return target, nil
 }
 
-func (action *initializeAction) propagateIcon(ctx context.Context, binding *v1.Pipe) {
-	icon, err := action.findIcon(ctx, binding)
+func (action *initializeAction) propagateIcon(ctx context.Context, repo repository.KameletRepository, binding *v1.Pipe) {
+	icon, err := action.findIcon(ctx, repo, binding)
 	if err != nil {
 		action.L.Errorf(err, "cannot find icon for Pipe %q", binding.Name)
 		return
@@ -114,7 +156,7 @@ func (action *initializeAction) propagateIcon(ctx context.Context, binding *v1.P
 	}
 }
 
-func (action *initializeAction) findIcon(ctx context.Context, binding *v1.Pipe) (string, error) {
+func (action *initializeAction) findIcon(ctx context.Context, repo repository.KameletRepository, binding *v1.Pipe) (string, error) {
 	var kameletRef *corev1.ObjectReference
 	if binding.Spec.Source.Ref != nil && binding.Spec.Source.Ref.Kind == "Kamelet" && strings.HasPrefix(binding.Spec.Source.Ref.APIVersion, "camel.apache.org/") {
 		kameletRef = binding.Spec.Source.Ref
@@ -126,11 +168,6 @@ func (action *initializeAction) findIcon(ctx context.Context, binding *v1.Pipe)
 		return "", nil
 	}
 
-	repo, err := repository.New(ctx, action.client, binding.Namespace, platform.GetOperatorNamespace())
-	if err != nil {
-		return "", err
-	}
-
 	kamelet, err := repo.Get(ctx, kameletRef.Name)
 	if err != nil {
 		return "", err
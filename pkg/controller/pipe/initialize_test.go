@@ -0,0 +1,232 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+func TestInitializePipeWithKameletNotReady(t *testing.T) {
+	source := v1.NewKamelet("default", "my-source")
+	source.Status.Phase = v1.KameletPhaseError
+	source.Status.SetCondition(v1.KameletConditionReady, corev1.ConditionFalse,
+		v1.KameletConditionReasonInvalidSchema, `invalid definition: required property "period" is not declared`)
+	sink := v1.NewKamelet("default", "my-sink")
+	sink.Status.Phase = v1.KameletPhaseReady
+
+	pipe := nominalPipe("my-pipe")
+	pipe.Status.Phase = v1.PipePhaseNone
+	c, err := test.NewFakeClient(&source, &sink, &pipe)
+	require.NoError(t, err)
+
+	a := NewInitializeAction()
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+	require.True(t, a.CanHandle(&pipe))
+	target, err := a.Handle(context.TODO(), &pipe)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.PipePhaseError, target.Status.Phase)
+	condition := target.Status.GetCondition(v1.PipeIntegrationConditionError)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1.PipeKameletsNotReadyReason, condition.Reason)
+	assert.Equal(t, `kamelets [my-source (invalid definition: required property "period" is not declared)] are not ready`, condition.Message)
+
+	// The Pipe is initialized again once the Kamelet is fixed
+	assert.True(t, a.CanHandle(target))
+	requests := kameletEnqueueRequestsFromMapFunc(context.TODO(), c, &sink)
+	assert.Empty(t, requests)
+
+	source.Status.Phase = v1.KameletPhaseReady
+	c, err = test.NewFakeClient(&source, &sink, target)
+	require.NoError(t, err)
+	requests = kameletEnqueueRequestsFromMapFunc(context.TODO(), c, &source)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "my-pipe", requests[0].Name)
+}
+
+func TestInitializePipeWithInvalidProperties(t *testing.T) {
+	source := v1.NewKamelet("default", "my-source")
+	source.Spec.Definition = &v1.JSONSchemaProps{
+		Required: []string{"message"},
+		Properties: map[string]v1.JSONSchemaProp{
+			"message": {Type: "string"},
+			"period":  {Type: "integer"},
+		},
+	}
+	source.Status.Phase = v1.KameletPhaseReady
+	sink := v1.NewKamelet("default", "my-sink")
+	sink.Status.Phase = v1.KameletPhaseReady
+
+	pipe := nominalPipe("my-pipe")
+	pipe.Status.Phase = v1.PipePhaseNone
+	pipe.Spec.Source.Properties = &v1.EndpointProperties{RawMessage: []byte(`{"mesage":"hello","period":"1s"}`)}
+	c, err := test.NewFakeClient(&source, &sink, &pipe)
+	require.NoError(t, err)
+
+	a := NewInitializeAction()
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+	require.True(t, a.CanHandle(&pipe))
+	target, err := a.Handle(context.TODO(), &pipe)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.PipePhaseError, target.Status.Phase)
+	condition := target.Status.GetCondition(v1.PipeIntegrationConditionError)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1.PipeInvalidPropertiesReason, condition.Reason)
+	assert.Equal(t, `invalid properties: source property "mesage" is not declared by Kamelet my-source, `+
+		`source property "message" is required, source property "period" value "1s" is not an integer`, condition.Message)
+	condition = target.Status.GetCondition(v1.PipeConditionPropertyValidPrefix + "source.period")
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, "InvalidType", condition.Reason)
+	assert.Equal(t, `value "1s" is not an integer`, condition.Message)
+
+	// The Pipe is initialized again once its properties are fixed
+	require.True(t, a.CanHandle(target))
+	target.Spec.Source.Properties = &v1.EndpointProperties{RawMessage: []byte(`{"message":"hello","period":"1000"}`)}
+	target, err = a.Handle(context.TODO(), target)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.PipePhaseCreating, target.Status.Phase)
+	assert.Nil(t, target.Status.GetCondition(v1.PipeIntegrationConditionError))
+	assert.Nil(t, target.Status.GetCondition(v1.PipeConditionPropertyValidPrefix+"source.period"))
+}
+
+func TestInitializePipeWithIncompatibleDataTypes(t *testing.T) {
+	source := v1.NewKamelet("default", "my-source")
+	source.Spec.DataTypes = map[v1.TypeSlot]v1.DataTypesSpec{
+		v1.TypeSlotOut: {
+			Default: "binary",
+			Types: map[string]v1.DataTypeSpec{
+				"binary": {Format: "application-octet-stream", MediaType: "application/octet-stream"},
+			},
+		},
+	}
+	source.Status.Phase = v1.KameletPhaseReady
+	sink := v1.NewKamelet("default", "my-sink")
+	sink.Spec.DataTypes = map[v1.TypeSlot]v1.DataTypesSpec{
+		v1.TypeSlotIn: {
+			Default: "json",
+			Types: map[string]v1.DataTypeSpec{
+				"json": {Format: "application-json", MediaType: "application/json"},
+			},
+		},
+	}
+	sink.Status.Phase = v1.KameletPhaseReady
+
+	pipe := nominalPipe("my-pipe")
+	pipe.Status.Phase = v1.PipePhaseNone
+	c, err := test.NewFakeClient(&source, &sink, &pipe)
+	require.NoError(t, err)
+
+	a := NewInitializeAction()
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+	require.True(t, a.CanHandle(&pipe))
+	target, err := a.Handle(context.TODO(), &pipe)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.PipePhaseError, target.Status.Phase)
+	condition := target.Status.GetCondition(v1.PipeConditionDataTypesCompatible)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.PipeIncompatibleDataTypesReason, condition.Reason)
+	assert.Equal(t, "incompatible data types: source -> sink: data type camel:application-octet-stream (application/octet-stream) "+
+		"cannot be converted to any of the data types accepted by Kamelet my-sink [camel:application-json (application/json)]", condition.Message)
+	condition = target.Status.GetCondition(v1.PipeIntegrationConditionError)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1.PipeIncompatibleDataTypesReason, condition.Reason)
+
+	// The Pipe is initialized again once the sink data type is selected
+	require.True(t, a.CanHandle(target))
+	target.Spec.Sink.DataTypes = map[v1.TypeSlot]v1.DataTypeReference{
+		v1.TypeSlotIn: {Format: "application-json"},
+	}
+	target, err = a.Handle(context.TODO(), target)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.PipePhaseCreating, target.Status.Phase)
+	assert.Nil(t, target.Status.GetCondition(v1.PipeConditionDataTypesCompatible))
+}
+
+func TestInitializePipeWithDataTypeConversions(t *testing.T) {
+	source := v1.NewKamelet("default", "my-source")
+	source.Spec.DataTypes = map[v1.TypeSlot]v1.DataTypesSpec{
+		v1.TypeSlotOut: {
+			Default: "cloudevents",
+			Types: map[string]v1.DataTypeSpec{
+				"cloudevents": {Format: "application-cloudevents", MediaType: "application/cloudevents+json"},
+			},
+		},
+	}
+	source.Status.Phase = v1.KameletPhaseReady
+	sink := v1.NewKamelet("default", "my-sink")
+	sink.Spec.DataTypes = map[v1.TypeSlot]v1.DataTypesSpec{
+		v1.TypeSlotIn: {
+			Default: "binary",
+			Types: map[string]v1.DataTypeSpec{
+				"binary": {Format: "application-octet-stream", MediaType: "application/octet-stream"},
+			},
+		},
+	}
+	sink.Status.Phase = v1.KameletPhaseReady
+
+	pipe := nominalPipe("my-pipe")
+	pipe.Status.Phase = v1.PipePhaseNone
+	c, err := test.NewFakeClient(&source, &sink, &pipe)
+	require.NoError(t, err)
+
+	a := NewInitializeAction()
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+	target, err := a.Handle(context.TODO(), &pipe)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.PipePhaseCreating, target.Status.Phase)
+	condition := target.Status.GetCondition(v1.PipeConditionDataTypesCompatible)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1.PipeDataTypesConvertedReason, condition.Reason)
+	it := v1.NewIntegration("default", "my-pipe")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&it), &it))
+	assert.Equal(t, "sink=camel:application-octet-stream", it.Annotations[dataTypeConversionsAnnotation])
+
+	// The monitor reuses the conversions the Integration has been created with, without looking up the Kamelets
+	require.NoError(t, c.Delete(context.TODO(), &source))
+	require.NoError(t, c.Delete(context.TODO(), &sink))
+	m := NewMonitorAction()
+	m.InjectLogger(log.Log)
+	m.InjectClient(c)
+	require.True(t, m.CanHandle(target))
+	target, err = m.Handle(context.TODO(), target)
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, v1.PipePhaseCreating, target.Status.Phase)
+}
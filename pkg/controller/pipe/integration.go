@@ -42,13 +42,25 @@ var (
 	endpointTypeSinkContext   = bindings.EndpointContext{Type: v1.EndpointTypeSink}
 )
 
-// CreateIntegrationFor creates and Integration from a Pipe.
-func CreateIntegrationFor(ctx context.Context, c client.Client, binding *v1.Pipe) (*v1.Integration, error) {
+// dataTypeConversionsAnnotation records the data type conversions the Integration has been created with,
+// so that the Pipe can be compared with its Integration without looking up the referenced Kamelets.
+const dataTypeConversionsAnnotation = "camel.apache.org/pipe.data-type-conversions"
+
+// CreateIntegrationFor creates and Integration from a Pipe, adding the transformers needed by the given
+// data type conversions, as returned by AnalyzeDataTypes.
+func CreateIntegrationFor(ctx context.Context, c client.Client, binding *v1.Pipe, conversions []bindings.DataTypeConversion) (*v1.Integration, error) {
 	controller := true
 	blockOwnerDeletion := true
 	annotations := util.CopyMap(binding.Annotations)
 	// avoid propagating the icon to the integration as it's heavyweight and not needed
 	delete(annotations, v1.AnnotationIcon)
+	delete(annotations, dataTypeConversionsAnnotation)
+	if len(conversions) > 0 {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[dataTypeConversionsAnnotation] = bindings.FormatDataTypeConversions(conversions)
+	}
 
 	it := v1.Integration{
 		ObjectMeta: metav1.ObjectMeta{
@@ -106,13 +118,27 @@ func CreateIntegrationFor(ctx context.Context, c client.Client, binding *v1.Pipe
 		Metadata:  it.Annotations,
 	}
 
+	// add the transformers needed for the data to flow through the endpoints
+	if len(conversions) > 0 {
+		binding = binding.DeepCopy()
+		dataTypes := bindings.DataTypesAnalysis{Conversions: conversions}
+		dataTypes.Apply(binding)
+	}
+
 	from, err := bindings.Translate(bindingContext, endpointTypeSourceContext, binding.Spec.Source)
 	if err != nil {
 		return nil, fmt.Errorf("could not determine source URI: %w", err)
 	}
-	to, err := bindings.Translate(bindingContext, endpointTypeSinkContext, binding.Spec.Sink)
-	if err != nil {
-		return nil, fmt.Errorf("could not determine sink URI: %w", err)
+	// the sink is optional when the data is routed to branches
+	var to *bindings.Binding
+	if binding.Spec.Routing == nil || !binding.Spec.Sink.IsEmpty() {
+		to, err = bindings.Translate(bindingContext, endpointTypeSinkContext, binding.Spec.Sink)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine sink URI: %w", err)
+		}
+		if to.Step == nil && to.URI == "" {
+			return nil, fmt.Errorf("illegal step definition for sink step: either Step or URI should be provided")
+		}
 	}
 	// error handler is optional
 	errorHandler, err := maybeErrorHandler(binding.Spec.ErrorHandler, bindingContext)
@@ -133,9 +159,6 @@ func CreateIntegrationFor(ctx context.Context, c client.Client, binding *v1.Pipe
 		steps = append(steps, stepBinding)
 	}
 
-	if to.Step == nil && to.URI == "" {
-		return nil, fmt.Errorf("illegal step definition for sink step: either Step or URI should be provided")
-	}
 	if from.URI == "" {
 		return nil, fmt.Errorf("illegal step definition for source step: URI should be provided")
 	}
@@ -161,6 +184,13 @@ func CreateIntegrationFor(ctx context.Context, c client.Client, binding *v1.Pipe
 		return nil, err
 	}
 
+	var routing map[string]interface{}
+	if binding.Spec.Routing != nil {
+		if routing, err = translateRouting(bindingContext, &it, binding.Spec.Routing, to); err != nil {
+			return nil, err
+		}
+	}
+
 	if it.Spec.Configuration != nil {
 		sort.SliceStable(it.Spec.Configuration, func(i, j int) bool {
 			mi, mj := it.Spec.Configuration[i], it.Spec.Configuration[j]
@@ -183,23 +213,28 @@ func CreateIntegrationFor(ctx context.Context, c client.Client, binding *v1.Pipe
 		dslSteps = append(dslSteps, step.AsYamlDSL())
 	}
 
-	if to.Step != nil {
-		dslSteps = append(dslSteps, to.AsYamlDSL())
+	if routing != nil {
+		dslSteps = append(dslSteps, routing)
+	} else {
+		dslSteps = append(dslSteps, sinkSteps(to)...)
 	}
-	dslSteps = append(dslSteps, map[string]interface{}{
-		"to": to.URI,
-	})
 
 	fromWrapper := map[string]interface{}{
 		"uri":   from.URI,
 		"steps": dslSteps,
 	}
 
+	route := map[string]interface{}{
+		"id":   "binding",
+		"from": fromWrapper,
+	}
+	// the circuit breaker declared by the error handler is applied as a route policy
+	if it.Spec.GetConfigurationProperty(v1.ErrorHandlerCircuitBreakerAppPropertiesPrefix) != "" {
+		route["routePolicy"] = v1.ErrorHandlerCircuitBreakerRefName
+	}
+
 	flowRoute := map[string]interface{}{
-		"route": map[string]interface{}{
-			"id":   "binding",
-			"from": fromWrapper,
-		},
+		"route": route,
 	}
 	encodedRoute, err := json.Marshal(flowRoute)
 	if err != nil {
@@ -210,6 +245,118 @@ func CreateIntegrationFor(ctx context.Context, c client.Client, binding *v1.Pipe
 	return &it, nil
 }
 
+// sinkSteps returns the steps sending the data to the given sink.
+func sinkSteps(sink *bindings.Binding) []map[string]interface{} {
+	steps := make([]map[string]interface{}, 0, 2)
+	if sink.Step != nil {
+		steps = append(steps, sink.AsYamlDSL())
+	}
+	return append(steps, map[string]interface{}{
+		"to": sink.URI,
+	})
+}
+
+// translateRouting returns the multicast or choice step routing the data to the branches, and to the sink, if any.
+func translateRouting(bindingContext bindings.BindingContext, it *v1.Integration, routing *v1.PipeRouting, sink *bindings.Binding) (map[string]interface{}, error) {
+	if len(routing.Branches) == 0 {
+		return nil, fmt.Errorf("no branches specified for %s routing", routing.Type)
+	}
+
+	branches := make([][]map[string]interface{}, 0, len(routing.Branches))
+	for b, branch := range routing.Branches {
+		if err := bindings.ValidateBranch(routing.Type, branch); err != nil {
+			return nil, fmt.Errorf("invalid branch %d: %w", b, err)
+		}
+		branchIdx := b
+		steps := make([]map[string]interface{}, 0, len(branch.Steps)+2)
+		for idx, step := range branch.Steps {
+			position := idx
+			stepBinding, err := bindings.Translate(bindingContext, bindings.EndpointContext{
+				Type:     v1.EndpointTypeAction,
+				Position: &position,
+				Branch:   &branchIdx,
+			}, step)
+			if err != nil {
+				return nil, fmt.Errorf("could not determine URI for step %d of branch %d: %w", idx, b, err)
+			}
+			if stepBinding.Step == nil && stepBinding.URI == "" {
+				return nil, fmt.Errorf("illegal step definition for step %d of branch %d: either Step or URI should be provided", idx, b)
+			}
+			if err := configureBinding(it, stepBinding); err != nil {
+				return nil, err
+			}
+			steps = append(steps, stepBinding.AsYamlDSL())
+		}
+		to, err := bindings.Translate(bindingContext, bindings.EndpointContext{
+			Type:   v1.EndpointTypeSink,
+			Branch: &branchIdx,
+		}, branch.Sink)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine sink URI of branch %d: %w", b, err)
+		}
+		if to.Step == nil && to.URI == "" {
+			return nil, fmt.Errorf("illegal step definition for sink step of branch %d: either Step or URI should be provided", b)
+		}
+		if err := configureBinding(it, to); err != nil {
+			return nil, err
+		}
+		branches = append(branches, append(steps, sinkSteps(to)...))
+	}
+
+	switch routing.Type {
+	case v1.PipeRoutingTypeMulticast:
+		outputs := make([]map[string]interface{}, 0, len(branches)+1)
+		for b, steps := range branches {
+			outputs = append(outputs, map[string]interface{}{
+				"pipeline": map[string]interface{}{
+					"id":    fmt.Sprintf("branch-%d", b),
+					"steps": steps,
+				},
+			})
+		}
+		if sink != nil {
+			outputs = append(outputs, map[string]interface{}{
+				"pipeline": map[string]interface{}{
+					"steps": sinkSteps(sink),
+				},
+			})
+		}
+		multicast := map[string]interface{}{
+			"steps": outputs,
+		}
+		if routing.ParallelProcessing {
+			multicast["parallelProcessing"] = true
+		}
+		return map[string]interface{}{
+			"multicast": multicast,
+		}, nil
+	case v1.PipeRoutingTypeChoice:
+		when := make([]map[string]interface{}, 0, len(branches))
+		for b, steps := range branches {
+			language := routing.Branches[b].Condition.Language
+			if language == "" {
+				language = v1.PipePredicateLanguageSimple
+			}
+			when = append(when, map[string]interface{}{
+				string(language): routing.Branches[b].Condition.Expression,
+				"steps":          steps,
+			})
+		}
+		choice := map[string]interface{}{
+			"when": when,
+		}
+		if sink != nil {
+			choice["otherwise"] = map[string]interface{}{
+				"steps": sinkSteps(sink),
+			}
+		}
+		return map[string]interface{}{
+			"choice": choice,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported routing type %q", routing.Type)
+}
+
 func configureBinding(integration *v1.Integration, bindings ...*bindings.Binding) error {
 	for _, b := range bindings {
 		if b == nil {
@@ -34,7 +34,7 @@ func TestCreateIntegrationForPipe(t *testing.T) {
 	require.NoError(t, err)
 
 	pipe := nominalPipe("my-pipe")
-	it, err := CreateIntegrationFor(context.TODO(), client, &pipe)
+	it, err := CreateIntegrationFor(context.TODO(), client, &pipe, nil)
 	require.NoError(t, err)
 	assert.Equal(t, "my-pipe", it.Name)
 	assert.Equal(t, "default", it.Namespace)
@@ -64,7 +64,7 @@ func TestCreateIntegrationForPipeDataType(t *testing.T) {
 			Format: "string",
 		},
 	}
-	it, err := CreateIntegrationFor(context.TODO(), client, &pipe)
+	it, err := CreateIntegrationFor(context.TODO(), client, &pipe, nil)
 	require.NoError(t, err)
 	dsl, err := dsl.ToYamlDSL(it.Spec.Flows)
 	require.NoError(t, err)
@@ -83,13 +83,225 @@ func TestCreateIntegrationForPipeDataTypeOverridden(t *testing.T) {
 	}
 	newDataTypeKameletAction := "data-type-action-v4-2"
 	pipe.Annotations[v1.KameletDataTypeLabel] = newDataTypeKameletAction
-	it, err := CreateIntegrationFor(context.TODO(), client, &pipe)
+	it, err := CreateIntegrationFor(context.TODO(), client, &pipe, nil)
 	require.NoError(t, err)
 	dsl, err := dsl.ToYamlDSL(it.Spec.Flows)
 	require.NoError(t, err)
 	assert.Equal(t, expectedNominalRouteWithDataType(newDataTypeKameletAction), string(dsl))
 }
 
+func TestCreateIntegrationForPipeDataTypeConverted(t *testing.T) {
+	source := v1.NewKamelet("default", "my-source")
+	source.Spec.DataTypes = map[v1.TypeSlot]v1.DataTypesSpec{
+		v1.TypeSlotOut: {
+			Default: "cloudevents",
+			Types: map[string]v1.DataTypeSpec{
+				"cloudevents": {Format: "application-cloudevents", MediaType: "application/cloudevents+json"},
+			},
+		},
+	}
+	sink := v1.NewKamelet("default", "my-sink")
+	sink.Spec.DataTypes = map[v1.TypeSlot]v1.DataTypesSpec{
+		v1.TypeSlotIn: {
+			Default: "binary",
+			Types: map[string]v1.DataTypeSpec{
+				"binary": {Format: "application-octet-stream", MediaType: "application/octet-stream"},
+			},
+		},
+	}
+	client, err := test.NewFakeClient(&source, &sink)
+	require.NoError(t, err)
+
+	pipe := nominalPipe("my-pipe-data-type")
+	dataTypes, err := AnalyzeDataTypes(context.TODO(), client, &pipe)
+	require.NoError(t, err)
+	it, err := CreateIntegrationFor(context.TODO(), client, &pipe, dataTypes.Conversions)
+	require.NoError(t, err)
+	dsl, err := dsl.ToYamlDSL(it.Spec.Flows)
+	require.NoError(t, err)
+	assert.Equal(t, expectedNominalRouteWithDataType("data-type-action"), string(dsl))
+	assert.Equal(t, "sink=camel:application-octet-stream", it.Annotations[dataTypeConversionsAnnotation])
+	assert.Contains(t, it.Spec.Configuration, v1.ConfigurationSpec{
+		Type:  "property",
+		Value: "camel.kamelet.data-type-action.sink-in.format = application-octet-stream",
+	})
+	// The Pipe itself is left untouched
+	assert.Nil(t, pipe.Spec.Sink.DataTypes)
+}
+
+func TestCreateIntegrationForPipeMulticast(t *testing.T) {
+	client, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	pipe := nominalPipe("my-pipe-multicast")
+	audit := "log:audit"
+	pipe.Spec.Routing = &v1.PipeRouting{
+		Type:               v1.PipeRoutingTypeMulticast,
+		ParallelProcessing: true,
+		Branches: []v1.PipeBranch{
+			{
+				Steps: []v1.Endpoint{
+					{
+						Ref: &corev1.ObjectReference{
+							Kind:       "Kamelet",
+							Name:       "my-action",
+							APIVersion: "camel.apache.org/v1",
+						},
+					},
+				},
+				Sink: v1.Endpoint{
+					Ref: &corev1.ObjectReference{
+						Kind:       "Kamelet",
+						Name:       "my-other-sink",
+						APIVersion: "camel.apache.org/v1",
+					},
+					Properties: &v1.EndpointProperties{RawMessage: []byte(`{"topic":"orders"}`)},
+				},
+			},
+			{
+				Sink: v1.Endpoint{URI: &audit},
+			},
+		},
+	}
+	it, err := CreateIntegrationFor(context.TODO(), client, &pipe, nil)
+	require.NoError(t, err)
+	dsl, err := dsl.ToYamlDSL(it.Spec.Flows)
+	require.NoError(t, err)
+	assert.Equal(t, `- route:
+    from:
+      steps:
+      - multicast:
+          parallelProcessing: true
+          steps:
+          - pipeline:
+              id: branch-0
+              steps:
+              - kamelet:
+                  name: my-action/branch-0-action-0
+              - to: kamelet:my-other-sink/branch-0-sink
+          - pipeline:
+              id: branch-1
+              steps:
+              - to: log:audit
+          - pipeline:
+              steps:
+              - to: kamelet:my-sink/sink
+      uri: kamelet:my-source/source
+    id: binding
+`, string(dsl))
+	assert.Contains(t, it.Spec.Configuration, v1.ConfigurationSpec{
+		Type:  "property",
+		Value: "camel.kamelet.my-other-sink.branch-0-sink.topic = orders",
+	})
+}
+
+func TestCreateIntegrationForPipeChoice(t *testing.T) {
+	client, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	pipe := nominalPipe("my-pipe-choice")
+	pipe.Spec.Sink = v1.Endpoint{}
+	orders := "log:orders"
+	pipe.Spec.Routing = &v1.PipeRouting{
+		Type: v1.PipeRoutingTypeChoice,
+		Branches: []v1.PipeBranch{
+			{
+				Condition: &v1.PipePredicate{Expression: "${header.type} == 'order'"},
+				Sink:      v1.Endpoint{URI: &orders},
+			},
+			{
+				Condition: &v1.PipePredicate{Language: v1.PipePredicateLanguageJSONPath, Expression: "$[?(@.priority > 5)]"},
+				Sink: v1.Endpoint{
+					Ref: &corev1.ObjectReference{
+						Kind:       "Kamelet",
+						Name:       "my-urgent-sink",
+						APIVersion: "camel.apache.org/v1",
+					},
+				},
+			},
+		},
+	}
+	it, err := CreateIntegrationFor(context.TODO(), client, &pipe, nil)
+	require.NoError(t, err)
+	dsl, err := dsl.ToYamlDSL(it.Spec.Flows)
+	require.NoError(t, err)
+	assert.Equal(t, `- route:
+    from:
+      steps:
+      - choice:
+          when:
+          - simple: ${header.type} == 'order'
+            steps:
+            - to: log:orders
+          - jsonpath: $[?(@.priority > 5)]
+            steps:
+            - to: kamelet:my-urgent-sink/branch-1-sink
+      uri: kamelet:my-source/source
+    id: binding
+`, string(dsl))
+
+	pipe.Spec.Routing.Branches[0].Condition = nil
+	_, err = CreateIntegrationFor(context.TODO(), client, &pipe, nil)
+	require.Error(t, err)
+	assert.Equal(t, "invalid branch 0: a condition is required by the Choice routing", err.Error())
+}
+
+func TestCreateIntegrationForPipeInlineSteps(t *testing.T) {
+	client, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	pipe := nominalPipe("my-pipe-inline")
+	pipe.Spec.Steps = []v1.Endpoint{
+		{
+			Inline: &v1.Flow{RawMessage: []byte(`{"setHeader": {"name": "type", "constant": "order"}}`)},
+		},
+		{
+			Inline: &v1.Flow{RawMessage: []byte(`{"marshal": {"json": {"library": "Jackson"}}}`)},
+		},
+	}
+	it, err := CreateIntegrationFor(context.TODO(), client, &pipe, nil)
+	require.NoError(t, err)
+	dsl, err := dsl.ToYamlDSL(it.Spec.Flows)
+	require.NoError(t, err)
+	assert.Equal(t, `- route:
+    from:
+      steps:
+      - setHeader:
+          constant: order
+          name: type
+      - marshal:
+          json:
+            library: Jackson
+      - to: kamelet:my-sink/sink
+      uri: kamelet:my-source/source
+    id: binding
+`, string(dsl))
+}
+
+func TestCreateIntegrationForPipeCircuitBreaker(t *testing.T) {
+	client, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	pipe := nominalPipe("my-pipe-circuit-breaker")
+	pipe.Spec.ErrorHandler = &v1.ErrorHandlerSpec{
+		RawMessage: []byte(`{"log": {"circuitBreaker": {"failureThreshold": 5}, "redelivery": {"maximumRedeliveries": 3}}}`),
+	}
+	it, err := CreateIntegrationFor(context.TODO(), client, &pipe, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "5", it.Spec.GetConfigurationProperty("camel.beans.errorHandlerCircuitBreaker.threshold"))
+	assert.Equal(t, "3", it.Spec.GetConfigurationProperty("camel.beans.defaultErrorHandler.maximumRedeliveries"))
+	dsl, err := dsl.ToYamlDSL(it.Spec.Flows)
+	require.NoError(t, err)
+	assert.Equal(t, `- route:
+    from:
+      steps:
+      - to: kamelet:my-sink/sink
+      uri: kamelet:my-source/source
+    id: binding
+    routePolicy: errorHandlerCircuitBreaker
+`, string(dsl))
+}
+
 func nominalPipe(name string) v1.Pipe {
 	pipe := v1.NewPipe("default", name)
 	pipe.Annotations = map[string]string{
@@ -0,0 +1,196 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/kamelet/repository"
+	"github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/util/bindings"
+	"github.com/apache/camel-k/v2/pkg/util/kamelets"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// kameletRefs returns the names of the Kamelets referenced by the Pipe endpoints.
+func kameletRefs(pipe *v1.Pipe) []string {
+	endpoints := make([]v1.Endpoint, 0, len(pipe.Spec.Steps)+2)
+	endpoints = append(endpoints, pipe.Spec.Source, pipe.Spec.Sink)
+	endpoints = append(endpoints, pipe.Spec.Steps...)
+	if pipe.Spec.Routing != nil {
+		for _, branch := range pipe.Spec.Routing.Branches {
+			endpoints = append(endpoints, branch.Steps...)
+			endpoints = append(endpoints, branch.Sink)
+		}
+	}
+
+	refs := make([]string, 0, len(endpoints))
+	for _, e := range endpoints {
+		if e.Ref != nil && e.Ref.Kind == v1.KameletKind && strings.HasPrefix(e.Ref.APIVersion, v1.SchemeGroupVersion.Group+"/") {
+			refs = append(refs, e.Ref.Name)
+		}
+	}
+
+	return refs
+}
+
+func referencesKamelet(pipe *v1.Pipe, name string) bool {
+	for _, ref := range kameletRefs(pipe) {
+		if ref == name {
+			return true
+		}
+	}
+	return false
+}
+
+// waitsForKamelets returns true if the Pipe initialization failed because of Kamelets that are not ready.
+func waitsForKamelets(pipe *v1.Pipe) bool {
+	if pipe.Status.Phase != v1.PipePhaseError {
+		return false
+	}
+	condition := pipe.Status.GetCondition(v1.PipeIntegrationConditionError)
+	return condition != nil && condition.Reason == v1.PipeKameletsNotReadyReason
+}
+
+// kameletRepository returns the repository the Kamelets referenced by the Pipe are looked up from.
+func kameletRepository(ctx context.Context, c client.Client, pipe *v1.Pipe) (repository.KameletRepository, error) {
+	return repository.New(ctx, c, pipe.Namespace, platform.GetOperatorNamespace())
+}
+
+// notReadyKamelets returns a message reporting the Kamelets referenced by the Pipe that have been found invalid
+// by the Kamelet controller, if any. The Kamelets that cannot be found are reported later on by the Integration.
+func notReadyKamelets(ctx context.Context, repo repository.KameletRepository, pipe *v1.Pipe) (string, error) {
+	refs := kameletRefs(pipe)
+	if len(refs) == 0 {
+		return "", nil
+	}
+
+	referenced := make(map[string]*v1.Kamelet, len(refs))
+	for _, ref := range refs {
+		kamelet, err := repo.Get(ctx, ref)
+		if err != nil {
+			return "", err
+		}
+		referenced[ref] = kamelet
+	}
+
+	return kamelets.NotReadyMessage(referenced), nil
+}
+
+// hasInvalidProperties returns true if the Pipe initialization failed because of endpoint properties
+// that do not match the Kamelet definitions.
+func hasInvalidProperties(pipe *v1.Pipe) bool {
+	if pipe.Status.Phase != v1.PipePhaseError {
+		return false
+	}
+	condition := pipe.Status.GetCondition(v1.PipeIntegrationConditionError)
+	return condition != nil && condition.Reason == v1.PipeInvalidPropertiesReason
+}
+
+// validateProperties checks the properties of the Pipe endpoints against the definitions of the referenced Kamelets,
+// and reports each violation with a dedicated condition. It returns a message summarizing the violations, if any.
+func validateProperties(ctx context.Context, repo repository.KameletRepository, pipe *v1.Pipe) (string, error) {
+	// Reset the conditions of the previous validation
+	previous := make([]v1.PipeConditionType, 0)
+	for _, condition := range pipe.Status.Conditions {
+		if strings.HasPrefix(string(condition.Type), v1.PipeConditionPropertyValidPrefix) {
+			previous = append(previous, condition.Type)
+		}
+	}
+	for _, condType := range previous {
+		pipe.Status.RemoveCondition(condType)
+	}
+	if len(kameletRefs(pipe)) == 0 {
+		return "", nil
+	}
+
+	violations, err := bindings.ValidateKameletProperties(ctx, repo, pipe)
+	if err != nil {
+		return "", err
+	}
+	if len(violations) == 0 {
+		return "", nil
+	}
+
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		pipe.Status.SetCondition(
+			v1.PipeConditionType(fmt.Sprintf("%s%s.%s", v1.PipeConditionPropertyValidPrefix, violation.Endpoint, violation.Property)),
+			corev1.ConditionFalse,
+			violation.Reason,
+			violation.Message,
+		)
+		messages = append(messages, violation.String())
+	}
+
+	return fmt.Sprintf("invalid properties: %s", strings.Join(messages, ", ")), nil
+}
+
+// hasIncompatibleDataTypes returns true if the Pipe initialization failed because of incompatible data types.
+func hasIncompatibleDataTypes(pipe *v1.Pipe) bool {
+	if pipe.Status.Phase != v1.PipePhaseError {
+		return false
+	}
+	condition := pipe.Status.GetCondition(v1.PipeIntegrationConditionError)
+	return condition != nil && condition.Reason == v1.PipeIncompatibleDataTypesReason
+}
+
+// AnalyzeDataTypes checks the data types flowing through the Pipe endpoints referencing Kamelets,
+// and returns the conversions to pass to CreateIntegrationFor.
+func AnalyzeDataTypes(ctx context.Context, c client.Client, pipe *v1.Pipe) (*bindings.DataTypesAnalysis, error) {
+	if len(kameletRefs(pipe)) == 0 {
+		return &bindings.DataTypesAnalysis{}, nil
+	}
+
+	repo, err := kameletRepository(ctx, c, pipe)
+	if err != nil {
+		return nil, err
+	}
+	return bindings.AnalyzeDataTypes(ctx, repo, pipe)
+}
+
+// checkDataTypes reports the result of the data types analysis with the DataTypesCompatible condition.
+// It returns a message summarizing the incompatibilities, if any.
+func checkDataTypes(pipe *v1.Pipe, analysis *bindings.DataTypesAnalysis) string {
+	switch {
+	case len(analysis.Incompatibilities) > 0:
+		messages := make([]string, 0, len(analysis.Incompatibilities))
+		for _, incompatibility := range analysis.Incompatibilities {
+			messages = append(messages, incompatibility.String())
+		}
+		message := fmt.Sprintf("incompatible data types: %s", strings.Join(messages, ", "))
+		pipe.Status.SetCondition(v1.PipeConditionDataTypesCompatible, corev1.ConditionFalse, v1.PipeIncompatibleDataTypesReason, message)
+		return message
+	case len(analysis.Conversions) > 0:
+		conversions := make([]string, 0, len(analysis.Conversions))
+		for _, conversion := range analysis.Conversions {
+			conversions = append(conversions, conversion.String())
+		}
+		pipe.Status.SetCondition(v1.PipeConditionDataTypesCompatible, corev1.ConditionTrue, v1.PipeDataTypesConvertedReason,
+			fmt.Sprintf("data types converted with transformers: %s", strings.Join(conversions, ", ")))
+	default:
+		pipe.Status.RemoveCondition(v1.PipeConditionDataTypesCompatible)
+	}
+
+	return ""
+}
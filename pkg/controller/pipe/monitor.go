@@ -29,6 +29,8 @@ import (
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/v2/pkg/trait"
+	"github.com/apache/camel-k/v2/pkg/util/bindings"
+	"github.com/apache/camel-k/v2/pkg/util/revision"
 )
 
 // NewMonitorAction returns an action that monitors the Pipe after it's fully initialized.
@@ -86,8 +88,12 @@ func (action *monitorAction) Handle(ctx context.Context, pipe *v1.Pipe) (*v1.Pip
 		return nil, err
 	}
 
-	// Check if the integration needs to be changed
-	expected, err := CreateIntegrationFor(ctx, action.client, pipe)
+	// Check if the integration needs to be changed, reusing the data type conversions it has been created with
+	conversions, err := bindings.ParseDataTypeConversions(it.Annotations[dataTypeConversionsAnnotation])
+	if err != nil {
+		return nil, err
+	}
+	expected, err := CreateIntegrationFor(ctx, action.client, pipe, conversions)
 	if pipe.Spec.Integration != nil {
 		action.L.Infof("Pipe %s is using deprecated .spec.integration parameter. Please, update and use annotation traits instead", pipe.Name)
 		pipe.Status.SetCondition(
@@ -164,6 +170,14 @@ func (action *monitorAction) Handle(ctx context.Context, pipe *v1.Pipe) (*v1.Pip
 	target.Status.Replicas = it.Status.Replicas
 	target.Status.Selector = it.Status.Selector
 
+	// Keep track of the successfully deployed specs, so that the Pipe can be rolled back
+	if it.Status.Phase == v1.IntegrationPhaseRunning && it.IsConditionTrue(v1.IntegrationConditionReady) && it.IsRolledOut() {
+		if _, err := revision.Record(ctx, action.client, target, v1.PipeKind, target.Spec,
+			it.Status.Digest, it.Status.IntegrationKit, it.Status.Image); err != nil {
+			action.L.Error(err, "Unable to record Pipe revision")
+		}
+	}
+
 	return target, nil
 }
 
@@ -19,10 +19,12 @@ package pipe
 
 import (
 	"context"
+	"fmt"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
@@ -47,7 +49,7 @@ import (
 // Add creates a new Pipe Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(ctx context.Context, mgr manager.Manager, c client.Client) error {
-	return add(mgr, newReconciler(mgr, c))
+	return add(mgr, c, newReconciler(mgr, c))
 }
 
 func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
@@ -65,7 +67,7 @@ func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
 	)
 }
 
-func add(mgr manager.Manager, r reconcile.Reconciler) error {
+func add(mgr manager.Manager, cl client.Client, r reconcile.Reconciler) error {
 	c, err := controller.New("pipe-controller", mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return err
@@ -126,9 +128,56 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch Kamelets to initialize the Pipes that reference them once they are ready
+	err = c.Watch(source.Kind(mgr.GetCache(), &v1.Kamelet{}),
+		handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a ctrl.Object) []reconcile.Request {
+			kamelet, ok := a.(*v1.Kamelet)
+			if !ok {
+				Log.Error(fmt.Errorf("type assertion failed: %v", a), "Failed to retrieve Kamelet")
+				return []reconcile.Request{}
+			}
+			return kameletEnqueueRequestsFromMapFunc(ctx, cl, kamelet)
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func kameletEnqueueRequestsFromMapFunc(ctx context.Context, c client.Client, kamelet *v1.Kamelet) []reconcile.Request {
+	requests := make([]reconcile.Request, 0)
+	if kamelet.Status.Phase != v1.KameletPhaseReady {
+		return requests
+	}
+
+	list := v1.NewPipeList()
+	// Kamelets in the operator namespace can be referenced from any namespace
+	var opts []ctrl.ListOption
+	if kamelet.Namespace != platform.GetOperatorNamespace() {
+		opts = append(opts, ctrl.InNamespace(kamelet.Namespace))
+	}
+	if err := c.List(ctx, &list, opts...); err != nil {
+		Log.Error(err, "Failed to list Pipes")
+		return requests
+	}
+
+	for _, pipe := range list.Items {
+		if !waitsForKamelets(&pipe) || !referencesKamelet(&pipe, kamelet.Name) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: pipe.Namespace,
+				Name:      pipe.Name,
+			},
+		})
+	}
+
+	return requests
+}
+
 var _ reconcile.Reconciler = &ReconcilePipe{}
 
 // ReconcilePipe reconciles a Pipe object.
@@ -18,7 +18,23 @@ limitations under the License.
 package repository
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+const (
+	// maxDownloadSize is the maximum size of the files downloaded by the remote Kamelet repositories.
+	maxDownloadSize = 32 << 20
+	// maxKameletFileSize is the maximum size of a Kamelet file extracted from a bundle or an artifact.
+	maxKameletFileSize = 1 << 20
+	// maxBundleSize is the maximum total size of the Kamelet files extracted from a bundle or an artifact.
+	maxBundleSize = 64 << 20
 )
 
 var fileSuffixes = []string{".kamelet.yaml", ".kamelet.yml", ".kamelet.json"}
@@ -48,3 +64,33 @@ func getKameletNameFromFile(fileName string) string {
 	}
 	return name
 }
+
+// decodeKamelet decodes the content of a Kamelet file, that is expected to be YAML or JSON depending on its extension.
+func decodeKamelet(fileName string, content []byte) (*v1.Kamelet, error) {
+	if strings.HasSuffix(fileName, ".yaml") || strings.HasSuffix(fileName, ".yml") {
+		var err error
+		content, err = yaml.ToJSON(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var kamelet v1.Kamelet
+	if err := json.Unmarshal(content, &kamelet); err != nil {
+		return nil, err
+	}
+	return &kamelet, nil
+}
+
+// readAtMost reads the content of the given reader, failing if it's larger than limit bytes, so that remote
+// repositories, or archives with a high compression ratio, cannot exhaust the operator memory.
+func readAtMost(r io.Reader, limit int64, name string) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("%s exceeds the maximum size of %d bytes", name, limit)
+	}
+	return content, nil
+}
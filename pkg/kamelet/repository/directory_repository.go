@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/git"
+)
+
+// directoryKameletRepository serves the Kamelets contained in a local directory, e.g., a volume mounted
+// into the operator Pod. When a ref is set, the directory must belong to a git working tree, and
+// the Kamelets are read from that ref, regardless of the revision that is checked out.
+type directoryKameletRepository struct {
+	path string
+	ref  string
+}
+
+func newDirectoryKameletRepository(path, ref string) KameletRepository {
+	return &directoryKameletRepository{
+		path: path,
+		ref:  ref,
+	}
+}
+
+// Enforce type.
+var _ KameletRepository = &directoryKameletRepository{}
+
+func (r *directoryKameletRepository) List(ctx context.Context) ([]string, error) {
+	files, err := r.listFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(files))
+	for _, file := range files {
+		if isKameletFileName(file) {
+			res = append(res, getKameletNameFromFile(file))
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func (r *directoryKameletRepository) Get(ctx context.Context, name string) (*v1.Kamelet, error) {
+	files, err := r.listFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if !isFileNameForKamelet(name, file) {
+			continue
+		}
+		content, err := r.readFile(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+		kamelet, err := decodeKamelet(file, content)
+		if err != nil {
+			return nil, err
+		}
+		if kamelet.Name != name {
+			return nil, fmt.Errorf("kamelet names do not match: expected %s, got %s", name, kamelet.Name)
+		}
+		return kamelet, nil
+	}
+	return nil, nil
+}
+
+func (r *directoryKameletRepository) listFiles(ctx context.Context) ([]string, error) {
+	if r.ref != "" {
+		return git.ListFiles(ctx, r.path, r.ref)
+	}
+
+	entries, err := os.ReadDir(r.path)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		// Symbolic links are listed as well, as the files of ConfigMap volumes are links
+		if !entry.IsDir() {
+			files = append(files, entry.Name())
+		}
+	}
+	return files, nil
+}
+
+func (r *directoryKameletRepository) readFile(ctx context.Context, file string) ([]byte, error) {
+	if r.ref != "" {
+		return git.ReadFile(ctx, r.path, r.ref, file)
+	}
+	return os.ReadFile(filepath.Join(r.path, file))
+}
+
+func (r *directoryKameletRepository) String() string {
+	return fmt.Sprintf("Directory[path=%s, ref=%s]", r.path, r.ref)
+}
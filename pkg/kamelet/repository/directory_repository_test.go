@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+const testKamelet = `apiVersion: camel.apache.org/v1
+kind: Kamelet
+metadata:
+  name: %s
+spec:
+  template:
+    from:
+      uri: timer:tick
+`
+
+func kameletFile(name string) string {
+	return fmt.Sprintf(testKamelet, name)
+}
+
+func TestDirectoryRepository(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "timer-source.kamelet.yaml"), []byte(kameletFile("timer-source")), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "log-sink.kamelet.json"),
+		[]byte(`{"apiVersion":"camel.apache.org/v1","kind":"Kamelet","metadata":{"name":"log-sink"}}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "wrong-name.kamelet.yaml"), []byte(kameletFile("other")), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("documentation"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested.kamelet.yaml"), 0o700))
+
+	repo := newDirectoryKameletRepository(dir, "")
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log-sink", "timer-source", "wrong-name"}, list)
+
+	kamelet, err := repo.Get(ctx, "timer-source")
+	require.NoError(t, err)
+	require.NotNil(t, kamelet)
+	assert.Equal(t, "timer-source", kamelet.Name)
+	kamelet, err = repo.Get(ctx, "log-sink")
+	require.NoError(t, err)
+	require.NotNil(t, kamelet)
+	assert.Equal(t, "log-sink", kamelet.Name)
+
+	kamelet, err = repo.Get(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, kamelet)
+	_, err = repo.Get(ctx, "wrong-name")
+	require.Error(t, err)
+
+	_, err = newDirectoryKameletRepository(filepath.Join(dir, "missing"), "").List(ctx)
+	require.Error(t, err)
+}
+
+func TestDirectoryRepositoryWithGitRef(t *testing.T) {
+	ctx := context.Background()
+	git := test.NewGitRepository(t)
+	git.Commit(t, map[string]string{"kamelets/timer-source.kamelet.yaml": kameletFile("timer-source")})
+	git.Tag(t, "v1")
+	git.Commit(t, map[string]string{"kamelets/log-sink.kamelet.yaml": kameletFile("log-sink")})
+
+	dir := filepath.Join(git.Dir, "kamelets")
+	repo := newDirectoryKameletRepository(dir, "v1")
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"timer-source"}, list)
+	kamelet, err := repo.Get(ctx, "log-sink")
+	require.NoError(t, err)
+	assert.Nil(t, kamelet)
+
+	repo = newDirectoryKameletRepository(dir, "main")
+	list, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log-sink", "timer-source"}, list)
+	kamelet, err = repo.Get(ctx, "log-sink")
+	require.NoError(t, err)
+	require.NotNil(t, kamelet)
+	assert.Equal(t, "log-sink", kamelet.Name)
+}
@@ -19,19 +19,15 @@ package repository
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"sort"
-	"strings"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	"github.com/google/go-github/v52/github"
 	"golang.org/x/oauth2"
-
-	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
 type githubKameletRepository struct {
@@ -131,18 +127,7 @@ func (c *githubKameletRepository) downloadKamelet(ctx context.Context, url strin
 	if err != nil {
 		return nil, err
 	}
-	if strings.HasSuffix(url, ".yaml") || strings.HasSuffix(url, ".yml") {
-		content, err = yaml.ToJSON(content)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	var kamelet v1.Kamelet
-	if err := json.Unmarshal(content, &kamelet); err != nil {
-		return nil, err
-	}
-	return &kamelet, nil
+	return decodeKamelet(url, content)
 }
 
 func (c *githubKameletRepository) String() string {
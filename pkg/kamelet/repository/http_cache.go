@@ -0,0 +1,171 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultHTTPCacheTTL is the duration during which the resources downloaded by the HTTP Kamelet repositories
+// are served from the cache, before they are revalidated with the server.
+const DefaultHTTPCacheTTL = 5 * time.Minute
+
+// DefaultHTTPCacheRetention is the duration after which the resources that have not been requested are evicted
+// from the cache, so that the resources of the repositories that are no longer used do not accumulate.
+const DefaultHTTPCacheRetention = time.Hour
+
+// httpCache is shared by all the HTTP Kamelet repositories, as repositories are created for every reconciliation.
+var httpCache = newHTTPResourceCache(DefaultHTTPCacheTTL, DefaultHTTPCacheRetention)
+
+type httpResource struct {
+	content []byte
+	etag    string
+	fetched time.Time
+	used    time.Time
+	// the content parsed by the repository, that is kept as long as the content does not change
+	parsed interface{}
+}
+
+// httpResourceCache caches the content of HTTP resources in memory. Expired resources are revalidated
+// using their ETag, if the server provided one, so that their content is only downloaded if it has changed.
+// Resources that have not been requested for longer than the retention are evicted.
+type httpResourceCache struct {
+	lock      sync.Mutex
+	ttl       time.Duration
+	retention time.Duration
+	resources map[string]httpResource
+}
+
+func newHTTPResourceCache(ttl time.Duration, retention time.Duration) *httpResourceCache {
+	return &httpResourceCache{
+		ttl:       ttl,
+		retention: retention,
+		resources: make(map[string]httpResource),
+	}
+}
+
+// get returns the content of the resource at the given URL. The cached content is returned if it's accepted
+// by the valid function, or if it has not expired when valid is nil.
+func (c *httpResourceCache) get(ctx context.Context, client *http.Client, url string, valid func([]byte) bool) ([]byte, error) {
+	resource, err := c.fetch(ctx, client, url, valid)
+	if err != nil {
+		return nil, err
+	}
+	return resource.content, nil
+}
+
+// getParsed returns the content of the resource at the given URL, as get does, along with the result of the parse
+// function, that is only called again when the content changes.
+func (c *httpResourceCache) getParsed(ctx context.Context, client *http.Client, url string, valid func([]byte) bool,
+	parse func([]byte) (interface{}, error)) ([]byte, interface{}, error) {
+	resource, err := c.fetch(ctx, client, url, valid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resource.parsed != nil {
+		return resource.content, resource.parsed, nil
+	}
+
+	parsed, err := parse(resource.content)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.lock.Lock()
+	if current, ok := c.resources[url]; ok && current.fetched.Equal(resource.fetched) {
+		current.parsed = parsed
+		c.resources[url] = current
+	}
+	c.lock.Unlock()
+
+	return resource.content, parsed, nil
+}
+
+func (c *httpResourceCache) fetch(ctx context.Context, client *http.Client, url string, valid func([]byte) bool) (httpResource, error) {
+	c.lock.Lock()
+	c.evict(time.Now())
+	cached, ok := c.resources[url]
+	if ok {
+		cached.used = time.Now()
+		c.resources[url] = cached
+	}
+	c.lock.Unlock()
+
+	if ok {
+		if valid != nil && valid(cached.content) {
+			return cached, nil
+		}
+		if valid == nil && time.Since(cached.fetched) < c.ttl {
+			return cached, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return httpResource{}, err
+	}
+	if ok && cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return httpResource{}, err
+	}
+	defer resp.Body.Close()
+
+	resource := httpResource{
+		etag:    resp.Header.Get("ETag"),
+		fetched: time.Now(),
+		used:    time.Now(),
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		resource.content = cached.content
+		resource.parsed = cached.parsed
+		if resource.etag == "" {
+			resource.etag = cached.etag
+		}
+	case resp.StatusCode == http.StatusOK:
+		resource.content, err = readAtMost(resp.Body, maxDownloadSize, "file "+redactURL(url))
+		if err != nil {
+			return httpResource{}, err
+		}
+	default:
+		return httpResource{}, fmt.Errorf("cannot download file %s: %s", redactURL(url), resp.Status)
+	}
+
+	c.lock.Lock()
+	c.resources[url] = resource
+	c.lock.Unlock()
+
+	return resource, nil
+}
+
+// evict removes the resources that have not been requested for longer than the retention. It must be called
+// with the lock held.
+func (c *httpResourceCache) evict(now time.Time) {
+	for url, resource := range c.resources {
+		if now.Sub(resource.used) > c.retention {
+			delete(c.resources, url)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+// httpTimeout bounds the download of the index, Kamelet and bundle files, so that an unresponsive server does not
+// block the reconciliation of the resources that use the repository.
+const httpTimeout = time.Minute
+
+// kameletIndex is the index file listing the Kamelets served by an HTTP repository.
+type kameletIndex struct {
+	Kamelets []kameletIndexEntry `json:"kamelets"`
+}
+
+type kameletIndexEntry struct {
+	// the name of the Kamelet
+	Name string `json:"name"`
+	// the version of the Kamelet, the highest one is served when the index lists several versions of a Kamelet
+	Version string `json:"version,omitempty"`
+	// the URL of the Kamelet file, relative to the index file
+	URL string `json:"url"`
+	// the checksum of the Kamelet file, in the format [sha256:]<hex>
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// httpKameletRepository serves the Kamelets listed in an index file, or contained in a .tar.gz bundle,
+// downloaded from an HTTP(S) server.
+type httpKameletRepository struct {
+	httpClient *http.Client
+	url        string
+	// the checksum of the bundle, set from the URL fragment, e.g., #sha256=<hex>
+	checksum string
+	bundle   bool
+}
+
+func newHTTPKameletRepository(uri string) (KameletRepository, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("expected format is http[s]://host/path/to/index.yaml or http[s]://host/path/to/bundle.tar.gz[#sha256=checksum], got: %s", u.Redacted())
+	}
+
+	var checksum string
+	if u.Fragment != "" {
+		algorithm, value, _ := strings.Cut(u.Fragment, "=")
+		if algorithm != "sha256" || value == "" {
+			return nil, fmt.Errorf("unsupported checksum %q, expected format is sha256=checksum", u.Fragment)
+		}
+		checksum = value
+		u.Fragment = ""
+	}
+
+	return &httpKameletRepository{
+		httpClient: &http.Client{Timeout: httpTimeout},
+		url:        u.String(),
+		checksum:   checksum,
+		bundle:     strings.HasSuffix(u.Path, ".tar.gz") || strings.HasSuffix(u.Path, ".tgz"),
+	}, nil
+}
+
+// Enforce type.
+var _ KameletRepository = &httpKameletRepository{}
+
+func (r *httpKameletRepository) List(ctx context.Context) ([]string, error) {
+	var res []string
+	if r.bundle {
+		files, err := r.bundleFiles(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for name := range files {
+			res = append(res, name)
+		}
+	} else {
+		entries, err := r.indexEntries(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for name := range entries {
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func (r *httpKameletRepository) Get(ctx context.Context, name string) (*v1.Kamelet, error) {
+	var fileName string
+	var content []byte
+	if r.bundle {
+		files, err := r.bundleFiles(ctx)
+		if err != nil {
+			return nil, err
+		}
+		file, ok := files[name]
+		if !ok {
+			return nil, nil
+		}
+		fileName, content = file.name, file.content
+	} else {
+		entries, err := r.indexEntries(ctx)
+		if err != nil {
+			return nil, err
+		}
+		entry, ok := entries[name]
+		if !ok {
+			return nil, nil
+		}
+		fileName, content, err = r.download(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	kamelet, err := decodeKamelet(fileName, content)
+	if err != nil {
+		return nil, err
+	}
+	if kamelet.Name != name {
+		return nil, fmt.Errorf("kamelet names do not match: expected %s, got %s", name, kamelet.Name)
+	}
+	return kamelet, nil
+}
+
+// indexEntries returns the entries of the index, keyed by Kamelet name.
+func (r *httpKameletRepository) indexEntries(ctx context.Context) (map[string]kameletIndexEntry, error) {
+	content, err := httpCache.get(ctx, r.httpClient, r.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is valid YAML
+	content, err = yaml.ToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse Kamelet index %s: %w", redactURL(r.url), err)
+	}
+	var index kameletIndex
+	if err := json.Unmarshal(content, &index); err != nil {
+		return nil, fmt.Errorf("cannot parse Kamelet index %s: %w", redactURL(r.url), err)
+	}
+
+	entries := make(map[string]kameletIndexEntry, len(index.Kamelets))
+	for _, entry := range index.Kamelets {
+		if entry.Name == "" || entry.URL == "" {
+			return nil, fmt.Errorf("invalid entry in Kamelet index %s: name and url are required", redactURL(r.url))
+		}
+		if current, ok := entries[entry.Name]; !ok || isHigherVersion(entry.Version, current.Version) {
+			entries[entry.Name] = entry
+		}
+	}
+	return entries, nil
+}
+
+// download returns the name and the content of the Kamelet file of the given index entry. As the content of a file
+// is pinned by its checksum, it's served from the cache as long as it matches the checksum.
+func (r *httpKameletRepository) download(ctx context.Context, entry kameletIndexEntry) (string, []byte, error) {
+	base, err := url.Parse(r.url)
+	if err != nil {
+		return "", nil, err
+	}
+	ref, err := url.Parse(entry.URL)
+	if err != nil {
+		return "", nil, err
+	}
+	location := base.ResolveReference(ref)
+
+	var valid func([]byte) bool
+	checksum := strings.TrimPrefix(entry.Checksum, "sha256:")
+	if checksum != "" {
+		valid = func(content []byte) bool {
+			return verifyChecksum(content, checksum) == nil
+		}
+	}
+	content, err := httpCache.get(ctx, r.httpClient, location.String(), valid)
+	if err != nil {
+		return "", nil, err
+	}
+	if checksum != "" {
+		if err := verifyChecksum(content, checksum); err != nil {
+			return "", nil, fmt.Errorf("kamelet %s: %w", entry.Name, err)
+		}
+	}
+	return path.Base(location.Path), content, nil
+}
+
+type bundleFile struct {
+	name    string
+	content []byte
+}
+
+// bundleFiles returns the Kamelet files contained in the bundle, keyed by Kamelet name. The bundle is only extracted
+// again when its content changes.
+func (r *httpKameletRepository) bundleFiles(ctx context.Context) (map[string]bundleFile, error) {
+	var valid func([]byte) bool
+	if r.checksum != "" {
+		valid = func(content []byte) bool {
+			return verifyChecksum(content, r.checksum) == nil
+		}
+	}
+	content, parsed, err := httpCache.getParsed(ctx, r.httpClient, r.url, valid, func(content []byte) (interface{}, error) {
+		return readBundle(r.url, content)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if r.checksum != "" {
+		if err := verifyChecksum(content, r.checksum); err != nil {
+			return nil, fmt.Errorf("kamelet bundle %s: %w", redactURL(r.url), err)
+		}
+	}
+	files, ok := parsed.(map[string]bundleFile)
+	if !ok {
+		return nil, fmt.Errorf("cannot read kamelet bundle %s", redactURL(r.url))
+	}
+	return files, nil
+}
+
+// readBundle extracts the Kamelet files of the given .tar.gz bundle, keyed by Kamelet name.
+func readBundle(location string, content []byte) (map[string]bundleFile, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read kamelet bundle %s: %w", redactURL(location), err)
+	}
+	defer gz.Close()
+
+	files := make(map[string]bundleFile)
+	total := 0
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("cannot read kamelet bundle %s: %w", redactURL(location), err)
+		}
+		name := path.Base(header.Name)
+		if header.Typeflag != tar.TypeReg || !isKameletFileName(name) {
+			continue
+		}
+		data, err := readAtMost(archive, maxKameletFileSize, "kamelet file "+header.Name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read kamelet bundle %s: %w", redactURL(location), err)
+		}
+		if total += len(data); total > maxBundleSize {
+			return nil, fmt.Errorf("cannot read kamelet bundle %s: the kamelet files exceed the maximum size of %d bytes", redactURL(location), maxBundleSize)
+		}
+		files[getKameletNameFromFile(name)] = bundleFile{name: name, content: data}
+	}
+	return files, nil
+}
+
+func (r *httpKameletRepository) String() string {
+	return fmt.Sprintf("HTTP[url=%s, bundle=%t]", redactURL(r.url), r.bundle)
+}
+
+func verifyChecksum(content []byte, expected string) error {
+	sum := sha256.Sum256(content)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch: expected sha256 %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// isHigherVersion returns whether version is higher than current, versions that are not semantic versions being
+// considered lower than any semantic version.
+func isHigherVersion(version, current string) bool {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	c, err := semver.NewVersion(current)
+	if err != nil {
+		return true
+	}
+	return v.GreaterThan(c)
+}
+
+// redactURL removes the password from the URL, so that it can be logged.
+func redactURL(location string) string {
+	u, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+	return u.Redacted()
+}
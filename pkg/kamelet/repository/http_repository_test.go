@@ -0,0 +1,316 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kameletServer serves static files, honoring ETags, and counts the downloads of each file.
+type kameletServer struct {
+	lock      sync.Mutex
+	files     map[string]string
+	downloads map[string]int
+}
+
+func newKameletServer(t *testing.T, files map[string]string) (*kameletServer, *httptest.Server) {
+	t.Helper()
+
+	ks := &kameletServer{files: files, downloads: make(map[string]int)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ks.lock.Lock()
+		defer ks.lock.Unlock()
+
+		content, ok := ks.files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		etag := `"` + checksum(content) + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		ks.downloads[r.URL.Path]++
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+
+	return ks, server
+}
+
+func (ks *kameletServer) set(path, content string) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	ks.files[path] = content
+}
+
+func (ks *kameletServer) count(path string) int {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	return ks.downloads[path]
+}
+
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func useHTTPCache(t *testing.T, ttl time.Duration) {
+	t.Helper()
+
+	cache := httpCache
+	httpCache = newHTTPResourceCache(ttl, DefaultHTTPCacheRetention)
+	t.Cleanup(func() {
+		httpCache = cache
+	})
+}
+
+func TestHTTPRepositoryWithIndex(t *testing.T) {
+	useHTTPCache(t, time.Hour)
+	timer := kameletFile("timer-source")
+	ks, server := newKameletServer(t, map[string]string{
+		"/kamelets/timer-source-1.0.kamelet.yaml": kameletFile("timer-source-v1"),
+		"/kamelets/timer-source.kamelet.yaml":     timer,
+		"/kamelets/log-sink.kamelet.yaml":         kameletFile("log-sink"),
+	})
+	ks.set("/index.yaml", `kamelets:
+- name: timer-source
+  version: 1.0.0
+  url: kamelets/timer-source-1.0.kamelet.yaml
+- name: timer-source
+  version: 1.1.0
+  url: kamelets/timer-source.kamelet.yaml
+  checksum: sha256:`+checksum(timer)+`
+- name: log-sink
+  url: kamelets/log-sink.kamelet.yaml
+  checksum: `+checksum("tampered")+`
+`)
+
+	ctx := context.Background()
+	repo, err := newFromURI(ctx, nil, nil, server.URL+"/index.yaml")
+	require.NoError(t, err)
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log-sink", "timer-source"}, list)
+
+	// The highest version is served, and downloaded only once
+	for i := 0; i < 3; i++ {
+		kamelet, err := repo.Get(ctx, "timer-source")
+		require.NoError(t, err)
+		require.NotNil(t, kamelet)
+		assert.Equal(t, "timer-source", kamelet.Name)
+	}
+	assert.Equal(t, 1, ks.count("/index.yaml"))
+	assert.Equal(t, 1, ks.count("/kamelets/timer-source.kamelet.yaml"))
+	assert.Equal(t, 0, ks.count("/kamelets/timer-source-1.0.kamelet.yaml"))
+
+	_, err = repo.Get(ctx, "log-sink")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+
+	kamelet, err := repo.Get(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, kamelet)
+}
+
+func TestHTTPRepositoryRevalidation(t *testing.T) {
+	useHTTPCache(t, 0)
+	ks, server := newKameletServer(t, map[string]string{
+		"/index.json":            `{"kamelets":[{"name":"log-sink","url":"log-sink.kamelet.yaml"}]}`,
+		"/log-sink.kamelet.yaml": kameletFile("log-sink"),
+	})
+
+	ctx := context.Background()
+	repo, err := newFromURI(ctx, nil, nil, server.URL+"/index.json")
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		kamelet, err := repo.Get(ctx, "log-sink")
+		require.NoError(t, err)
+		require.NotNil(t, kamelet)
+	}
+	// Expired resources are revalidated, and only downloaded again if they have changed
+	assert.Equal(t, 1, ks.count("/index.json"))
+	assert.Equal(t, 1, ks.count("/log-sink.kamelet.yaml"))
+
+	ks.set("/index.json", `{"kamelets":[{"name":"log-sink","url":"log-sink.kamelet.yaml"},{"name":"timer-source","url":"log-sink.kamelet.yaml"}]}`)
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log-sink", "timer-source"}, list)
+	assert.Equal(t, 2, ks.count("/index.json"))
+
+	_, err = repo.Get(ctx, "timer-source")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kamelet names do not match")
+}
+
+func TestHTTPCacheEviction(t *testing.T) {
+	cache := newHTTPResourceCache(0, time.Minute)
+	ks, server := newKameletServer(t, map[string]string{
+		"/a.kamelet.yaml": kameletFile("a"),
+		"/b.kamelet.yaml": kameletFile("b"),
+	})
+
+	ctx := context.Background()
+	client := &http.Client{Timeout: httpTimeout}
+	_, err := cache.get(ctx, client, server.URL+"/a.kamelet.yaml", nil)
+	require.NoError(t, err)
+	_, err = cache.get(ctx, client, server.URL+"/b.kamelet.yaml", nil)
+	require.NoError(t, err)
+
+	// Resources that have not been requested for longer than the retention are evicted
+	cache.lock.Lock()
+	a := cache.resources[server.URL+"/a.kamelet.yaml"]
+	a.used = time.Now().Add(-2 * time.Minute)
+	cache.resources[server.URL+"/a.kamelet.yaml"] = a
+	cache.lock.Unlock()
+
+	_, err = cache.get(ctx, client, server.URL+"/b.kamelet.yaml", nil)
+	require.NoError(t, err)
+	cache.lock.Lock()
+	assert.NotContains(t, cache.resources, server.URL+"/a.kamelet.yaml")
+	assert.Contains(t, cache.resources, server.URL+"/b.kamelet.yaml")
+	cache.lock.Unlock()
+	assert.Equal(t, 1, ks.count("/b.kamelet.yaml"))
+}
+
+func TestHTTPRepositoryClientTimeout(t *testing.T) {
+	repo, err := newHTTPKameletRepository("https://example.com/index.yaml")
+	require.NoError(t, err)
+	httpRepo, ok := repo.(*httpKameletRepository)
+	require.True(t, ok)
+	assert.Equal(t, httpTimeout, httpRepo.httpClient.Timeout)
+}
+
+func TestHTTPRepositoryWithBundle(t *testing.T) {
+	useHTTPCache(t, time.Hour)
+	bundle := createBundle(t, map[string]string{
+		"catalog/kamelets/timer-source.kamelet.yaml": kameletFile("timer-source"),
+		"catalog/kamelets/log-sink.kamelet.yaml":     kameletFile("log-sink"),
+		"catalog/README.md":                          "documentation",
+	})
+	ks, server := newKameletServer(t, map[string]string{"/kamelets.tar.gz": bundle})
+
+	ctx := context.Background()
+	repo, err := newFromURI(ctx, nil, nil, server.URL+"/kamelets.tar.gz#sha256="+checksum(bundle))
+	require.NoError(t, err)
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"log-sink", "timer-source"}, list)
+	kamelet, err := repo.Get(ctx, "timer-source")
+	require.NoError(t, err)
+	require.NotNil(t, kamelet)
+	assert.Equal(t, "timer-source", kamelet.Name)
+	assert.Equal(t, 1, ks.count("/kamelets.tar.gz"))
+
+	repo, err = newFromURI(ctx, nil, nil, server.URL+"/kamelets.tar.gz#sha256="+checksum("other"))
+	require.NoError(t, err)
+	_, err = repo.List(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+
+	_, err = newFromURI(ctx, nil, nil, server.URL+"/kamelets.tar.gz#md5=0")
+	require.Error(t, err)
+}
+
+func TestHTTPRepositoryBundleLimits(t *testing.T) {
+	useHTTPCache(t, time.Hour)
+	// a highly compressible Kamelet file, exceeding the maximum size once extracted
+	bundle := createBundle(t, map[string]string{
+		"kamelets/bomb.kamelet.yaml": strings.Repeat(" ", maxKameletFileSize+1),
+	})
+	_, server := newKameletServer(t, map[string]string{"/kamelets.tar.gz": bundle})
+
+	ctx := context.Background()
+	repo, err := newFromURI(ctx, nil, nil, server.URL+"/kamelets.tar.gz")
+	require.NoError(t, err)
+	_, err = repo.List(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the maximum size")
+}
+
+func TestHTTPCacheParsedContent(t *testing.T) {
+	cache := newHTTPResourceCache(0, DefaultHTTPCacheRetention)
+	ks, server := newKameletServer(t, map[string]string{"/kamelets.tar.gz": "v1"})
+
+	ctx := context.Background()
+	client := &http.Client{Timeout: httpTimeout}
+	parsed := 0
+	parse := func(content []byte) (interface{}, error) {
+		parsed++
+		return string(content), nil
+	}
+	for i := 0; i < 3; i++ {
+		_, result, err := cache.getParsed(ctx, client, server.URL+"/kamelets.tar.gz", nil, parse)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", result)
+	}
+	// The content is revalidated, and only parsed again once it has changed
+	assert.Equal(t, 1, parsed)
+
+	ks.set("/kamelets.tar.gz", "v2")
+	_, result, err := cache.getParsed(ctx, client, server.URL+"/kamelets.tar.gz", nil, parse)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", result)
+	assert.Equal(t, 2, parsed)
+}
+
+func TestReadAtMost(t *testing.T) {
+	content, err := readAtMost(strings.NewReader("12345"), 5, "file")
+	require.NoError(t, err)
+	assert.Equal(t, "12345", string(content))
+	_, err = readAtMost(strings.NewReader("123456"), 5, "file")
+	require.EqualError(t, err, "file exceeds the maximum size of 5 bytes")
+}
+
+func createBundle(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, archive.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := archive.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.String()
+}
@@ -0,0 +1,389 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util"
+	"github.com/apache/camel-k/v2/pkg/util/defaults"
+	"github.com/apache/camel-k/v2/pkg/util/jvm"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+const (
+	// mavenKameletsDirectory is the directory of the Kamelet catalog artifacts containing the Kamelet files.
+	mavenKameletsDirectory = "kamelets"
+	// defaultMavenTimeout is used when the platform does not define a build timeout, i.e., it's not reconciled yet.
+	defaultMavenTimeout = 5 * time.Minute
+	// mavenRetryBackoff is the duration during which the failure to resolve an artifact is cached, before it's
+	// resolved again. It's doubled on each consecutive failure, up to mavenMaxRetryBackoff.
+	mavenRetryBackoff    = 30 * time.Second
+	mavenMaxRetryBackoff = 10 * time.Minute
+	// mavenCacheRetention is the duration after which the artifacts that have not been requested are evicted
+	// from the cache, so that the artifacts of the repositories that are no longer used do not accumulate.
+	mavenCacheRetention = time.Hour
+)
+
+// ErrNotYetAvailable is returned while the Kamelets of a repository are being resolved, so that the lookup can be
+// retried later.
+var ErrNotYetAvailable = errors.New("kamelets not yet available")
+
+// mavenKamelets is the cache entry of an artifact, resolved with the Maven configuration of a platform. The artifact
+// is resolved in the background, so that the lookups never wait for Maven longer than mavenResolutionWait, and only
+// once at a time.
+type mavenKamelets struct {
+	lock sync.Mutex
+	// the Kamelet files keyed by Kamelet name
+	files map[string]bundleFile
+	// the error of the last resolution, that is returned until the retry backoff has elapsed
+	err      error
+	failures int
+	resolved time.Time
+	// closed once the resolution in progress completes, nil when no resolution is in progress
+	resolving chan struct{}
+	// the last time the entry has been requested, guarded by mavenCacheLock
+	used time.Time
+}
+
+var (
+	// mavenCache is shared by all the Maven Kamelet repositories, as repositories are created for every reconciliation.
+	// The entries are keyed by platform and artifact, as the artifacts are resolved with the Maven configuration,
+	// e.g., the credentials, of the platform. mavenCacheLock only guards the map.
+	mavenCacheLock sync.Mutex
+	mavenCache     = make(map[string]*mavenKamelets)
+	// mavenResolutionWait is the duration a lookup waits for the resolution of an artifact, before failing with
+	// ErrNotYetAvailable.
+	mavenResolutionWait = 5 * time.Second
+)
+
+// mavenKameletRepository serves the Kamelets contained in the kamelets directory of a Maven artifact, e.g., the
+// org.apache.camel.kamelets:camel-kamelets catalog, resolved with the Maven configuration of the platform.
+type mavenKameletRepository struct {
+	client     ctrl.Reader
+	platform   *v1.IntegrationPlatform
+	dependency maven.Dependency
+}
+
+func newMavenKameletRepository(client ctrl.Reader, platform *v1.IntegrationPlatform, gav string) (KameletRepository, error) {
+	dependency, err := maven.ParseGAV(gav)
+	if err != nil || dependency.Version == "" {
+		return nil, fmt.Errorf("expected format is mvn:groupId:artifactId:version, got: mvn:%s", gav)
+	}
+
+	return &mavenKameletRepository{
+		client:     client,
+		platform:   platform,
+		dependency: dependency,
+	}, nil
+}
+
+// Enforce type.
+var _ KameletRepository = &mavenKameletRepository{}
+
+func (r *mavenKameletRepository) List(ctx context.Context) ([]string, error) {
+	files, err := r.files(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(files))
+	for name := range files {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func (r *mavenKameletRepository) Get(ctx context.Context, name string) (*v1.Kamelet, error) {
+	files, err := r.files(ctx)
+	if err != nil {
+		return nil, err
+	}
+	file, ok := files[name]
+	if !ok {
+		return nil, nil
+	}
+
+	kamelet, err := decodeKamelet(file.name, file.content)
+	if err != nil {
+		return nil, err
+	}
+	if kamelet.Name != name {
+		return nil, fmt.Errorf("kamelet names do not match: expected %s, got %s", name, kamelet.Name)
+	}
+	return kamelet, nil
+}
+
+// files returns the Kamelet files of the artifact, that is only resolved once, unless it's a snapshot,
+// in which case it's resolved again once the cached files have expired. Failures are cached, and the
+// resolution is retried with an exponential backoff, so that an unreachable repository is not hit on
+// every lookup. ErrNotYetAvailable is returned while the artifact is being resolved.
+func (r *mavenKameletRepository) files(ctx context.Context) (map[string]bundleFile, error) {
+	entry := mavenCacheEntry(r.cacheKey(), time.Now())
+
+	entry.lock.Lock()
+	switch {
+	case entry.resolving != nil:
+		// the resolution is in progress
+	case entry.err != nil && time.Since(entry.resolved) < entry.backoff():
+		err := entry.err
+		entry.lock.Unlock()
+		return nil, err
+	case entry.err == nil && entry.files != nil &&
+		(!strings.HasSuffix(r.dependency.Version, "-SNAPSHOT") || time.Since(entry.resolved) < DefaultHTTPCacheTTL):
+		files := entry.files
+		entry.lock.Unlock()
+		return files, nil
+	default:
+		entry.resolving = make(chan struct{})
+		go r.resolveFiles(entry)
+	}
+	resolving := entry.resolving
+	// the expired files of a snapshot are served until it's resolved again
+	stale := entry.files
+	if entry.err != nil {
+		stale = nil
+	}
+	entry.lock.Unlock()
+	if stale != nil {
+		return stale, nil
+	}
+
+	timer := time.NewTimer(mavenResolutionWait)
+	defer timer.Stop()
+	select {
+	case <-resolving:
+		entry.lock.Lock()
+		defer entry.lock.Unlock()
+		if entry.err != nil {
+			return nil, entry.err
+		}
+		return entry.files, nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return nil, fmt.Errorf("Kamelet catalog %s is being resolved: %w", r.gav(), ErrNotYetAvailable)
+}
+
+// resolveFiles resolves the artifact, and records the Kamelet files it contains, or the failure, into the given entry.
+func (r *mavenKameletRepository) resolveFiles(entry *mavenKamelets) {
+	var files map[string]bundleFile
+	err := util.WithTempDir("camel-k-kamelets", func(dir string) error {
+		jar, err := r.resolve(context.Background(), dir)
+		if err != nil {
+			return err
+		}
+		files, err = readKameletsJar(jar)
+		return err
+	})
+
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+	entry.resolved = time.Now()
+	if err != nil {
+		entry.err = fmt.Errorf("cannot resolve Kamelet catalog %s: %w", r.gav(), err)
+		entry.failures++
+	} else {
+		entry.files = files
+		entry.err = nil
+		entry.failures = 0
+	}
+	close(entry.resolving)
+	entry.resolving = nil
+}
+
+// mavenCacheEntry returns the cache entry with the given key, creating it if needed, and evicts the entries
+// that have not been requested for longer than the retention.
+func mavenCacheEntry(key string, now time.Time) *mavenKamelets {
+	mavenCacheLock.Lock()
+	defer mavenCacheLock.Unlock()
+
+	for k, entry := range mavenCache {
+		if now.Sub(entry.used) > mavenCacheRetention {
+			delete(mavenCache, k)
+		}
+	}
+
+	entry, ok := mavenCache[key]
+	if !ok {
+		entry = &mavenKamelets{}
+		mavenCache[key] = entry
+	}
+	entry.used = now
+	return entry
+}
+
+// backoff returns the duration during which the last failure is cached. It must be called with the entry lock held.
+func (e *mavenKamelets) backoff() time.Duration {
+	backoff := mavenRetryBackoff
+	for i := 1; i < e.failures && backoff < mavenMaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > mavenMaxRetryBackoff {
+		return mavenMaxRetryBackoff
+	}
+	return backoff
+}
+
+// resolve downloads the artifact into the given directory, using the Maven configuration of the platform,
+// and returns the location of the downloaded jar.
+func (r *mavenKameletRepository) resolve(ctx context.Context, dir string) (string, error) {
+	build := v1.IntegrationPlatformBuildSpec{}
+	namespace := ""
+	if r.platform != nil {
+		build = r.platform.Status.Build
+		if r.platform.Status.Phase == v1.IntegrationPlatformPhaseNone {
+			// Maybe not reconciled yet
+			build = r.platform.Spec.Build
+		}
+		namespace = r.platform.Namespace
+	}
+	mvn := build.Maven
+
+	project := maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-kamelets-resolver", defaults.Version)
+	project.AddDependency(r.dependency)
+	project.Build = &maven.Build{
+		DefaultGoal: "dependency:copy-dependencies",
+	}
+
+	output := filepath.Join(dir, "target")
+	mc := maven.NewContext(dir)
+	mc.LocalRepository = mvn.LocalRepository
+	mc.AdditionalArguments = mvn.CLIOptions
+	mc.AddSystemProperty("outputDirectory", output)
+	mc.AddSystemProperty("excludeTransitive", "true")
+
+	settings, err := maven.NewSettings(maven.DefaultRepositories, maven.ProxyFromEnvironment)
+	if err != nil {
+		return "", err
+	}
+	mc.GlobalSettings, err = settings.MarshalBytes()
+	if err != nil {
+		return "", err
+	}
+
+	if mvn.Settings.ConfigMapKeyRef != nil || mvn.Settings.SecretKeyRef != nil || mvn.CASecrets != nil {
+		if r.client == nil {
+			return "", fmt.Errorf("cannot read the Maven configuration of platform %s/%s", namespace, r.platform.Name)
+		}
+		if userSettings, err := kubernetes.ResolveValueSource(ctx, r.client, namespace, &mvn.Settings); err != nil {
+			return "", err
+		} else if userSettings != "" {
+			mc.UserSettings = []byte(userSettings)
+		}
+		if mvn.CASecrets != nil {
+			certsData, err := kubernetes.GetSecretsRefData(ctx, r.client, namespace, mvn.CASecrets)
+			if err != nil {
+				return "", err
+			}
+			trustStoreName := "trust.jks"
+			trustStorePass := jvm.NewKeystorePassword()
+			if err := jvm.GenerateKeystore(ctx, dir, trustStoreName, trustStorePass, certsData); err != nil {
+				return "", err
+			}
+			mc.ExtraMavenOpts = append(mc.ExtraMavenOpts,
+				"-Djavax.net.ssl.trustStore="+trustStoreName,
+				"-Djavax.net.ssl.trustStorePassword="+trustStorePass,
+			)
+		}
+	}
+
+	timeout := build.GetTimeout().Duration
+	if timeout == 0 {
+		timeout = defaultMavenTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := project.Command(mc).Do(ctx); err != nil {
+		return "", err
+	}
+
+	jars, err := filepath.Glob(filepath.Join(output, r.dependency.ArtifactID+"-*.jar"))
+	if err != nil {
+		return "", err
+	}
+	if len(jars) == 0 {
+		return "", fmt.Errorf("artifact %s not found in %s", r.dependency.ArtifactID, output)
+	}
+	return jars[0], nil
+}
+
+// cacheKey identifies the artifact resolved with the Maven configuration of the platform.
+func (r *mavenKameletRepository) cacheKey() string {
+	if r.platform == nil {
+		return r.gav()
+	}
+	return fmt.Sprintf("%s/%s/%s", r.platform.Namespace, r.platform.Name, r.gav())
+}
+
+func (r *mavenKameletRepository) gav() string {
+	return fmt.Sprintf("%s:%s:%s", r.dependency.GroupID, r.dependency.ArtifactID, r.dependency.Version)
+}
+
+func (r *mavenKameletRepository) String() string {
+	return fmt.Sprintf("Maven[artifact=%s]", r.gav())
+}
+
+// readKameletsJar returns the Kamelet files contained in the kamelets directory of the given jar, keyed by Kamelet name.
+func readKameletsJar(jar string) (map[string]bundleFile, error) {
+	archive, err := zip.OpenReader(jar)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	files := make(map[string]bundleFile)
+	total := 0
+	for _, f := range archive.File {
+		dir, name := path.Split(f.Name)
+		if path.Clean(dir) != mavenKameletsDirectory || !isKameletFileName(name) {
+			continue
+		}
+		content, err := readZipFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if total += len(content); total > maxBundleSize {
+			return nil, fmt.Errorf("the kamelet files of %s exceed the maximum size of %d bytes", filepath.Base(jar), maxBundleSize)
+		}
+		files[getKameletNameFromFile(name)] = bundleFile{name: name, content: content}
+	}
+	return files, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return readAtMost(rc, maxKameletFileSize, "kamelet file "+f.Name)
+}
@@ -0,0 +1,277 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+// fakeMaven copies the given jar into the output directory of the dependency:copy-dependencies goal,
+// and counts the invocations. The resolution of the blocked artifact fails once the unblock file exists.
+const fakeMaven = `#!/bin/sh
+echo invoked >> "$(dirname "$0")/invocations"
+if grep -q '<artifactId>blocked</artifactId>' pom.xml; then
+  while [ ! -f "$(dirname "$0")/unblock" ]; do sleep 0.1; done
+  exit 1
+fi
+grep -q '<artifactId>camel-kamelets</artifactId>' pom.xml || exit 1
+for arg in "$@"; do
+  case "$arg" in
+    -DoutputDirectory=*) output="${arg#-DoutputDirectory=}" ;;
+  esac
+done
+mkdir -p "$output" && cp "$(dirname "$0")/camel-kamelets.jar" "$output/camel-kamelets-4.0.0.jar"
+`
+
+func useFakeMaven(t *testing.T, entries map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	jar, err := os.Create(filepath.Join(dir, "camel-kamelets.jar"))
+	require.NoError(t, err)
+	w := zip.NewWriter(jar)
+	for name, content := range entries {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, jar.Close())
+
+	mvn := filepath.Join(dir, "mvn")
+	require.NoError(t, os.WriteFile(mvn, []byte(fakeMaven), 0o700))
+	t.Setenv("MAVEN_CMD", mvn)
+	t.Setenv("MAVEN_WRAPPER", "false")
+
+	cache := mavenCache
+	mavenCache = make(map[string]*mavenKamelets)
+	t.Cleanup(func() {
+		mavenCache = cache
+	})
+	return filepath.Join(dir, "invocations")
+}
+
+func TestMavenRepository(t *testing.T) {
+	ctx := context.Background()
+	invocations := useFakeMaven(t, map[string]string{
+		"kamelets/timer-source.kamelet.yaml":    kameletFile("timer-source"),
+		"kamelets/wrong-name.kamelet.yaml":      kameletFile("other"),
+		"kamelets/README.md":                    "documentation",
+		"META-INF/MANIFEST.MF":                  "Manifest-Version: 1.0",
+		"other/log-sink.kamelet.yaml":           kameletFile("log-sink"),
+		"kamelets/nested/log-sink.kamelet.yaml": kameletFile("log-sink"),
+	})
+
+	repo, err := newFromURI(ctx, nil, nil, "mvn:org.apache.camel.kamelets:camel-kamelets:4.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Maven[artifact=org.apache.camel.kamelets:camel-kamelets:4.0.0]", repo.String())
+
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"timer-source", "wrong-name"}, list)
+
+	kamelet, err := repo.Get(ctx, "timer-source")
+	require.NoError(t, err)
+	require.NotNil(t, kamelet)
+	assert.Equal(t, "timer-source", kamelet.Name)
+	kamelet, err = repo.Get(ctx, "log-sink")
+	require.NoError(t, err)
+	assert.Nil(t, kamelet)
+	_, err = repo.Get(ctx, "wrong-name")
+	require.Error(t, err)
+
+	// The artifact is only resolved once
+	repo, err = newFromURI(ctx, nil, nil, "mvn:org.apache.camel.kamelets:camel-kamelets:4.0.0")
+	require.NoError(t, err)
+	list, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	data, err := os.ReadFile(invocations)
+	require.NoError(t, err)
+	assert.Equal(t, "invoked\n", string(data))
+}
+
+func TestMavenRepositoryErrors(t *testing.T) {
+	ctx := context.Background()
+	useFakeMaven(t, nil)
+
+	_, err := newFromURI(ctx, nil, nil, "mvn:org.apache.camel.kamelets:camel-kamelets")
+	require.Error(t, err)
+
+	repo, err := newFromURI(ctx, nil, nil, "mvn:org.apache.camel.kamelets:other:4.0.0")
+	require.NoError(t, err)
+	_, err = repo.List(ctx)
+	require.Error(t, err)
+}
+
+func TestMavenRepositoryFailureBackoff(t *testing.T) {
+	ctx := context.Background()
+	invocations := useFakeMaven(t, nil)
+	gav := "org.apache.camel.kamelets:other:4.0.0"
+
+	repo, err := newFromURI(ctx, nil, nil, "mvn:"+gav)
+	require.NoError(t, err)
+	_, err = repo.List(ctx)
+	require.Error(t, err)
+	// The failure is cached until the backoff has elapsed
+	_, err = repo.Get(ctx, "timer-source")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot resolve Kamelet catalog "+gav)
+	data, err := os.ReadFile(invocations)
+	require.NoError(t, err)
+	assert.Equal(t, "invoked\n", string(data))
+
+	entry := mavenCache[gav]
+	entry.resolved = time.Now().Add(-mavenRetryBackoff)
+	_, err = repo.List(ctx)
+	require.Error(t, err)
+	data, err = os.ReadFile(invocations)
+	require.NoError(t, err)
+	assert.Equal(t, "invoked\ninvoked\n", string(data))
+	assert.Equal(t, 2*mavenRetryBackoff, entry.backoff())
+
+	entry.failures = 10
+	assert.Equal(t, mavenMaxRetryBackoff, entry.backoff())
+}
+
+func TestMavenRepositoryEviction(t *testing.T) {
+	ctx := context.Background()
+	useFakeMaven(t, map[string]string{
+		"kamelets/timer-source.kamelet.yaml": kameletFile("timer-source"),
+	})
+
+	repo, err := newFromURI(ctx, nil, nil, "mvn:org.apache.camel.kamelets:other:4.0.0")
+	require.NoError(t, err)
+	_, err = repo.List(ctx)
+	require.Error(t, err)
+	mavenCache["org.apache.camel.kamelets:other:4.0.0"].used = time.Now().Add(-2 * mavenCacheRetention)
+
+	// Artifacts that have not been requested for longer than the retention are evicted
+	repo, err = newFromURI(ctx, nil, nil, "mvn:org.apache.camel.kamelets:camel-kamelets:4.0.0")
+	require.NoError(t, err)
+	_, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, mavenCache, "org.apache.camel.kamelets:other:4.0.0")
+	assert.Contains(t, mavenCache, "org.apache.camel.kamelets:camel-kamelets:4.0.0")
+}
+
+func TestMavenRepositoryConcurrentArtifacts(t *testing.T) {
+	ctx := context.Background()
+	invocations := useFakeMaven(t, map[string]string{
+		"kamelets/timer-source.kamelet.yaml": kameletFile("timer-source"),
+	})
+
+	blocked, err := newFromURI(ctx, nil, nil, "mvn:org.apache.camel.kamelets:blocked:4.0.0")
+	require.NoError(t, err)
+	done := make(chan error)
+	go func() {
+		_, err := blocked.List(ctx)
+		done <- err
+	}()
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(invocations)
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The resolution of an artifact does not block the lookups of the other artifacts
+	repo, err := newFromURI(ctx, nil, nil, "mvn:org.apache.camel.kamelets:camel-kamelets:4.0.0")
+	require.NoError(t, err)
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"timer-source"}, list)
+
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(invocations), "unblock"), nil, 0o600))
+	require.Error(t, <-done)
+}
+
+func TestMavenRepositoryPerPlatform(t *testing.T) {
+	ctx := context.Background()
+	invocations := useFakeMaven(t, map[string]string{
+		"kamelets/timer-source.kamelet.yaml": kameletFile("timer-source"),
+	})
+	uri := "mvn:org.apache.camel.kamelets:camel-kamelets:4.0.0"
+
+	// The artifact is resolved with the Maven configuration of each platform
+	for _, name := range []string{"camel-k", "camel-k", "other"} {
+		platform := &v1.IntegrationPlatform{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}}
+		repo, err := newFromURI(ctx, nil, platform, uri)
+		require.NoError(t, err)
+		list, err := repo.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"timer-source"}, list)
+	}
+	data, err := os.ReadFile(invocations)
+	require.NoError(t, err)
+	assert.Equal(t, "invoked\ninvoked\n", string(data))
+	assert.Contains(t, mavenCache, "ns/camel-k/org.apache.camel.kamelets:camel-kamelets:4.0.0")
+	assert.Contains(t, mavenCache, "ns/other/org.apache.camel.kamelets:camel-kamelets:4.0.0")
+}
+
+func TestMavenRepositoryNotYetAvailable(t *testing.T) {
+	ctx := context.Background()
+	invocations := useFakeMaven(t, nil)
+	wait := mavenResolutionWait
+	mavenResolutionWait = 10 * time.Millisecond
+	t.Cleanup(func() {
+		mavenResolutionWait = wait
+	})
+
+	repo, err := newFromURI(ctx, nil, nil, "mvn:org.apache.camel.kamelets:blocked:4.0.0")
+	require.NoError(t, err)
+	// The lookups do not wait for the resolution to complete
+	_, err = repo.List(ctx)
+	require.ErrorIs(t, err, ErrNotYetAvailable)
+	_, err = repo.Get(ctx, "timer-source")
+	require.ErrorIs(t, err, ErrNotYetAvailable)
+
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(invocations), "unblock"), nil, 0o600))
+	require.Eventually(t, func() bool {
+		_, err := repo.List(ctx)
+		return err != nil && !errors.Is(err, ErrNotYetAvailable)
+	}, 10*time.Second, 10*time.Millisecond)
+	data, err := os.ReadFile(invocations)
+	require.NoError(t, err)
+	assert.Equal(t, "invoked\n", string(data))
+}
+
+func TestMavenRepositorySizeLimit(t *testing.T) {
+	ctx := context.Background()
+	useFakeMaven(t, map[string]string{
+		"kamelets/timer-source.kamelet.yaml": kameletFile("timer-source") + strings.Repeat("#", maxKameletFileSize),
+	})
+
+	repo, err := newFromURI(ctx, nil, nil, "mvn:org.apache.camel.kamelets:camel-kamelets:4.0.0")
+	require.NoError(t, err)
+	_, err = repo.List(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the maximum size")
+}
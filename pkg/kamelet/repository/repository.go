@@ -20,9 +20,11 @@ package repository
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
 	camel "github.com/apache/camel-k/v2/pkg/client/camel/clientset/versioned"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -51,29 +53,29 @@ type KameletRepository interface {
 // Kamelets are first looked up in all the given namespaces, in the order they appear.
 // If one namespace defines an IntegrationPlatform (only the first IntegrationPlatform in state "Ready" found),
 // then all kamelet repository URIs defined in the IntegrationPlatform are included.
-func New(ctx context.Context, client camel.Interface, namespaces ...string) (KameletRepository, error) {
+func New(ctx context.Context, c client.Client, namespaces ...string) (KameletRepository, error) {
 	namespaces = makeDistinctNonEmpty(namespaces)
-	platform, err := lookupPlatform(ctx, client, namespaces...)
+	platform, err := lookupPlatform(ctx, c, namespaces...)
 	if err != nil {
 		return nil, err
 	}
-	return NewForPlatform(ctx, client, platform, namespaces...)
+	return NewForPlatform(ctx, c, platform, namespaces...)
 }
 
 // NewForPlatform creates a KameletRepository for the given namespaces and platform.
 // Kamelets are first looked up in all the given namespaces, in the order they appear,
 // then repositories defined in the platform are looked up.
-func NewForPlatform(ctx context.Context, client camel.Interface, platform *v1.IntegrationPlatform, namespaces ...string) (KameletRepository, error) {
+func NewForPlatform(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform, namespaces ...string) (KameletRepository, error) {
 	namespaces = makeDistinctNonEmpty(namespaces)
 	repoImpls := make([]KameletRepository, 0)
 	for _, namespace := range namespaces {
 		// Add first a namespace local repository for each namespace
-		repoImpls = append(repoImpls, newKubernetesKameletRepository(client, namespace))
+		repoImpls = append(repoImpls, newKubernetesKameletRepository(c, namespace))
 	}
 	if platform != nil {
 		repos := getRepositoriesFromPlatform(platform)
 		for _, repoURI := range repos {
-			repoImpl, err := newFromURI(ctx, repoURI)
+			repoImpl, err := newFromURI(ctx, c, platform, repoURI)
 			if err != nil {
 				return nil, err
 			}
@@ -81,7 +83,7 @@ func NewForPlatform(ctx context.Context, client camel.Interface, platform *v1.In
 		}
 	} else {
 		// Add default repo
-		defaultRepoImpl, err := newFromURI(ctx, DefaultRemoteRepository)
+		defaultRepoImpl, err := newFromURI(ctx, c, platform, DefaultRemoteRepository)
 		if err != nil {
 			return nil, err
 		}
@@ -91,9 +93,9 @@ func NewForPlatform(ctx context.Context, client camel.Interface, platform *v1.In
 	return newCompositeKameletRepository(repoImpls...), nil
 }
 
-func lookupPlatform(ctx context.Context, client camel.Interface, namespaces ...string) (*v1.IntegrationPlatform, error) {
+func lookupPlatform(ctx context.Context, c camel.Interface, namespaces ...string) (*v1.IntegrationPlatform, error) {
 	for _, namespace := range namespaces {
-		pls, err := client.CamelV1().IntegrationPlatforms(namespace).List(ctx, metav1.ListOptions{})
+		pls, err := c.CamelV1().IntegrationPlatforms(namespace).List(ctx, metav1.ListOptions{})
 		if err != nil {
 			return nil, err
 		}
@@ -126,7 +128,7 @@ func getRepositoriesFromPlatform(platform *v1.IntegrationPlatform) []string {
 	return res
 }
 
-func newFromURI(ctx context.Context, uri string) (KameletRepository, error) {
+func newFromURI(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform, uri string) (KameletRepository, error) {
 	if uri == NoneRepository {
 		return newEmptyKameletRepository(), nil
 	} else if strings.HasPrefix(uri, "github:") {
@@ -148,6 +150,25 @@ func newFromURI(ctx context.Context, uri string) (KameletRepository, error) {
 			path = strings.Join(parts[2:], "/")
 		}
 		return newGithubKameletRepository(ctx, owner, repo, path, version), nil
+	} else if strings.HasPrefix(uri, "dir:") || strings.HasPrefix(uri, "file:") {
+		_, path, _ := strings.Cut(uri, ":")
+		// Accept file URLs, e.g., file:///path/to/kamelets
+		path = strings.TrimPrefix(path, "//")
+		var ref string
+		if strings.Contains(path, "@") {
+			pos := strings.LastIndex(path, "@")
+			ref = path[pos+1:]
+			path = path[0:pos]
+		}
+		if !filepath.IsAbs(path) {
+			return nil, fmt.Errorf("expected format is dir:/absolute/path[@ref], got: %s", uri)
+		}
+		return newDirectoryKameletRepository(filepath.Clean(path), ref), nil
+	} else if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+		return newHTTPKameletRepository(uri)
+	} else if strings.HasPrefix(uri, "mvn:") {
+		// The Maven settings and CA secrets of the platform are read with the given client
+		return newMavenKameletRepository(c, platform, strings.TrimPrefix(uri, "mvn:"))
 	}
 	return nil, fmt.Errorf("invalid uri: %s", uri)
 }
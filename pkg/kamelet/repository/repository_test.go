@@ -20,10 +20,12 @@ package repository
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
-	"github.com/apache/camel-k/v2/pkg/client/camel/clientset/versioned/fake"
+	"github.com/apache/camel-k/v2/pkg/util/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -93,6 +95,30 @@ func TestURIParse(t *testing.T) {
 			uri:   "zithub:apache/camel-kamelets/the/path",
 			error: true,
 		},
+		{
+			uri: "dir:/kamelets",
+			repository: &directoryKameletRepository{
+				path: "/kamelets",
+			},
+		},
+		{
+			uri: "file:///etc/camel/kamelets/@v1.2.3",
+			repository: &directoryKameletRepository{
+				path: "/etc/camel/kamelets",
+				ref:  "v1.2.3",
+			},
+		},
+		{
+			uri: "dir:/mirror/camel-kamelets/kamelets@main",
+			repository: &directoryKameletRepository{
+				path: "/mirror/camel-kamelets/kamelets",
+				ref:  "main",
+			},
+		},
+		{
+			uri:   "dir:kamelets",
+			error: true,
+		},
 		{
 			uri:        "none",
 			repository: &emptyKameletRepository{},
@@ -100,7 +126,7 @@ func TestURIParse(t *testing.T) {
 	}
 	for i, test := range tests {
 		t.Run(fmt.Sprintf("%d-%s", i, test.uri), func(t *testing.T) {
-			catalog, err := newFromURI(context.Background(), test.uri)
+			catalog, err := newFromURI(context.Background(), nil, nil, test.uri)
 			if test.error {
 				require.Error(t, err)
 			} else {
@@ -113,6 +139,11 @@ func TestURIParse(t *testing.T) {
 					assert.Equal(t, r.repo, gc.repo)
 					assert.Equal(t, r.path, gc.path)
 					assert.Equal(t, r.ref, gc.ref)
+				case *directoryKameletRepository:
+					dc, ok := catalog.(*directoryKameletRepository)
+					assert.True(t, ok)
+					assert.Equal(t, r.path, dc.path)
+					assert.Equal(t, r.ref, dc.ref)
 				case *emptyKameletRepository:
 					_, ok := catalog.(*emptyKameletRepository)
 					assert.True(t, ok)
@@ -128,7 +159,8 @@ func TestURIParse(t *testing.T) {
 
 func TestNewRepository(t *testing.T) {
 	ctx := context.Background()
-	fakeClient := fake.NewSimpleClientset(createTestContext("none")...)
+	fakeClient, err := test.NewFakeClient(createTestContext("none")...)
+	require.NoError(t, err)
 	repo, err := New(ctx, fakeClient, "test")
 	require.NoError(t, err)
 	list, err := repo.List(ctx)
@@ -144,7 +176,8 @@ func TestNewRepository(t *testing.T) {
 
 func TestNewRepositoryWithCamelKamelets(t *testing.T) {
 	ctx := context.Background()
-	fakeClient := fake.NewSimpleClientset(createTestContext("github:apache/camel-kamelets/kamelets")...)
+	fakeClient, err := test.NewFakeClient(createTestContext("github:apache/camel-kamelets/kamelets")...)
+	require.NoError(t, err)
 	repo, err := New(ctx, fakeClient, "test")
 	require.NoError(t, err)
 	list, err := repo.List(ctx)
@@ -158,9 +191,26 @@ func TestNewRepositoryWithCamelKamelets(t *testing.T) {
 	assert.Equal(t, "kamelet2", k2.Name)
 }
 
+func TestNewRepositoryWithDirectory(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kamelet3.kamelet.yaml"), []byte(kameletFile("kamelet3")), 0o600))
+	fakeClient, err := test.NewFakeClient(createTestContext("dir:" + dir)...)
+	require.NoError(t, err)
+	repo, err := New(ctx, fakeClient, "test")
+	require.NoError(t, err)
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kamelet1", "kamelet2", "kamelet3"}, list)
+	k3, err := repo.Get(ctx, "kamelet3")
+	require.NoError(t, err)
+	assert.Equal(t, "kamelet3", k3.Name)
+}
+
 func TestNewRepositoryWithDefault(t *testing.T) {
 	ctx := context.Background()
-	fakeClient := fake.NewSimpleClientset(createTestContext()...)
+	fakeClient, err := test.NewFakeClient(createTestContext()...)
+	require.NoError(t, err)
 	repo, err := New(ctx, fakeClient, "test")
 	require.NoError(t, err)
 	list, err := repo.List(ctx)
@@ -268,6 +268,11 @@ func applyPlatformSpec(source *v1.IntegrationPlatform, target *v1.IntegrationPla
 		copy(target.Status.Build.Maven.Extension, source.Status.Build.Maven.Extension)
 	}
 
+	if target.Status.Build.Maven.Cache == nil && source.Status.Build.Maven.Cache != nil {
+		log.Debugf("Integration Platform %s [%s]: setting Maven cache", target.Name, target.Namespace)
+		target.Status.Build.Maven.Cache = source.Status.Build.Maven.Cache.DeepCopy()
+	}
+
 	if target.Status.Build.Registry.Address == "" && source.Status.Build.Registry.Address != "" {
 		log.Debugf("Integration Platform %s [%s]: setting registry", target.Name, target.Namespace)
 		source.Status.Build.Registry.DeepCopyInto(&target.Status.Build.Registry)
@@ -290,6 +295,16 @@ func applyPlatformSpec(source *v1.IntegrationPlatform, target *v1.IntegrationPla
 		target.Status.Build.MaxRunningBuilds = source.Status.Build.MaxRunningBuilds
 	}
 
+	if target.Status.Build.MaxRunningBuildsPerNamespace <= 0 {
+		log.Debugf("Integration Platform %s [%s]: setting max running builds per namespace", target.Name, target.Namespace)
+		target.Status.Build.MaxRunningBuildsPerNamespace = source.Status.Build.MaxRunningBuildsPerNamespace
+	}
+
+	if target.Status.Build.Priority == 0 {
+		log.Debugf("Integration Platform %s [%s]: setting build priority", target.Name, target.Namespace)
+		target.Status.Build.Priority = source.Status.Build.Priority
+	}
+
 	if len(target.Status.Kamelet.Repositories) == 0 {
 		log.Debugf("Integration Platform %s [%s]: setting kamelet repositories", target.Name, target.Namespace)
 		target.Status.Kamelet.Repositories = source.Status.Kamelet.Repositories
@@ -94,6 +94,13 @@ func GetOperatorNamespace() string {
 	return ""
 }
 
+// IsOperatorNamespace returns true if the given namespace is the namespace where the current operator is located,
+// or if that namespace is unknown, e.g., when the operator runs locally.
+func IsOperatorNamespace(namespace string) bool {
+	operatorNamespace := GetOperatorNamespace()
+	return operatorNamespace == "" || namespace == operatorNamespace
+}
+
 // GetOperatorPodName returns the pod that is running the current operator (if any).
 func GetOperatorPodName() string {
 	if podName, envSet := os.LookupEnv(operatorPodNameEnvVariable); envSet {
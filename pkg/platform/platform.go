@@ -89,6 +89,20 @@ func GetForResource(ctx context.Context, c k8sclient.Reader, o k8sclient.Object)
 	return ip, nil
 }
 
+// LookupForOperator returns the platform of the operator namespace, or nil if the operator namespace is unknown,
+// or if it does not contain any platform.
+func LookupForOperator(ctx context.Context, c k8sclient.Reader) (*v1.IntegrationPlatform, error) {
+	operatorNamespace := GetOperatorNamespace()
+	if operatorNamespace == "" {
+		return nil, nil
+	}
+	ip, err := findLocal(ctx, c, operatorNamespace)
+	if err != nil && k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return ip, err
+}
+
 func GetForName(ctx context.Context, c k8sclient.Reader, namespace string, name string) (*v1.IntegrationPlatform, error) {
 	return getOrFindAny(ctx, c, namespace, name)
 }
@@ -91,6 +91,11 @@ func ApplyIntegrationProfile(ctx context.Context, c k8sclient.Reader, ip *v1.Int
 		copy(ip.Status.Build.Maven.Extension, profile.Status.Build.Maven.Extension)
 	}
 
+	if profile.Status.Build.Maven.Cache != nil {
+		log.Debugf("Integration Platform %s [%s]: setting Maven cache", ip.Name, ip.Namespace)
+		ip.Status.Build.Maven.Cache = profile.Status.Build.Maven.Cache.DeepCopy()
+	}
+
 	if profile.Status.Build.Registry.Address != "" && profile.Status.Build.Registry.Address != ip.Status.Build.Registry.Address {
 		log.Debugf("Integration Platform %s [%s]: setting registry", ip.Name, ip.Namespace)
 		profile.Status.Build.Registry.DeepCopyInto(&ip.Status.Build.Registry)
@@ -108,6 +113,11 @@ func ApplyIntegrationProfile(ctx context.Context, c k8sclient.Reader, ip *v1.Int
 		ip.Status.Build.Timeout = profile.Status.Build.Timeout
 	}
 
+	if profile.Status.Build.KitRetention != nil {
+		log.Debugf("Integration Platform %s [%s]: setting kit retention policy", ip.Name, ip.Namespace)
+		ip.Status.Build.KitRetention = profile.Status.Build.KitRetention.DeepCopy()
+	}
+
 	if len(profile.Status.Kamelet.Repositories) > 0 {
 		log.Debugf("Integration Platform %s [%s]: setting kamelet repositories", ip.Name, ip.Namespace)
 		ip.Status.Kamelet.Repositories = append(ip.Status.Kamelet.Repositories, profile.Status.Kamelet.Repositories...)
@@ -116,6 +126,28 @@ func ApplyIntegrationProfile(ctx context.Context, c k8sclient.Reader, ip *v1.Int
 	return profile, nil
 }
 
+// GetBuildPriority returns the priority of the builds of the given resource, that is defined by its integration
+// profile or by the given platform. Only the platforms and the integration profiles of the operator namespace are
+// considered, as the ones of the other namespaces are managed by the namespace owners, that could otherwise raise
+// the priority of their own builds. It defaults to the priority of the operator platform.
+func GetBuildPriority(ctx context.Context, c k8sclient.Reader, ip *v1.IntegrationPlatform, o k8sclient.Object) (int32, error) {
+	profile, err := findIntegrationProfile(ctx, c, o)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return 0, err
+	}
+	if profile != nil && profile.Status.Build.Priority != 0 && IsOperatorNamespace(profile.Namespace) {
+		return profile.Status.Build.Priority, nil
+	}
+
+	if ip == nil || !IsOperatorNamespace(ip.Namespace) {
+		ip, err = LookupForOperator(ctx, c)
+		if err != nil || ip == nil {
+			return 0, err
+		}
+	}
+	return ip.Status.Build.Priority, nil
+}
+
 // findIntegrationProfile finds profile from given resource annotations and resolves the profile in given resource namespace or operator namespace as a fallback option.
 func findIntegrationProfile(ctx context.Context, c k8sclient.Reader, o k8sclient.Object) (*v1.IntegrationProfile, error) {
 	if profileName := v1.GetIntegrationProfileAnnotation(o); profileName != "" {
@@ -321,3 +321,49 @@ func TestApplyIntegrationProfileAndRetainPlatformSpec(t *testing.T) {
 	assert.Equal(t, "local_value2", ip.Status.Build.Maven.Properties["global_prop2"])
 	assert.Equal(t, "local_value1", ip.Status.Build.Maven.Properties["local_prop1"])
 }
+
+func TestGetBuildPriority(t *testing.T) {
+	operatorPlatform := v1.NewIntegrationPlatform("operator-namespace", "camel-k")
+	operatorPlatform.Status.Build.Priority = 5
+	localPlatform := v1.NewIntegrationPlatform("ns", "camel-k")
+	localPlatform.Status.Build.Priority = 500
+	operatorProfile := v1.NewIntegrationProfile("operator-namespace", "production")
+	operatorProfile.Status.Build.Priority = 100
+	localProfile := v1.NewIntegrationProfile("ns", "fast")
+	localProfile.Status.Build.Priority = 1000
+
+	c, err := test.NewFakeClient(&operatorPlatform, &localPlatform, &operatorProfile, &localProfile)
+	require.NoError(t, err)
+
+	kit := func(profile string) *v1.IntegrationKit {
+		kit := v1.NewIntegrationKit("ns", "my-kit")
+		if profile != "" {
+			kit.Annotations = map[string]string{v1.IntegrationProfileAnnotation: profile}
+		}
+		return kit
+	}
+
+	// Any platform and profile is considered when the operator namespace is unknown
+	priority, err := GetBuildPriority(context.TODO(), c, &localPlatform, kit("fast"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1000), priority)
+	priority, err = GetBuildPriority(context.TODO(), c, &localPlatform, kit(""))
+	require.NoError(t, err)
+	assert.Equal(t, int32(500), priority)
+
+	t.Setenv(operatorNamespaceEnvVariable, "operator-namespace")
+
+	priority, err = GetBuildPriority(context.TODO(), c, &localPlatform, kit("production"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(100), priority)
+	// The priorities set by the namespace owners are ignored
+	priority, err = GetBuildPriority(context.TODO(), c, &localPlatform, kit("fast"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), priority)
+	priority, err = GetBuildPriority(context.TODO(), c, &localPlatform, kit(""))
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), priority)
+	priority, err = GetBuildPriority(context.TODO(), c, &operatorPlatform, kit(""))
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), priority)
+}
@@ -421,6 +421,10 @@ func (t *builderTrait) builderTask(e *Environment, taskConf *v1.BuildConfigurati
 
 	steps := make([]builder.Step, 0)
 	steps = append(steps, builder.Project.CommonSteps...)
+	// Share the downloaded Maven artifacts across builds
+	if task.Maven.Cache != nil {
+		steps = append(steps, builder.MavenCache.CommonSteps...)
+	}
 
 	// sort steps by phase
 	sort.SliceStable(steps, func(i, j int) bool {
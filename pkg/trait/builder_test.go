@@ -29,6 +29,7 @@ import (
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
+	"github.com/apache/camel-k/v2/pkg/builder"
 	"github.com/apache/camel-k/v2/pkg/util/camel"
 	"github.com/apache/camel-k/v2/pkg/util/defaults"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
@@ -165,6 +166,20 @@ func TestMavenPropertyBuilderTrait(t *testing.T) {
 	assert.Equal(t, "build-time-value1", env.Pipeline[0].Builder.Maven.Properties["build-time-prop1"])
 }
 
+func TestMavenCacheBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategySpectrum, v1.BuildStrategyPod)
+	env.Platform.Status.Build.Maven.Cache = &v1.MavenCacheSpec{MaxSize: "8Gi"}
+	builderTrait := createNominalBuilderTraitTest()
+
+	err := builderTrait.Apply(env)
+
+	require.NoError(t, err)
+	assert.Equal(t, "8Gi", env.Pipeline[0].Builder.Maven.Cache.MaxSize)
+	assert.Contains(t, env.Pipeline[0].Builder.Steps, builder.MavenCache.ConfigureMavenCache.ID())
+	assert.Contains(t, env.Pipeline[0].Builder.Steps, builder.MavenCache.PublishMavenCache.ID())
+	assert.NotContains(t, env.Pipeline[1].Package.Steps, builder.MavenCache.PublishMavenCache.ID())
+}
+
 func createNominalBuilderTraitTest() *builderTrait {
 	builderTrait, _ := newBuilderTrait().(*builderTrait)
 	return builderTrait
@@ -87,13 +87,21 @@ func (t *deploymentTrait) ControllerStrategySelectorOrder() int {
 
 func (t *deploymentTrait) Apply(e *Environment) error {
 	deployment := t.getDeploymentFor(e)
+	name := deployment.Name
+
+	if t.RolloutStrategy != "" {
+		if err := t.configureRollout(e, deployment); err != nil {
+			return err
+		}
+	}
+
 	e.Resources.Add(deployment)
 
 	e.Integration.Status.SetCondition(
 		v1.IntegrationConditionDeploymentAvailable,
 		corev1.ConditionTrue,
 		v1.IntegrationConditionDeploymentAvailableReason,
-		fmt.Sprintf("deployment name is %s", deployment.Name),
+		fmt.Sprintf("deployment name is %s", name),
 	)
 
 	return nil
@@ -0,0 +1,270 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
+	"github.com/apache/camel-k/v2/pkg/util/digest"
+	"github.com/apache/camel-k/v2/pkg/util/envvar"
+)
+
+const (
+	defaultCanaryWeight           = int32(20)
+	defaultRolloutAnalysisSeconds = int32(60)
+)
+
+// configureRollout turns the generated Deployment into the candidate of a progressive rollout, when the
+// stable Deployment runs a different version of the Integration. The stable Deployment is kept as is until
+// the candidate is promoted, or restored when the candidate is aborted.
+// With the blue/green strategy, the Services are switched to the candidate once it's promoted, and switched
+// back once the stable Deployment has been rolled out to the promoted version.
+func (t *deploymentTrait) configureRollout(e *Environment, deployment *appsv1.Deployment) error {
+	// the Pods selected by the Services with the blue/green strategy, that's set by the rollout steps below
+	serving := v1.IntegrationRolloutStable
+	if t.RolloutStrategy == traitv1.RolloutStrategyBlueGreen {
+		deployment.Spec.Template.Labels[v1.IntegrationRolloutLabel] = v1.IntegrationRolloutStable
+		e.PostProcessors = append(e.PostProcessors, func(env *Environment) error {
+			return selectRolloutServices(env, serving)
+		})
+	}
+
+	stable := &appsv1.Deployment{}
+	key := ctrl.ObjectKey{Namespace: deployment.Namespace, Name: deployment.Name}
+	if err := e.Client.Get(e.Ctx, key, stable); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		stable = nil
+	}
+
+	condition := e.Integration.Status.GetCondition(v1.IntegrationConditionRollout)
+	if stable == nil || deploymentDigest(stable) == e.Integration.Status.Digest {
+		promoted := condition != nil && condition.Reason == v1.IntegrationConditionRolloutPromotedReason
+		if promoted && stable != nil && !isRolledOut(stable) {
+			// The candidate keeps serving until the stable Deployment runs the promoted version
+			serving = v1.IntegrationRolloutCandidate
+			e.PostProcessors = append(e.PostProcessors, t.addRolloutCandidateOf(deployment))
+		} else {
+			e.PostActions = append(e.PostActions, deleteRolloutCandidate)
+		}
+		return nil
+	}
+
+	switch {
+	case condition != nil && condition.Reason == v1.IntegrationConditionRolloutAbortedReason:
+		e.PostProcessors = append(e.PostProcessors, func(env *Environment) error {
+			env.Resources.RemoveDeployment(func(d *appsv1.Deployment) bool {
+				return d == deployment
+			})
+			env.Resources.Add(copyLiveDeployment(stable))
+			return nil
+		})
+		e.PostActions = append(e.PostActions, deleteRolloutCandidate)
+	case t.isAnalysisComplete(condition):
+		promoted := condition.Reason == v1.IntegrationConditionRolloutPromotedReason
+		e.Integration.Status.SetCondition(
+			v1.IntegrationConditionRollout,
+			corev1.ConditionTrue,
+			v1.IntegrationConditionRolloutPromotedReason,
+			fmt.Sprintf("version %s promoted", e.Integration.Status.Digest),
+		)
+		serving = v1.IntegrationRolloutCandidate
+		if !promoted && t.RolloutStrategy == traitv1.RolloutStrategyBlueGreen {
+			// The stable Deployment is only rolled out once the Services have been switched to the candidate
+			toRolloutCandidate(deployment, t.candidateReplicas(*deployment.Spec.Replicas))
+			e.PostProcessors = append(e.PostProcessors, func(env *Environment) error {
+				env.Resources.Add(copyLiveDeployment(stable))
+				return nil
+			})
+			break
+		}
+		e.PostProcessors = append(e.PostProcessors, t.addRolloutCandidateOf(deployment))
+	default:
+		if condition == nil {
+			e.Integration.Status.SetCondition(
+				v1.IntegrationConditionRollout,
+				corev1.ConditionFalse,
+				v1.IntegrationConditionRolloutProgressingReason,
+				fmt.Sprintf("rolling out version %s", e.Integration.Status.Digest),
+			)
+		}
+		toRolloutCandidate(deployment, t.candidateReplicas(*deployment.Spec.Replicas))
+		e.PostProcessors = append(e.PostProcessors, func(env *Environment) error {
+			env.Resources.Add(copyLiveDeployment(stable))
+			return nil
+		})
+	}
+
+	return nil
+}
+
+// isAnalysisComplete returns whether the candidate has been ready for the whole analysis period.
+func (t *deploymentTrait) isAnalysisComplete(condition *v1.IntegrationCondition) bool {
+	if condition == nil {
+		return false
+	}
+	if condition.Reason == v1.IntegrationConditionRolloutPromotedReason {
+		return true
+	}
+	if condition.Reason != v1.IntegrationConditionRolloutAnalysisReason {
+		return false
+	}
+
+	analysis := defaultRolloutAnalysisSeconds
+	if t.RolloutAnalysisSeconds != nil {
+		analysis = *t.RolloutAnalysisSeconds
+	}
+	return time.Since(condition.LastUpdateTime.Time) >= time.Duration(analysis)*time.Second
+}
+
+// candidateReplicas returns the number of replicas of the candidate, given the number of replicas of the Integration.
+func (t *deploymentTrait) candidateReplicas(replicas int32) int32 {
+	if t.RolloutStrategy == traitv1.RolloutStrategyBlueGreen {
+		return replicas
+	}
+
+	weight := defaultCanaryWeight
+	if t.CanaryWeight != nil {
+		weight = *t.CanaryWeight
+	}
+	candidate := int32(math.Round(float64(replicas) * float64(weight) / float64(100-weight)))
+	if candidate < 1 {
+		candidate = 1
+	}
+	return candidate
+}
+
+// addRolloutCandidateOf returns a post-processor that keeps the candidate running the promoted version,
+// until the stable Deployment has been entirely rolled out.
+func (t *deploymentTrait) addRolloutCandidateOf(deployment *appsv1.Deployment) func(*Environment) error {
+	return func(env *Environment) error {
+		candidate := deployment.DeepCopy()
+		toRolloutCandidate(candidate, t.candidateReplicas(*deployment.Spec.Replicas))
+		env.Resources.Add(candidate)
+		return nil
+	}
+}
+
+// selectRolloutServices restricts the Services of the Integration to the stable or the candidate Pods, when the stable
+// Deployment has been created with the blue/green strategy.
+func selectRolloutServices(e *Environment, serving string) error {
+	stable := false
+	e.Resources.VisitDeployment(func(d *appsv1.Deployment) {
+		if d.Name == e.Integration.Name && d.Spec.Template.Labels[v1.IntegrationRolloutLabel] == v1.IntegrationRolloutStable {
+			stable = true
+		}
+	})
+	if !stable && serving == v1.IntegrationRolloutStable {
+		return nil
+	}
+
+	e.Resources.VisitService(func(s *corev1.Service) {
+		if s.Spec.Selector[v1.IntegrationLabel] == e.Integration.Name {
+			s.Spec.Selector[v1.IntegrationRolloutLabel] = serving
+		}
+	})
+	return nil
+}
+
+// deleteRolloutCandidate deletes the candidate Deployment, once it's not needed anymore.
+func deleteRolloutCandidate(e *Environment) error {
+	candidate := &appsv1.Deployment{}
+	key := ctrl.ObjectKey{Namespace: e.Integration.Namespace, Name: rolloutCandidateName(e.Integration.Name)}
+	if err := e.Client.Get(e.Ctx, key, candidate); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if err := e.Client.Delete(e.Ctx, candidate); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func rolloutCandidateName(name string) string {
+	return fmt.Sprintf("%s-%s", name, v1.IntegrationRolloutCandidate)
+}
+
+func toRolloutCandidate(deployment *appsv1.Deployment, replicas int32) {
+	deployment.Name = rolloutCandidateName(deployment.Name)
+	deployment.Labels[v1.IntegrationRolloutLabel] = v1.IntegrationRolloutCandidate
+	deployment.Spec.Selector.MatchLabels[v1.IntegrationRolloutLabel] = v1.IntegrationRolloutCandidate
+	deployment.Spec.Template.Labels[v1.IntegrationRolloutLabel] = v1.IntegrationRolloutCandidate
+	deployment.Spec.Replicas = &replicas
+}
+
+// copyLiveDeployment returns the desired state of a live Deployment, so that it can be applied again unchanged.
+func copyLiveDeployment(live *appsv1.Deployment) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            live.Name,
+			Namespace:       live.Namespace,
+			Labels:          copyStringMap(live.Labels),
+			Annotations:     copyStringMap(live.Annotations),
+			OwnerReferences: live.OwnerReferences,
+		},
+		Spec: *live.Spec.DeepCopy(),
+	}
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// deploymentDigest returns the digest of the Integration version run by the Deployment.
+func deploymentDigest(deployment *appsv1.Deployment) string {
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		if v := envvar.Get(c.Env, digest.IntegrationDigestEnvVar); v != nil {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+// isRolledOut returns whether all the replicas of the Deployment run its latest template.
+func isRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas >= replicas &&
+		deployment.Status.Replicas == deployment.Status.UpdatedReplicas &&
+		deployment.Status.AvailableReplicas >= replicas
+}
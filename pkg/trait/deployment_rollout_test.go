@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
+	"github.com/apache/camel-k/v2/pkg/util/digest"
+)
+
+func createRolloutDeploymentTest(strategy traitv1.RolloutStrategyType) (*deploymentTrait, *Environment) {
+	deploymentTrait, environment := createNominalDeploymentTest()
+	deploymentTrait.RolloutStrategy = strategy
+	environment.Integration.Namespace = "namespace"
+	environment.Integration.Status.Phase = v1.IntegrationPhaseRunning
+	environment.Integration.Status.Digest = "new-digest"
+	environment.Resources.Add(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "integration-name",
+			Namespace: "namespace",
+			Labels: map[string]string{
+				v1.IntegrationLabel: "integration-name",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				v1.IntegrationLabel: "integration-name",
+			},
+		},
+	})
+
+	return deploymentTrait, environment
+}
+
+func applyRolloutDeploymentTest(t *testing.T, deploymentTrait *deploymentTrait, environment *Environment) {
+	t.Helper()
+
+	require.NoError(t, deploymentTrait.Apply(environment))
+	for _, processor := range environment.PostProcessors {
+		require.NoError(t, processor(environment))
+	}
+}
+
+func getRolloutDeployment(environment *Environment, name string) *appsv1.Deployment {
+	return environment.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool {
+		return deployment.Name == name
+	})
+}
+
+func TestApplyDeploymentTraitCanaryRollout(t *testing.T) {
+	deploymentTrait, environment := createRolloutDeploymentTest(traitv1.RolloutStrategyCanary)
+
+	applyRolloutDeploymentTest(t, deploymentTrait, environment)
+
+	candidate := getRolloutDeployment(environment, "integration-name-candidate")
+	require.NotNil(t, candidate)
+	assert.Equal(t, int32(1), *candidate.Spec.Replicas)
+	assert.Equal(t, v1.IntegrationRolloutCandidate, candidate.Labels[v1.IntegrationRolloutLabel])
+	assert.Equal(t, v1.IntegrationRolloutCandidate, candidate.Spec.Selector.MatchLabels[v1.IntegrationRolloutLabel])
+	assert.Equal(t, v1.IntegrationRolloutCandidate, candidate.Spec.Template.Labels[v1.IntegrationRolloutLabel])
+
+	// The stable Deployment is applied unchanged
+	stable := getRolloutDeployment(environment, "integration-name")
+	require.NotNil(t, stable)
+	assert.Equal(t, int32(0), *stable.Spec.Replicas)
+	assert.Equal(t, "deployment name is integration-name",
+		environment.Integration.Status.GetCondition(v1.IntegrationConditionDeploymentAvailable).Message)
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionRollout)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionRolloutProgressingReason, condition.Reason)
+}
+
+func TestApplyDeploymentTraitRolloutWaitsForAnalysis(t *testing.T) {
+	deploymentTrait, environment := createRolloutDeploymentTest(traitv1.RolloutStrategyCanary)
+	environment.Integration.Status.SetCondition(v1.IntegrationConditionRollout, corev1.ConditionFalse,
+		v1.IntegrationConditionRolloutAnalysisReason, "1/1 candidate replicas ready")
+
+	applyRolloutDeploymentTest(t, deploymentTrait, environment)
+
+	assert.NotNil(t, getRolloutDeployment(environment, "integration-name-candidate"))
+	assert.Equal(t, v1.IntegrationConditionRolloutAnalysisReason,
+		environment.Integration.Status.GetCondition(v1.IntegrationConditionRollout).Reason)
+}
+
+func TestApplyDeploymentTraitBlueGreenPromotion(t *testing.T) {
+	deploymentTrait, environment := createRolloutDeploymentTest(traitv1.RolloutStrategyBlueGreen)
+	environment.Integration.Status.SetConditions(v1.IntegrationCondition{
+		Type:           v1.IntegrationConditionRollout,
+		Status:         corev1.ConditionFalse,
+		Reason:         v1.IntegrationConditionRolloutAnalysisReason,
+		LastUpdateTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+	})
+
+	applyRolloutDeploymentTest(t, deploymentTrait, environment)
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionRollout)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionRolloutPromotedReason, condition.Reason)
+
+	// The Services are first switched to the candidate, while the stable Deployment is applied unchanged
+	stable := getRolloutDeployment(environment, "integration-name")
+	require.NotNil(t, stable)
+	assert.Equal(t, int32(0), *stable.Spec.Replicas)
+	candidate := getRolloutDeployment(environment, "integration-name-candidate")
+	require.NotNil(t, candidate)
+	assert.Equal(t, int32(3), *candidate.Spec.Replicas)
+	service := environment.Resources.GetServiceForIntegration(environment.Integration)
+	require.NotNil(t, service)
+	assert.Equal(t, v1.IntegrationRolloutCandidate, service.Spec.Selector[v1.IntegrationRolloutLabel])
+
+	// The stable Deployment is then rolled out, while the candidate keeps serving
+	deploymentTrait, environment = createRolloutDeploymentTest(traitv1.RolloutStrategyBlueGreen)
+	environment.Integration.Status.SetCondition(v1.IntegrationConditionRollout, corev1.ConditionTrue,
+		v1.IntegrationConditionRolloutPromotedReason, "version new-digest promoted")
+
+	applyRolloutDeploymentTest(t, deploymentTrait, environment)
+
+	stable = getRolloutDeployment(environment, "integration-name")
+	require.NotNil(t, stable)
+	assert.Equal(t, int32(3), *stable.Spec.Replicas)
+	assert.Equal(t, v1.IntegrationRolloutStable, stable.Spec.Template.Labels[v1.IntegrationRolloutLabel])
+	candidate = getRolloutDeployment(environment, "integration-name-candidate")
+	require.NotNil(t, candidate)
+	assert.Equal(t, int32(3), *candidate.Spec.Replicas)
+	service = environment.Resources.GetServiceForIntegration(environment.Integration)
+	require.NotNil(t, service)
+	assert.Equal(t, v1.IntegrationRolloutCandidate, service.Spec.Selector[v1.IntegrationRolloutLabel])
+
+	// The Services are switched back to the stable Deployment once it's rolled out
+	deploymentTrait, environment = createRolloutDeploymentTest(traitv1.RolloutStrategyBlueGreen)
+	live := &appsv1.Deployment{}
+	require.NoError(t, deploymentTrait.Client.Get(environment.Ctx, ctrl.ObjectKey{Namespace: "namespace", Name: "integration-name"}, live))
+	live.Spec.Replicas = stable.Spec.Replicas
+	live.Spec.Template = stable.Spec.Template
+	live.Spec.Template.Spec.Containers = []corev1.Container{{
+		Name: "integration",
+		Env:  []corev1.EnvVar{{Name: digest.IntegrationDigestEnvVar, Value: "new-digest"}},
+	}}
+	require.NoError(t, deploymentTrait.Client.Update(environment.Ctx, live))
+	live.Status = appsv1.DeploymentStatus{ObservedGeneration: live.Generation, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}
+	require.NoError(t, deploymentTrait.Client.Status().Update(environment.Ctx, live))
+	environment.Integration.Status.SetCondition(v1.IntegrationConditionRollout, corev1.ConditionTrue,
+		v1.IntegrationConditionRolloutPromotedReason, "version new-digest promoted")
+
+	applyRolloutDeploymentTest(t, deploymentTrait, environment)
+
+	assert.Nil(t, getRolloutDeployment(environment, "integration-name-candidate"))
+	service = environment.Resources.GetServiceForIntegration(environment.Integration)
+	require.NotNil(t, service)
+	assert.Equal(t, v1.IntegrationRolloutStable, service.Spec.Selector[v1.IntegrationRolloutLabel])
+}
+
+func TestApplyDeploymentTraitRolloutAborted(t *testing.T) {
+	deploymentTrait, environment := createRolloutDeploymentTest(traitv1.RolloutStrategyCanary)
+	environment.Integration.Status.SetCondition(v1.IntegrationConditionRollout, corev1.ConditionFalse,
+		v1.IntegrationConditionRolloutAbortedReason, "candidate deployment integration-name-candidate failed")
+
+	applyRolloutDeploymentTest(t, deploymentTrait, environment)
+
+	assert.Nil(t, getRolloutDeployment(environment, "integration-name-candidate"))
+	stable := getRolloutDeployment(environment, "integration-name")
+	require.NotNil(t, stable)
+	assert.Equal(t, int32(0), *stable.Spec.Replicas)
+}
+
+func TestDeploymentTraitCandidateReplicas(t *testing.T) {
+	deploymentTrait, _ := createNominalDeploymentTest()
+
+	deploymentTrait.RolloutStrategy = traitv1.RolloutStrategyCanary
+	assert.Equal(t, int32(1), deploymentTrait.candidateReplicas(1))
+	assert.Equal(t, int32(1), deploymentTrait.candidateReplicas(4))
+	assert.Equal(t, int32(2), deploymentTrait.candidateReplicas(8))
+
+	weight := int32(50)
+	deploymentTrait.CanaryWeight = &weight
+	assert.Equal(t, int32(8), deploymentTrait.candidateReplicas(8))
+
+	deploymentTrait.RolloutStrategy = traitv1.RolloutStrategyBlueGreen
+	assert.Equal(t, int32(5), deploymentTrait.candidateReplicas(5))
+}
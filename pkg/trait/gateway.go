@@ -0,0 +1,311 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+const (
+	gatewayTraitID = "gateway"
+
+	httpRouteKind = "HTTPRoute"
+	grpcRouteKind = "GRPCRoute"
+
+	defaultGatewayBackendPort = 80
+)
+
+var (
+	// gatewayAPIGroupVersion is the Gateway API version the routes are created with.
+	gatewayAPIGroupVersion = schema.GroupVersion{Group: "gateway.networking.k8s.io", Version: "v1"}
+
+	gatewayPathMatchTypes = []string{"Exact", "PathPrefix", "RegularExpression"}
+)
+
+type gatewayTrait struct {
+	BaseTrait
+	traitv1.GatewayTrait `property:",squash"`
+}
+
+func newGatewayTrait() Trait {
+	return &gatewayTrait{
+		BaseTrait: NewBaseTrait(gatewayTraitID, 2410),
+	}
+}
+
+func (t *gatewayTrait) Configure(e *Environment) (bool, *TraitCondition, error) {
+	if e.Integration == nil {
+		return false, nil, nil
+	}
+	if !e.IntegrationInRunningPhases() {
+		return false, nil, nil
+	}
+	if !pointer.BoolDeref(t.Enabled, true) {
+		return false, NewIntegrationCondition(
+			v1.IntegrationConditionExposureAvailable,
+			corev1.ConditionFalse,
+			v1.IntegrationConditionGatewayNotAvailableReason,
+			"explicitly disabled",
+		), nil
+	}
+
+	if pointer.BoolDeref(t.Auto, true) {
+		if t.Gateway == "" || e.Resources.GetUserServiceForIntegration(e.Integration) == nil {
+			return false, nil, nil
+		}
+		installed, err := kubernetes.IsAPIResourceInstalled(e.Client, gatewayAPIGroupVersion.String(), httpRouteKind)
+		if err != nil {
+			return false, nil, err
+		}
+		if !installed {
+			return false, NewIntegrationCondition(
+				v1.IntegrationConditionExposureAvailable,
+				corev1.ConditionFalse,
+				v1.IntegrationConditionGatewayNotAvailableReason,
+				"the Gateway API isn't installed in the cluster",
+			), nil
+		}
+	}
+
+	return true, nil, nil
+}
+
+func (t *gatewayTrait) Apply(e *Environment) error {
+	service := e.Resources.GetUserServiceForIntegration(e.Integration)
+	if service == nil {
+		return errors.New("cannot Apply gateway trait: no target service")
+	}
+	if t.Gateway == "" {
+		return errors.New("cannot Apply gateway trait: no parent gateway")
+	}
+
+	parentRef := map[string]interface{}{
+		"name": t.Gateway,
+	}
+	if ns, name, ok := strings.Cut(t.Gateway, "/"); ok {
+		parentRef["namespace"] = ns
+		parentRef["name"] = name
+	}
+	if t.SectionName != "" {
+		parentRef["sectionName"] = t.SectionName
+	}
+
+	port := int64(defaultGatewayBackendPort)
+	if len(service.Spec.Ports) > 0 {
+		port = int64(service.Spec.Ports[0].Port)
+	}
+	backends, err := t.backendRefs(service.Name, port)
+	if err != nil {
+		return err
+	}
+	headers, err := t.headerMatches()
+	if err != nil {
+		return err
+	}
+
+	httpRule, err := t.httpRouteRule(headers, backends)
+	if err != nil {
+		return err
+	}
+	httpRoute := t.route(e, service, httpRouteKind, parentRef, httpRule)
+	e.Resources.Add(httpRoute)
+
+	if pointer.BoolDeref(t.GRPC, false) {
+		grpcRule := map[string]interface{}{
+			"backendRefs": backends,
+		}
+		if len(headers) > 0 {
+			grpcRule["matches"] = []interface{}{
+				map[string]interface{}{"headers": headers},
+			}
+		}
+		e.Resources.Add(t.route(e, service, grpcRouteKind, parentRef, grpcRule))
+	}
+
+	message := fmt.Sprintf("%s(%s) -> %s(%d)", httpRoute.GetName(), strings.Join(t.Hosts, ","), service.Name, port)
+	e.Integration.Status.SetCondition(
+		v1.IntegrationConditionExposureAvailable,
+		corev1.ConditionTrue,
+		v1.IntegrationConditionGatewayAvailableReason,
+		message,
+	)
+
+	return nil
+}
+
+func (t *gatewayTrait) route(e *Environment, service *corev1.Service, kind string, parentRef map[string]interface{}, rule map[string]interface{}) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+		"rules":      []interface{}{rule},
+	}
+	if len(t.Hosts) > 0 {
+		hostnames := make([]interface{}, 0, len(t.Hosts))
+		for _, h := range t.Hosts {
+			hostnames = append(hostnames, h)
+		}
+		spec["hostnames"] = hostnames
+	}
+
+	route := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	route.SetGroupVersionKind(gatewayAPIGroupVersion.WithKind(kind))
+	route.SetName(service.Name)
+	route.SetNamespace(service.Namespace)
+	route.SetLabels(map[string]string{
+		v1.IntegrationLabel: e.Integration.Name,
+	})
+
+	return route
+}
+
+func (t *gatewayTrait) httpRouteRule(headers []interface{}, backends []interface{}) (map[string]interface{}, error) {
+	paths := t.Paths
+	if len(paths) == 0 {
+		paths = []string{"/"}
+	}
+	matches := make([]interface{}, 0, len(paths))
+	for _, p := range paths {
+		matchType, value := "PathPrefix", p
+		if prefix, v, ok := strings.Cut(p, ":"); ok && isGatewayPathMatchType(prefix) {
+			matchType, value = prefix, v
+		}
+		if value == "" {
+			return nil, fmt.Errorf("invalid path match %q, expected format is [<Exact|PathPrefix|RegularExpression>:]<path>", p)
+		}
+		match := map[string]interface{}{
+			"path": map[string]interface{}{
+				"type":  matchType,
+				"value": value,
+			},
+		}
+		if len(headers) > 0 {
+			match["headers"] = headers
+		}
+		matches = append(matches, match)
+	}
+
+	rule := map[string]interface{}{
+		"matches":     matches,
+		"backendRefs": backends,
+	}
+
+	timeouts := map[string]interface{}{}
+	if t.RequestTimeout != "" {
+		if _, err := time.ParseDuration(t.RequestTimeout); err != nil {
+			return nil, fmt.Errorf("invalid request timeout %q: %w", t.RequestTimeout, err)
+		}
+		timeouts["request"] = t.RequestTimeout
+	}
+	if t.BackendRequestTimeout != "" {
+		if _, err := time.ParseDuration(t.BackendRequestTimeout); err != nil {
+			return nil, fmt.Errorf("invalid backend request timeout %q: %w", t.BackendRequestTimeout, err)
+		}
+		timeouts["backendRequest"] = t.BackendRequestTimeout
+	}
+	if len(timeouts) > 0 {
+		rule["timeouts"] = timeouts
+	}
+
+	return rule, nil
+}
+
+// headerMatches parses the header matches expressed as `<name>=<value>`, or `<name>~=<regex>`.
+func (t *gatewayTrait) headerMatches() ([]interface{}, error) {
+	headers := make([]interface{}, 0, len(t.Headers))
+	for _, h := range t.Headers {
+		matchType := "Exact"
+		name, value, ok := strings.Cut(h, "~=")
+		if ok {
+			matchType = "RegularExpression"
+		} else {
+			name, value, ok = strings.Cut(h, "=")
+		}
+		if !ok || name == "" || value == "" {
+			return nil, fmt.Errorf("could not parse header match %q, expected format is <name>=<value> or <name>~=<regex>", h)
+		}
+		headers = append(headers, map[string]interface{}{
+			"type":  matchType,
+			"name":  name,
+			"value": value,
+		})
+	}
+
+	return headers, nil
+}
+
+// backendRefs returns the integration Service backend, followed by the additional backends
+// expressed as `<service>[:<port>]=<weight>`.
+func (t *gatewayTrait) backendRefs(service string, port int64) ([]interface{}, error) {
+	backends := []interface{}{
+		map[string]interface{}{
+			"name":   service,
+			"port":   port,
+			"weight": int64(pointer.Int32Deref(t.Weight, 1)),
+		},
+	}
+	for _, b := range t.Backends {
+		ref, w, ok := strings.Cut(b, "=")
+		if !ok || ref == "" {
+			return nil, fmt.Errorf("could not parse backend %q, expected format is <service>[:<port>]=<weight>", b)
+		}
+		weight, err := strconv.ParseInt(w, 10, 32)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q for backend %q, must be a non-negative number", w, b)
+		}
+		name, p, hasPort := strings.Cut(ref, ":")
+		backendPort := int64(defaultGatewayBackendPort)
+		if hasPort {
+			backendPort, err = strconv.ParseInt(p, 10, 32)
+			if err != nil || backendPort < 1 || backendPort > 65535 {
+				return nil, fmt.Errorf("invalid port %q for backend %q", p, b)
+			}
+		}
+		backends = append(backends, map[string]interface{}{
+			"name":   name,
+			"port":   backendPort,
+			"weight": weight,
+		})
+	}
+
+	return backends, nil
+}
+
+func isGatewayPathMatchType(matchType string) bool {
+	for _, t := range gatewayPathMatchTypes {
+		if t == matchType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+func TestConfigureGatewayTraitDoesSucceed(t *testing.T) {
+	gatewayTrait, environment := createNominalGatewayTest(t)
+	configured, condition, err := gatewayTrait.Configure(environment)
+
+	assert.True(t, configured)
+	require.NoError(t, err)
+	assert.Nil(t, condition)
+}
+
+func TestConfigureGatewayTraitWithoutParentGateway(t *testing.T) {
+	gatewayTrait, environment := createNominalGatewayTest(t)
+	gatewayTrait.Gateway = ""
+
+	configured, condition, err := gatewayTrait.Configure(environment)
+	assert.False(t, configured)
+	require.NoError(t, err)
+	assert.Nil(t, condition)
+}
+
+func TestConfigureGatewayTraitWithoutGatewayAPI(t *testing.T) {
+	gatewayTrait, environment := createNominalGatewayTest(t)
+	// disable the gateway api
+	fakeClient := environment.Client.(*test.FakeClient) //nolint
+	fakeClient.DisableAPIGroupDiscovery("gateway.networking.k8s.io/v1")
+
+	configured, condition, err := gatewayTrait.Configure(environment)
+	assert.False(t, configured)
+	require.NoError(t, err)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1.IntegrationConditionGatewayNotAvailableReason, condition.reason)
+}
+
+func TestConfigureDisabledGatewayTraitDoesNotSucceed(t *testing.T) {
+	gatewayTrait, environment := createNominalGatewayTest(t)
+	gatewayTrait.Enabled = pointer.Bool(false)
+
+	configured, condition, err := gatewayTrait.Configure(environment)
+	assert.False(t, configured)
+	require.NoError(t, err)
+	require.NotNil(t, condition)
+	assert.Equal(t, "explicitly disabled", condition.message)
+}
+
+func TestApplyGatewayTraitWithDefaults(t *testing.T) {
+	gatewayTrait, environment := createNominalGatewayTest(t)
+
+	err := gatewayTrait.Apply(environment)
+	require.NoError(t, err)
+
+	route := getGatewayRoute(environment, "HTTPRoute")
+	require.NotNil(t, route)
+	assert.Equal(t, "gateway.networking.k8s.io/v1", route.GetAPIVersion())
+	assert.Equal(t, "service-name", route.GetName())
+	assert.Equal(t, "namespace", route.GetNamespace())
+	assert.Equal(t, "integration-name", route.GetLabels()[v1.IntegrationLabel])
+
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "my-gateway"}}, parentRefs)
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	require.Len(t, rules, 1)
+	rule, _ := rules[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/"}},
+	}, rule["matches"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "service-name", "port": int64(80), "weight": int64(1)},
+	}, rule["backendRefs"])
+	assert.NotContains(t, rule, "timeouts")
+
+	assert.Nil(t, getGatewayRoute(environment, "GRPCRoute"))
+
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionExposureAvailable)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionGatewayAvailableReason, condition.Reason)
+	assert.Equal(t, "service-name() -> service-name(80)", condition.Message)
+}
+
+func TestApplyGatewayTraitWithMatchesAndBackends(t *testing.T) {
+	gatewayTrait, environment := createNominalGatewayTest(t)
+	gatewayTrait.Gateway = "infra/my-gateway"
+	gatewayTrait.SectionName = "https"
+	gatewayTrait.Hosts = []string{"api.example.com"}
+	gatewayTrait.Paths = []string{"/api", "Exact:/health"}
+	gatewayTrait.Headers = []string{"x-version=v2", "x-tenant~=acme-.*"}
+	gatewayTrait.Weight = pointer.Int32(90)
+	gatewayTrait.Backends = []string{"other-integration=10", "legacy:8080=0"}
+	gatewayTrait.RequestTimeout = "30s"
+	gatewayTrait.BackendRequestTimeout = "10s"
+	gatewayTrait.GRPC = pointer.Bool(true)
+
+	err := gatewayTrait.Apply(environment)
+	require.NoError(t, err)
+
+	route := getGatewayRoute(environment, "HTTPRoute")
+	require.NotNil(t, route)
+
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"namespace": "infra", "name": "my-gateway", "sectionName": "https"},
+	}, parentRefs)
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"api.example.com"}, hostnames)
+
+	headers := []interface{}{
+		map[string]interface{}{"type": "Exact", "name": "x-version", "value": "v2"},
+		map[string]interface{}{"type": "RegularExpression", "name": "x-tenant", "value": "acme-.*"},
+	}
+	backends := []interface{}{
+		map[string]interface{}{"name": "service-name", "port": int64(80), "weight": int64(90)},
+		map[string]interface{}{"name": "other-integration", "port": int64(80), "weight": int64(10)},
+		map[string]interface{}{"name": "legacy", "port": int64(8080), "weight": int64(0)},
+	}
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	require.Len(t, rules, 1)
+	rule, _ := rules[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/api"}, "headers": headers},
+		map[string]interface{}{"path": map[string]interface{}{"type": "Exact", "value": "/health"}, "headers": headers},
+	}, rule["matches"])
+	assert.Equal(t, backends, rule["backendRefs"])
+	assert.Equal(t, map[string]interface{}{"request": "30s", "backendRequest": "10s"}, rule["timeouts"])
+
+	grpcRoute := getGatewayRoute(environment, "GRPCRoute")
+	require.NotNil(t, grpcRoute)
+	grpcRules, _, _ := unstructured.NestedSlice(grpcRoute.Object, "spec", "rules")
+	require.Len(t, grpcRules, 1)
+	grpcRule, _ := grpcRules[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"headers": headers}}, grpcRule["matches"])
+	assert.Equal(t, backends, grpcRule["backendRefs"])
+	assert.NotContains(t, grpcRule, "timeouts")
+}
+
+func TestApplyGatewayTraitWithInvalidSettings(t *testing.T) {
+	for _, configure := range []func(*gatewayTrait){
+		func(t *gatewayTrait) { t.Headers = []string{"x-version"} },
+		func(t *gatewayTrait) { t.Paths = []string{"Exact:"} },
+		func(t *gatewayTrait) { t.Backends = []string{"other"} },
+		func(t *gatewayTrait) { t.Backends = []string{"other=-1"} },
+		func(t *gatewayTrait) { t.Backends = []string{"other:http=1"} },
+		func(t *gatewayTrait) { t.RequestTimeout = "soon" },
+	} {
+		gatewayTrait, environment := createNominalGatewayTest(t)
+		configure(gatewayTrait)
+		require.Error(t, gatewayTrait.Apply(environment))
+	}
+}
+
+func getGatewayRoute(environment *Environment, kind string) *unstructured.Unstructured {
+	for _, r := range environment.Resources.Items() {
+		if u, ok := r.(*unstructured.Unstructured); ok && u.GetKind() == kind {
+			return u
+		}
+	}
+	return nil
+}
+
+func createNominalGatewayTest(t *testing.T) (*gatewayTrait, *Environment) {
+	t.Helper()
+
+	client, err := test.NewFakeClient()
+	require.NoError(t, err)
+
+	trait, _ := newGatewayTrait().(*gatewayTrait)
+	trait.Gateway = "my-gateway"
+
+	environment := &Environment{
+		Catalog: NewCatalog(nil),
+		Client:  client,
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "integration-name",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(
+			&corev1.Service{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Service",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service-name",
+					Namespace: "namespace",
+					Labels: map[string]string{
+						v1.IntegrationLabel:             "integration-name",
+						"camel.apache.org/service.type": v1.ServiceTypeUser,
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "http", Port: 80},
+					},
+				},
+			},
+		),
+	}
+
+	return trait, environment
+}
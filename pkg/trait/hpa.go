@@ -0,0 +1,324 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
+)
+
+const (
+	defaultHPAMinReplicas    = int32(1)
+	defaultHPAMaxReplicas    = int32(10)
+	defaultHPACPUUtilization = int32(80)
+)
+
+type hpaTrait struct {
+	BaseTrait
+	traitv1.HPATrait `property:",squash"`
+}
+
+func newHPATrait() Trait {
+	return &hpaTrait{
+		BaseTrait: NewBaseTrait("hpa", 1150),
+	}
+}
+
+func (t *hpaTrait) Configure(e *Environment) (bool, *TraitCondition, error) {
+	if e.Integration == nil || !pointer.BoolDeref(t.Enabled, false) {
+		return false, nil, nil
+	}
+	if !e.IntegrationInPhase(v1.IntegrationPhaseInitialization) && !e.IntegrationInRunningPhases() {
+		return false, nil, nil
+	}
+
+	if t.getMinReplicas() < 1 {
+		return false, nil, fmt.Errorf("the minimum number of replicas must be greater than 0")
+	}
+	if t.getMaxReplicas() < t.getMinReplicas() {
+		return false, nil, fmt.Errorf("the maximum number of replicas can't be lower than the minimum number of replicas")
+	}
+
+	if e.IntegrationInRunningPhases() {
+		strategy, err := e.DetermineControllerStrategy()
+		if err != nil {
+			return false, nil, fmt.Errorf("unable to determine the controller strategy")
+		}
+		if strategy != ControllerStrategyDeployment {
+			return false, NewIntegrationCondition(
+				v1.IntegrationConditionTraitInfo,
+				corev1.ConditionTrue,
+				traitConfigurationReason,
+				fmt.Sprintf("horizontal pod autoscaling isn't supported with %s controller strategy", strategy),
+			), nil
+		}
+	}
+
+	return true, nil, nil
+}
+
+func (t *hpaTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1.IntegrationPhaseInitialization) {
+		return t.initializeReplicas(e)
+	}
+
+	hpa, err := t.horizontalPodAutoscalerFor(e)
+	if err != nil {
+		return err
+	}
+	e.Resources.Add(hpa)
+
+	return nil
+}
+
+// initializeReplicas sets the replicas of the scaled resource, as the autoscaler is disabled
+// when the scale sub-resource reports no replicas.
+func (t *hpaTrait) initializeReplicas(e *Environment) error {
+	target := t.getScaleTargetRef(e)
+
+	var replicas *int32
+	scaled := v1.SchemeGroupVersion.WithResource("integrations").GroupResource()
+	if target.Kind == v1.PipeKind {
+		pipe := v1.NewPipe(e.Integration.Namespace, target.Name)
+		if err := e.Client.Get(e.Ctx, ctrl.ObjectKeyFromObject(&pipe), &pipe); err != nil {
+			return err
+		}
+		replicas = pipe.Spec.Replicas
+		scaled = v1.SchemeGroupVersion.WithResource("pipes").GroupResource()
+	} else {
+		replicas = e.Integration.Spec.Replicas
+	}
+	if replicas != nil {
+		return nil
+	}
+
+	scalesClient, err := e.Client.ScalesClient()
+	if err != nil {
+		return err
+	}
+	scale := autoscalingv1.Scale{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: target.Name,
+		},
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: t.getMinReplicas(),
+		},
+	}
+	_, err = scalesClient.Scales(e.Integration.Namespace).Update(e.Ctx, scaled, &scale, metav1.UpdateOptions{})
+	return err
+}
+
+func (t *hpaTrait) horizontalPodAutoscalerFor(e *Environment) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	metrics, err := t.getMetrics()
+	if err != nil {
+		return nil, err
+	}
+	behavior, err := t.getBehavior()
+	if err != nil {
+		return nil, err
+	}
+
+	minReplicas := t.getMinReplicas()
+	hpa := autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name,
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: t.getScaleTargetRef(e),
+			MinReplicas:    &minReplicas,
+			MaxReplicas:    t.getMaxReplicas(),
+			Metrics:        metrics,
+			Behavior:       behavior,
+		},
+	}
+
+	return &hpa, nil
+}
+
+// getScaleTargetRef returns the resource the autoscaler scales, that is the Pipe the Integration
+// has been created from if any, so that the Pipe controller doesn't reset the Integration replicas.
+func (t *hpaTrait) getScaleTargetRef(e *Environment) autoscalingv2.CrossVersionObjectReference {
+	for _, o := range e.Integration.OwnerReferences {
+		if o.Kind == v1.PipeKind && strings.HasPrefix(o.APIVersion, v1.SchemeGroupVersion.Group) {
+			return autoscalingv2.CrossVersionObjectReference{
+				APIVersion: o.APIVersion,
+				Kind:       o.Kind,
+				Name:       o.Name,
+			}
+		}
+	}
+	return autoscalingv2.CrossVersionObjectReference{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       v1.IntegrationKind,
+		Name:       e.Integration.Name,
+	}
+}
+
+func (t *hpaTrait) getMetrics() ([]autoscalingv2.MetricSpec, error) {
+	var metrics []autoscalingv2.MetricSpec
+
+	cpu := t.CPUUtilization
+	if cpu == nil && t.MemoryUtilization == nil && len(t.CustomMetrics) == 0 {
+		cpu = pointer.Int32(defaultHPACPUUtilization)
+	}
+	if cpu != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, *cpu))
+	}
+	if t.MemoryUtilization != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *t.MemoryUtilization))
+	}
+
+	for _, m := range t.CustomMetrics {
+		name, value, ok := strings.Cut(m, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("could not parse custom metric %q, expected format is <metric-name>=<average-value>", m)
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the average value of custom metric %q: %w", m, err)
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: name,
+				},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &quantity,
+				},
+			},
+		})
+	}
+
+	return metrics, nil
+}
+
+func resourceMetric(name corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
+
+func (t *hpaTrait) getBehavior() (*autoscalingv2.HorizontalPodAutoscalerBehavior, error) {
+	scaleUp, err := scalingRules(t.ScaleUpStabilizationWindowSeconds, t.ScaleUpPolicies)
+	if err != nil {
+		return nil, err
+	}
+	scaleDown, err := scalingRules(t.ScaleDownStabilizationWindowSeconds, t.ScaleDownPolicies)
+	if err != nil {
+		return nil, err
+	}
+	if scaleUp == nil && scaleDown == nil {
+		return nil, nil
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp:   scaleUp,
+		ScaleDown: scaleDown,
+	}, nil
+}
+
+func scalingRules(window *int32, policies []string) (*autoscalingv2.HPAScalingRules, error) {
+	if window == nil && len(policies) == 0 {
+		return nil, nil
+	}
+
+	rules := autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: window,
+	}
+	for _, p := range policies {
+		policy, err := parseScalingPolicy(p)
+		if err != nil {
+			return nil, err
+		}
+		rules.Policies = append(rules.Policies, policy)
+	}
+
+	return &rules, nil
+}
+
+// parseScalingPolicy parses a scaling policy expressed as `<Pods|Percent>:<value>:<period-seconds>`.
+func parseScalingPolicy(policy string) (autoscalingv2.HPAScalingPolicy, error) {
+	parts := strings.Split(policy, ":")
+	if len(parts) != 3 {
+		return autoscalingv2.HPAScalingPolicy{}, fmt.Errorf("could not parse scaling policy %q, expected format is <Pods|Percent>:<value>:<period-seconds>", policy)
+	}
+
+	policyType := autoscalingv2.HPAScalingPolicyType(parts[0])
+	if policyType != autoscalingv2.PodsScalingPolicy && policyType != autoscalingv2.PercentScalingPolicy {
+		return autoscalingv2.HPAScalingPolicy{}, fmt.Errorf("invalid type %q for scaling policy %q, must be either %s or %s",
+			parts[0], policy, autoscalingv2.PodsScalingPolicy, autoscalingv2.PercentScalingPolicy)
+	}
+	value, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil || value <= 0 {
+		return autoscalingv2.HPAScalingPolicy{}, fmt.Errorf("invalid value %q for scaling policy %q, must be a positive number", parts[1], policy)
+	}
+	period, err := strconv.ParseInt(parts[2], 10, 32)
+	if err != nil || period <= 0 {
+		return autoscalingv2.HPAScalingPolicy{}, fmt.Errorf("invalid period %q for scaling policy %q, must be a positive number of seconds", parts[2], policy)
+	}
+
+	return autoscalingv2.HPAScalingPolicy{
+		Type:          policyType,
+		Value:         int32(value),
+		PeriodSeconds: int32(period),
+	}, nil
+}
+
+func (t *hpaTrait) getMinReplicas() int32 {
+	if t.MinReplicas != nil {
+		return *t.MinReplicas
+	}
+	return defaultHPAMinReplicas
+}
+
+func (t *hpaTrait) getMaxReplicas() int32 {
+	if t.MaxReplicas != nil {
+		return *t.MaxReplicas
+	}
+	return defaultHPAMaxReplicas
+}
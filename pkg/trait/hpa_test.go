@@ -0,0 +1,188 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+func TestConfigureHPATraitDoesSucceed(t *testing.T) {
+	hpaTrait, environment := createHPATest()
+	configured, condition, err := hpaTrait.Configure(environment)
+
+	assert.True(t, configured)
+	require.NoError(t, err)
+	assert.Nil(t, condition)
+}
+
+func TestConfigureHPATraitWithInvalidReplicas(t *testing.T) {
+	hpaTrait, environment := createHPATest()
+	hpaTrait.MinReplicas = pointer.Int32(5)
+	hpaTrait.MaxReplicas = pointer.Int32(2)
+
+	configured, _, err := hpaTrait.Configure(environment)
+	require.Error(t, err)
+	assert.False(t, configured)
+}
+
+func TestConfigureHPATraitWithCronJobStrategy(t *testing.T) {
+	hpaTrait, environment := createHPATest()
+	deployer, _ := newDeployerTrait().(*deployerTrait)
+	deployer.Kind = string(ControllerStrategyCronJob)
+	environment.ConfiguredTraits = []Trait{deployer}
+
+	configured, condition, err := hpaTrait.Configure(environment)
+	require.NoError(t, err)
+	assert.False(t, configured)
+	require.NotNil(t, condition)
+	assert.Contains(t, condition.message, "isn't supported with cron-job controller strategy")
+}
+
+func TestHPAIsCreatedWithDefaults(t *testing.T) {
+	hpaTrait, environment := createHPATest()
+
+	hpa := hpaCreatedCheck(t, hpaTrait, environment)
+	assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+	assert.Equal(t, autoscalingv2.CrossVersionObjectReference{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       v1.IntegrationKind,
+		Name:       "integration-name",
+	}, hpa.Spec.ScaleTargetRef)
+	require.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(t, int32(80), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	assert.Nil(t, hpa.Spec.Behavior)
+}
+
+func TestHPAIsCreatedWithMetricsAndBehavior(t *testing.T) {
+	hpaTrait, environment := createHPATest()
+	hpaTrait.MinReplicas = pointer.Int32(2)
+	hpaTrait.MaxReplicas = pointer.Int32(5)
+	hpaTrait.MemoryUtilization = pointer.Int32(70)
+	hpaTrait.CustomMetrics = []string{"application_camel_exchanges_inflight=10"}
+	hpaTrait.ScaleDownStabilizationWindowSeconds = pointer.Int32(120)
+	hpaTrait.ScaleDownPolicies = []string{"Pods:1:60", "Percent:10:30"}
+
+	hpa := hpaCreatedCheck(t, hpaTrait, environment)
+	assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+
+	require.Len(t, hpa.Spec.Metrics, 2)
+	assert.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(t, int32(70), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	assert.Equal(t, autoscalingv2.PodsMetricSourceType, hpa.Spec.Metrics[1].Type)
+	assert.Equal(t, "application_camel_exchanges_inflight", hpa.Spec.Metrics[1].Pods.Metric.Name)
+	assert.True(t, resource.MustParse("10").Equal(*hpa.Spec.Metrics[1].Pods.Target.AverageValue))
+
+	require.NotNil(t, hpa.Spec.Behavior)
+	assert.Nil(t, hpa.Spec.Behavior.ScaleUp)
+	assert.Equal(t, int32(120), *hpa.Spec.Behavior.ScaleDown.StabilizationWindowSeconds)
+	assert.Equal(t, []autoscalingv2.HPAScalingPolicy{
+		{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60},
+		{Type: autoscalingv2.PercentScalingPolicy, Value: 10, PeriodSeconds: 30},
+	}, hpa.Spec.Behavior.ScaleDown.Policies)
+}
+
+func TestHPATargetsPipe(t *testing.T) {
+	hpaTrait, environment := createHPATest()
+	environment.Integration.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.PipeKind,
+			Name:       "my-pipe",
+		},
+	}
+
+	hpa := hpaCreatedCheck(t, hpaTrait, environment)
+	assert.Equal(t, v1.PipeKind, hpa.Spec.ScaleTargetRef.Kind)
+	assert.Equal(t, "my-pipe", hpa.Spec.ScaleTargetRef.Name)
+}
+
+func TestHPAWithInvalidSettings(t *testing.T) {
+	hpaTrait, environment := createHPATest()
+	hpaTrait.CustomMetrics = []string{"my-metric"}
+	require.Error(t, hpaTrait.Apply(environment))
+
+	hpaTrait, environment = createHPATest()
+	hpaTrait.ScaleUpPolicies = []string{"Replicas:1:60"}
+	err := hpaTrait.Apply(environment)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be either Pods or Percent")
+
+	hpaTrait, environment = createHPATest()
+	hpaTrait.ScaleUpPolicies = []string{"Pods:1"}
+	require.Error(t, hpaTrait.Apply(environment))
+}
+
+func hpaCreatedCheck(t *testing.T, hpaTrait *hpaTrait, environment *Environment) *autoscalingv2.HorizontalPodAutoscaler {
+	t.Helper()
+
+	err := hpaTrait.Apply(environment)
+	require.NoError(t, err)
+
+	var hpa *autoscalingv2.HorizontalPodAutoscaler
+	for _, a := range environment.Resources.Items() {
+		if h, ok := a.(*autoscalingv2.HorizontalPodAutoscaler); ok {
+			hpa = h
+		}
+	}
+	require.NotNil(t, hpa)
+	assert.Equal(t, environment.Integration.Name, hpa.Name)
+	assert.Equal(t, environment.Integration.Name, hpa.Labels[v1.IntegrationLabel])
+	return hpa
+}
+
+func createHPATest() (*hpaTrait, *Environment) {
+	trait, _ := newHPATrait().(*hpaTrait)
+	trait.Enabled = pointer.Bool(true)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "integration-name",
+		},
+	}
+
+	environment := &Environment{
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "integration-name",
+				Namespace: "namespace",
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(deployment),
+	}
+
+	return trait, environment
+}
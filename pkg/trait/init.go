@@ -19,14 +19,19 @@ package trait
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
 	"github.com/apache/camel-k/v2/pkg/util"
 	"github.com/apache/camel-k/v2/pkg/util/dsl"
+	"github.com/apache/camel-k/v2/pkg/util/git"
 )
 
 const flowsInternalSourceName = "camel-k-embedded-flow.yaml"
@@ -51,6 +56,13 @@ func (t *initTrait) Configure(e *Environment) (bool, *TraitCondition, error) {
 }
 
 func (t *initTrait) Apply(e *Environment) error {
+	// Sources located in a git repository need to be checked out into generated sources
+	if e.Integration.Spec.Git != nil {
+		if err := t.checkoutGitSources(e); err != nil {
+			return err
+		}
+	}
+
 	// Flows need to be turned into a generated source
 	if len(e.Integration.Spec.Flows) > 0 {
 		content, err := dsl.ToYamlDSL(e.Integration.Spec.Flows)
@@ -91,3 +103,120 @@ func (t *initTrait) Apply(e *Environment) error {
 
 	return nil
 }
+
+// checkoutGitSources checks out the sources located in the git repository, and pins the commit they have
+// been checked out from, so that the Integration can be rebuilt when the ref moves.
+func (t *initTrait) checkoutGitSources(e *Environment) error {
+	spec := e.Integration.Spec.Git
+	if spec.Path != "" && !filepath.IsLocal(spec.Path) {
+		return fmt.Errorf("the git path %q must be relative to the root of the repository", spec.Path)
+	}
+
+	return util.WithTempDir("camel-k-git-", func(dir string) error {
+		commit, err := git.Checkout(e.Ctx, spec.URL, spec.Ref, dir)
+		if err != nil {
+			return err
+		}
+		sources, err := loadGitSources(dir, spec.Path)
+		if err != nil {
+			return err
+		}
+
+		e.Integration.Status.AddOrReplaceGeneratedSources(sources...)
+		e.Integration.Status.GitCommit = commit
+		now := metav1.Now().Rfc3339Copy()
+		e.Integration.Status.GitLastCheckTime = &now
+
+		return nil
+	})
+}
+
+// loadGitSources loads the source file at the given path of the repository checked out in dir, or the source files
+// directly contained in the directory at that path, whose language can be inferred from their extension.
+// Symbolic links are resolved, and rejected when they point outside the repository.
+func loadGitSources(dir string, path string) ([]v1.SourceSpec, error) {
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+	location, err := resolveGitPath(root, filepath.Join(root, path), path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find the git path %q: %w", path, err)
+	}
+
+	var files []gitSourceFile
+	switch {
+	case info.Mode().IsRegular():
+		if !isSourceFile(location) {
+			return nil, fmt.Errorf("the language of the git path %q cannot be inferred from its extension", path)
+		}
+		files = append(files, gitSourceFile{name: filepath.Base(path), location: location})
+	case info.IsDir():
+		entries, err := os.ReadDir(location)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !isSourceFile(entry.Name()) {
+				continue
+			}
+			file, err := resolveGitPath(root, filepath.Join(location, entry.Name()), filepath.Join(path, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			if info, err := os.Stat(file); err == nil && info.Mode().IsRegular() {
+				files = append(files, gitSourceFile{name: entry.Name(), location: file})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("the git path %q is neither a file nor a directory", path)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no sources found at git path %q", path)
+	}
+
+	sources := make([]v1.SourceSpec, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file.location)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, v1.SourceSpec{
+			DataSpec: v1.DataSpec{
+				Name:    file.name,
+				Content: string(content),
+			},
+		})
+	}
+
+	return sources, nil
+}
+
+// gitSourceFile is a source file of the repository, located at its resolved location.
+type gitSourceFile struct {
+	name     string
+	location string
+}
+
+// resolveGitPath resolves the symbolic links of the given location, and checks it's located in the repository root.
+func resolveGitPath(root string, location string, path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(location)
+	if err != nil {
+		return "", fmt.Errorf("cannot find the git path %q: %w", path, err)
+	}
+	if rel, err := filepath.Rel(root, resolved); err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("the git path %q points outside the repository", path)
+	}
+
+	return resolved, nil
+}
+
+// isSourceFile returns whether the language of the given file can be inferred from its extension.
+func isSourceFile(name string) bool {
+	source := v1.SourceSpec{DataSpec: v1.DataSpec{Name: filepath.Base(name)}}
+	return source.InferLanguage() != ""
+}
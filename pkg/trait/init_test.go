@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/git"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+func createGitInitTest(spec v1.GitSourceSpec) (*initTrait, *Environment) {
+	trait, _ := NewInitTrait().(*initTrait)
+	it := v1.NewIntegration("namespace", "integration-name")
+	it.Spec.Git = &spec
+	it.Status.Phase = v1.IntegrationPhaseInitialization
+
+	return trait, &Environment{
+		Ctx:         context.TODO(),
+		Integration: &it,
+	}
+}
+
+func TestInitTraitChecksOutGitSources(t *testing.T) {
+	allowed := git.AllowedProtocols
+	git.AllowedProtocols = append([]string{"file"}, allowed...)
+	t.Cleanup(func() { git.AllowedProtocols = allowed })
+	repo := test.NewGitRepository(t)
+	commit := repo.Commit(t, map[string]string{
+		"routes/route.yaml":  "- from:\n    uri: timer:tick\n",
+		"routes/Route.java":  "public class Route {}\n",
+		"routes/README.md":   "documentation\n",
+		"routes/sub/ignored": "- from:\n    uri: timer:tock\n",
+	})
+
+	trait, environment := createGitInitTest(v1.GitSourceSpec{URL: repo.URL, Path: "routes"})
+	require.NoError(t, trait.Apply(environment))
+
+	status := environment.Integration.Status
+	assert.Equal(t, commit, status.GitCommit)
+	assert.NotNil(t, status.GitLastCheckTime)
+	require.Len(t, status.GeneratedSources, 2)
+	assert.Equal(t, "Route.java", status.GeneratedSources[0].Name)
+	assert.Equal(t, "route.yaml", status.GeneratedSources[1].Name)
+	assert.Equal(t, "- from:\n    uri: timer:tick\n", status.GeneratedSources[1].Content)
+
+	trait, environment = createGitInitTest(v1.GitSourceSpec{URL: repo.URL, Ref: commit, Path: "routes/route.yaml"})
+	require.NoError(t, trait.Apply(environment))
+	require.Len(t, environment.Integration.Status.GeneratedSources, 1)
+	assert.Equal(t, "route.yaml", environment.Integration.Status.GeneratedSources[0].Name)
+}
+
+func TestInitTraitWithInvalidGitPath(t *testing.T) {
+	allowed := git.AllowedProtocols
+	git.AllowedProtocols = append([]string{"file"}, allowed...)
+	t.Cleanup(func() { git.AllowedProtocols = allowed })
+	repo := test.NewGitRepository(t)
+	repo.Commit(t, map[string]string{"docs/README.md": "documentation\n"})
+
+	trait, environment := createGitInitTest(v1.GitSourceSpec{URL: repo.URL, Path: "../routes"})
+	err := trait.Apply(environment)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be relative to the root of the repository")
+
+	trait, environment = createGitInitTest(v1.GitSourceSpec{URL: repo.URL, Path: "docs"})
+	err = trait.Apply(environment)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no sources found")
+
+	trait, environment = createGitInitTest(v1.GitSourceSpec{URL: repo.URL, Ref: "unknown"})
+	require.Error(t, trait.Apply(environment))
+	assert.Empty(t, environment.Integration.Status.GitCommit)
+}
+
+func TestInitTraitRejectsGitSymlinksOutsideRepository(t *testing.T) {
+	allowed := git.AllowedProtocols
+	git.AllowedProtocols = append([]string{"file"}, allowed...)
+	t.Cleanup(func() { git.AllowedProtocols = allowed })
+	outside := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.yaml"), []byte("secret"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "token"), []byte("secret"), 0o600))
+	repo := test.NewGitRepository(t)
+	repo.Commit(t, map[string]string{"routes/route.yaml": "- from:\n    uri: timer:tick\n"})
+	repo.Symlink(t, "link", outside)
+	repo.Symlink(t, "routes/escape.yaml", filepath.Join(outside, "secret.yaml"))
+	repo.Symlink(t, "alias/route.yaml", "../routes/route.yaml")
+
+	// An intermediate directory symlink
+	for _, path := range []string{"link/secret.yaml", "link", "routes", "routes/escape.yaml"} {
+		trait, environment := createGitInitTest(v1.GitSourceSpec{URL: repo.URL, Path: path})
+		err := trait.Apply(environment)
+		require.Error(t, err, path)
+		assert.Contains(t, err.Error(), "points outside the repository", path)
+		assert.Empty(t, environment.Integration.Status.GeneratedSources, path)
+	}
+
+	trait, environment := createGitInitTest(v1.GitSourceSpec{URL: repo.URL, Path: "link/token"})
+	require.Error(t, trait.Apply(environment))
+	assert.Empty(t, environment.Integration.Status.GeneratedSources)
+
+	// Symlinks within the repository are followed
+	trait, environment = createGitInitTest(v1.GitSourceSpec{URL: repo.URL, Path: "alias"})
+	require.NoError(t, trait.Apply(environment))
+	require.Len(t, environment.Integration.Status.GeneratedSources, 1)
+	assert.Equal(t, "route.yaml", environment.Integration.Status.GeneratedSources[0].Name)
+	assert.Equal(t, "- from:\n    uri: timer:tick\n", environment.Integration.Status.GeneratedSources[0].Content)
+}
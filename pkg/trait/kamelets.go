@@ -61,6 +61,12 @@ func newConfigurationKey(kamelet, configurationID string) configurationKey {
 	}
 }
 
+// kameletListItem is an item of the Kamelet list, where key is the Kamelet name followed by an optional configuration ID.
+type kameletListItem struct {
+	key     string
+	version string
+}
+
 type kameletsTrait struct {
 	BaseTrait
 	traitv1.KameletsTrait `property:",squash"`
@@ -117,7 +123,12 @@ func (t *kameletsTrait) collectKamelets(e *Environment) (map[string]*v1.Kamelet,
 		return nil, err
 	}
 
-	kamelets := make(map[string]*v1.Kamelet)
+	versions, err := t.getKameletVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	collected := make(map[string]*v1.Kamelet)
 	missingKamelets := make([]string, 0)
 	availableKamelets := make([]string, 0)
 
@@ -129,15 +140,37 @@ func (t *kameletsTrait) collectKamelets(e *Environment) (map[string]*v1.Kamelet,
 
 		if kamelet == nil {
 			missingKamelets = append(missingKamelets, key)
-		} else {
-			availableKamelets = append(availableKamelets, key)
-			kamelets[key] = kamelet
+			continue
+		}
+		// Materialize the pinned version, if any, so that its template and dependencies are used
+		kamelet, err = kamelet.CloneWithVersion(versions[key])
+		if err != nil {
+			e.Integration.Status.SetErrorCondition(
+				v1.IntegrationConditionKameletsAvailable,
+				v1.IntegrationConditionKameletsAvailableReason,
+				err,
+			)
+
+			return nil, err
 		}
+		availableKamelets = append(availableKamelets, key)
+		collected[key] = kamelet
 	}
 
 	sort.Strings(availableKamelets)
 	sort.Strings(missingKamelets)
 
+	if message := kamelets.NotReadyMessage(collected); message != "" {
+		e.Integration.Status.SetCondition(
+			v1.IntegrationConditionKameletsAvailable,
+			corev1.ConditionFalse,
+			v1.IntegrationConditionKameletsNotReadyReason,
+			message,
+		)
+
+		return nil, errors.New(message)
+	}
+
 	if len(missingKamelets) > 0 {
 		message := fmt.Sprintf("kamelets [%s] found, kamelets [%s] not found in %s repositories",
 			strings.Join(availableKamelets, ","),
@@ -161,7 +194,7 @@ func (t *kameletsTrait) collectKamelets(e *Environment) (map[string]*v1.Kamelet,
 		fmt.Sprintf("kamelets [%s] found in %s repositories", strings.Join(availableKamelets, ","), repo.String()),
 	)
 
-	return kamelets, nil
+	return collected, nil
 }
 
 func (t *kameletsTrait) addKamelets(e *Environment) error {
@@ -282,10 +315,21 @@ func (t *kameletsTrait) listConfigurationSecrets(e *Environment) ([]string, erro
 	return listConfigurationSecrets, nil
 }
 
-func (t *kameletsTrait) getKameletKeys() []string {
-	answer := make([]string, 0)
+// getListItems returns the items of the Kamelet list, in the format name[/configurationID][@version].
+func (t *kameletsTrait) getListItems() []kameletListItem {
+	answer := make([]kameletListItem, 0)
 	for _, item := range strings.Split(t.List, ",") {
 		i := strings.Trim(item, " \t\"")
+		key, version, _ := strings.Cut(i, "@")
+		answer = append(answer, kameletListItem{key: key, version: version})
+	}
+	return answer
+}
+
+func (t *kameletsTrait) getKameletKeys() []string {
+	answer := make([]string, 0)
+	for _, item := range t.getListItems() {
+		i := item.key
 		if strings.Contains(i, "/") {
 			i = strings.SplitN(i, "/", 2)[0]
 		}
@@ -297,13 +341,43 @@ func (t *kameletsTrait) getKameletKeys() []string {
 	return answer
 }
 
+// getKameletVersions returns the versions pinned in the Kamelet list, keyed by Kamelet name.
+// A Kamelet can only be used with a single version in an Integration.
+func (t *kameletsTrait) getKameletVersions() (map[string]string, error) {
+	versions := make(map[string]string)
+	for _, item := range t.getListItems() {
+		name, _, _ := strings.Cut(item.key, "/")
+		if name == "" {
+			continue
+		}
+		version, ok := versions[name]
+		if ok && version != item.version {
+			return nil, fmt.Errorf("kamelet %s is used with different versions %s", name, describeVersions(version, item.version))
+		}
+		versions[name] = item.version
+	}
+	return versions, nil
+}
+
+func describeVersions(versions ...string) string {
+	res := make([]string, 0, len(versions))
+	for _, v := range versions {
+		if v == "" {
+			v = "default"
+		}
+		res = append(res, strconv.Quote(v))
+	}
+	sort.Strings(res)
+	return strings.Join(res, " and ")
+}
+
 func (t *kameletsTrait) getConfigurationKeys() []configurationKey {
 	answer := make([]configurationKey, 0)
 	for _, item := range t.getKameletKeys() {
 		answer = append(answer, newConfigurationKey(item, ""))
 	}
-	for _, item := range strings.Split(t.List, ",") {
-		i := strings.Trim(item, " \t\"")
+	for _, item := range t.getListItems() {
+		i := item.key
 		if strings.Contains(i, "/") {
 			parts := strings.SplitN(i, "/", 2)
 			newKey := newConfigurationKey(parts[0], parts[1])
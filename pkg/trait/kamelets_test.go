@@ -90,14 +90,16 @@ func TestKameletLookup(t *testing.T) {
 			Name:      "timer",
 		},
 		Spec: v1.KameletSpec{
-			Template: templateOrFail(map[string]interface{}{
-				"from": map[string]interface{}{
-					"uri": "timer:tick",
+			KameletSpecBase: v1.KameletSpecBase{
+				Template: templateOrFail(map[string]interface{}{
+					"from": map[string]interface{}{
+						"uri": "timer:tick",
+					},
+				}),
+				Dependencies: []string{
+					"camel:timer",
+					"camel:log",
 				},
-			}),
-			Dependencies: []string{
-				"camel:timer",
-				"camel:log",
 			},
 		},
 	})
@@ -134,18 +136,20 @@ func TestKameletSecondarySourcesLookup(t *testing.T) {
 			Name:      "timer",
 		},
 		Spec: v1.KameletSpec{
-			Template: templateOrFail(map[string]interface{}{
-				"from": map[string]interface{}{
-					"uri": "timer:tick",
-				},
-			}),
-			Sources: []v1.SourceSpec{
-				{
-					DataSpec: v1.DataSpec{
-						Name:    "support.groovy",
-						Content: "from('xxx:xxx').('to:log:info')",
+			KameletSpecBase: v1.KameletSpecBase{
+				Template: templateOrFail(map[string]interface{}{
+					"from": map[string]interface{}{
+						"uri": "timer:tick",
+					},
+				}),
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "support.groovy",
+							Content: "from('xxx:xxx').('to:log:info')",
+						},
+						Language: v1.LanguageGroovy,
 					},
-					Language: v1.LanguageGroovy,
 				},
 			},
 		},
@@ -190,13 +194,15 @@ func TestNonYAMLKameletLookup(t *testing.T) {
 			Name:      "timer",
 		},
 		Spec: v1.KameletSpec{
-			Sources: []v1.SourceSpec{
-				{
-					DataSpec: v1.DataSpec{
-						Name:    "mykamelet.groovy",
-						Content: `from("timer").to("log:info")`,
+			KameletSpecBase: v1.KameletSpecBase{
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "mykamelet.groovy",
+							Content: `from("timer").to("log:info")`,
+						},
+						Type: v1.SourceTypeTemplate,
 					},
-					Type: v1.SourceTypeTemplate,
 				},
 			},
 		},
@@ -233,23 +239,25 @@ func TestMultipleKamelets(t *testing.T) {
 			Name:      "timer",
 		},
 		Spec: v1.KameletSpec{
-			Template: templateOrFail(map[string]interface{}{
-				"from": map[string]interface{}{
-					"uri": "timer:tick",
-				},
-			}),
-			Sources: []v1.SourceSpec{
-				{
-					DataSpec: v1.DataSpec{
-						Name:    "support.groovy",
-						Content: "from('xxx:xxx').('to:log:info')",
+			KameletSpecBase: v1.KameletSpecBase{
+				Template: templateOrFail(map[string]interface{}{
+					"from": map[string]interface{}{
+						"uri": "timer:tick",
+					},
+				}),
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "support.groovy",
+							Content: "from('xxx:xxx').('to:log:info')",
+						},
+						Language: v1.LanguageGroovy,
 					},
-					Language: v1.LanguageGroovy,
 				},
-			},
-			Dependencies: []string{
-				"camel:timer",
-				"camel:xxx",
+				Dependencies: []string{
+					"camel:timer",
+					"camel:xxx",
+				},
 			},
 		},
 	}, &v1.Kamelet{
@@ -258,21 +266,23 @@ func TestMultipleKamelets(t *testing.T) {
 			Name:      "logger",
 		},
 		Spec: v1.KameletSpec{
-			Template: templateOrFail(map[string]interface{}{
-				"from": map[string]interface{}{
-					"uri": "tbd:endpoint",
-					"steps": []interface{}{
-						map[string]interface{}{
-							"to": map[string]interface{}{
-								"uri": "log:info",
+			KameletSpecBase: v1.KameletSpecBase{
+				Template: templateOrFail(map[string]interface{}{
+					"from": map[string]interface{}{
+						"uri": "tbd:endpoint",
+						"steps": []interface{}{
+							map[string]interface{}{
+								"to": map[string]interface{}{
+									"uri": "log:info",
+								},
 							},
 						},
 					},
+				}),
+				Dependencies: []string{
+					"camel:log",
+					"camel:tbd",
 				},
-			}),
-			Dependencies: []string{
-				"camel:log",
-				"camel:tbd",
 			},
 		},
 	})
@@ -327,14 +337,16 @@ func TestKameletConfigLookup(t *testing.T) {
 			Name:      "timer",
 		},
 		Spec: v1.KameletSpec{
-			Template: templateOrFail(map[string]interface{}{
-				"from": map[string]interface{}{
-					"uri": "timer:tick",
+			KameletSpecBase: v1.KameletSpecBase{
+				Template: templateOrFail(map[string]interface{}{
+					"from": map[string]interface{}{
+						"uri": "timer:tick",
+					},
+				}),
+				Dependencies: []string{
+					"camel:timer",
+					"camel:log",
 				},
-			}),
-			Dependencies: []string{
-				"camel:timer",
-				"camel:log",
 			},
 		},
 	}, &corev1.Secret{
@@ -388,14 +400,16 @@ func TestKameletNamedConfigLookup(t *testing.T) {
 			Name:      "timer",
 		},
 		Spec: v1.KameletSpec{
-			Template: templateOrFail(map[string]interface{}{
-				"from": map[string]interface{}{
-					"uri": "timer:tick",
+			KameletSpecBase: v1.KameletSpecBase{
+				Template: templateOrFail(map[string]interface{}{
+					"from": map[string]interface{}{
+						"uri": "timer:tick",
+					},
+				}),
+				Dependencies: []string{
+					"camel:timer",
+					"camel:log",
 				},
-			}),
-			Dependencies: []string{
-				"camel:timer",
-				"camel:log",
 			},
 		},
 	}, &corev1.Secret{
@@ -456,11 +470,13 @@ func TestKameletConditionFalse(t *testing.T) {
 				Name:      "timer",
 			},
 			Spec: v1.KameletSpec{
-				Template: templateOrFail(map[string]interface{}{
-					"from": map[string]interface{}{
-						"uri": "timer:tick",
-					},
-				}),
+				KameletSpecBase: v1.KameletSpecBase{
+					Template: templateOrFail(map[string]interface{}{
+						"from": map[string]interface{}{
+							"uri": "timer:tick",
+						},
+					}),
+				},
 			},
 		})
 
@@ -495,11 +511,13 @@ func TestKameletConditionTrue(t *testing.T) {
 				Name:      "timer",
 			},
 			Spec: v1.KameletSpec{
-				Template: templateOrFail(map[string]interface{}{
-					"from": map[string]interface{}{
-						"uri": "timer:tick",
-					},
-				}),
+				KameletSpecBase: v1.KameletSpecBase{
+					Template: templateOrFail(map[string]interface{}{
+						"from": map[string]interface{}{
+							"uri": "timer:tick",
+						},
+					}),
+				},
 			},
 		},
 		&v1.Kamelet{
@@ -508,11 +526,13 @@ func TestKameletConditionTrue(t *testing.T) {
 				Name:      "none",
 			},
 			Spec: v1.KameletSpec{
-				Template: templateOrFail(map[string]interface{}{
-					"from": map[string]interface{}{
-						"uri": "timer:tick",
-					},
-				}),
+				KameletSpecBase: v1.KameletSpecBase{
+					Template: templateOrFail(map[string]interface{}{
+						"from": map[string]interface{}{
+							"uri": "timer:tick",
+						},
+					}),
+				},
 			},
 		})
 
@@ -576,3 +596,141 @@ func templateOrFail(template map[string]interface{}) *v1.Template {
 	t := v1.Template{RawMessage: data}
 	return &t
 }
+
+func TestKameletConditionNotReady(t *testing.T) {
+	flow := `
+- from:
+    uri: kamelet:timer
+    steps:
+    - to: log:info
+`
+	kamelet := &v1.Kamelet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "timer",
+		},
+		Spec: v1.KameletSpec{
+			KameletSpecBase: v1.KameletSpecBase{
+				Template: templateOrFail(map[string]interface{}{
+					"from": map[string]interface{}{
+						"uri": "timer:tick",
+					},
+				}),
+			},
+		},
+	}
+	kamelet.Status.Phase = v1.KameletPhaseError
+	kamelet.Status.SetCondition(v1.KameletConditionReady, corev1.ConditionFalse,
+		v1.KameletConditionReasonInvalidTemplate, "invalid template: a source Kamelet must produce to kamelet:sink")
+	trait, environment := createKameletsTestEnvironment(flow, kamelet)
+
+	enabled, condition, err := trait.Configure(environment)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Nil(t, condition)
+
+	err = trait.Apply(environment)
+	require.Error(t, err)
+
+	cond := environment.Integration.Status.GetCondition(v1.IntegrationConditionKameletsAvailable)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+	assert.Equal(t, v1.IntegrationConditionKameletsNotReadyReason, cond.Reason)
+	assert.Equal(t, "kamelets [timer (invalid template: a source Kamelet must produce to kamelet:sink)] are not ready", cond.Message)
+}
+
+func versionedKamelet() *v1.Kamelet {
+	return &v1.Kamelet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "timer",
+		},
+		Spec: v1.KameletSpec{
+			KameletSpecBase: v1.KameletSpecBase{
+				Template: templateOrFail(map[string]interface{}{
+					"from": map[string]interface{}{
+						"uri": "timer:tick",
+					},
+				}),
+				Dependencies: []string{
+					"camel:timer",
+				},
+			},
+			Versions: map[string]v1.KameletSpecBase{
+				"v2": {
+					Template: templateOrFail(map[string]interface{}{
+						"from": map[string]interface{}{
+							"uri": "cron:tick",
+						},
+					}),
+					Dependencies: []string{
+						"camel:cron",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestKameletVersionLookup(t *testing.T) {
+	trait, environment := createKameletsTestEnvironment(`
+- from:
+    uri: kamelet:timer?kameletVersion=v2
+    steps:
+    - to: log:info
+`, versionedKamelet())
+	enabled, condition, err := trait.Configure(environment)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Nil(t, condition)
+	assert.Equal(t, "timer@v2", trait.List)
+	assert.Equal(t, []string{"timer"}, trait.getKameletKeys())
+
+	err = trait.Apply(environment)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"camel:cron"}, environment.Integration.Status.Dependencies)
+
+	template := environment.Resources.GetConfigMap(func(cm *corev1.ConfigMap) bool { return cm.Name == "it-kamelet-timer-template" })
+	require.NotNil(t, template)
+	assert.Contains(t, template.Data[contentKey], "cron:tick")
+	bundle := environment.Resources.GetConfigMap(func(cm *corev1.ConfigMap) bool { return cm.Name == "kamelets-bundle-it-001" })
+	require.NotNil(t, bundle)
+	assert.Contains(t, bundle.Data["timer.kamelet.yaml"], "cron:tick")
+	assert.NotContains(t, bundle.Data["timer.kamelet.yaml"], "timer:tick")
+}
+
+func TestKameletVersionNotFound(t *testing.T) {
+	trait, environment := createKameletsTestEnvironment(`
+- from:
+    uri: kamelet:timer?kameletVersion=v3
+    steps:
+    - to: log:info
+`, versionedKamelet())
+	enabled, condition, err := trait.Configure(environment)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Nil(t, condition)
+
+	err = trait.Apply(environment)
+	require.Error(t, err)
+	cond := environment.Integration.Status.GetCondition(v1.IntegrationConditionKameletsAvailable)
+	require.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+	assert.Equal(t, `version "v3" of Kamelet timer not found, available versions are [v2]`, cond.Message)
+}
+
+func TestKameletConflictingVersions(t *testing.T) {
+	trait, environment := createKameletsTestEnvironment(`
+- from:
+    uri: kamelet:timer/a?kameletVersion=v2
+    steps:
+    - to: kamelet:timer/b
+`, versionedKamelet())
+	enabled, condition, err := trait.Configure(environment)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Nil(t, condition)
+
+	err = trait.Apply(environment)
+	require.Error(t, err)
+	assert.Equal(t, `kamelet timer is used with different versions "default" and "v2"`, err.Error())
+}
@@ -0,0 +1,337 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
+	"github.com/apache/camel-k/v2/pkg/metadata"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/uri"
+)
+
+const (
+	networkPolicyTraitID = "network-policy"
+
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+	dnsPort            = 53
+)
+
+var (
+	// uriPortRegexp matches the port of endpoint URIs with an authority, e.g., `https://my-host:8443/api`.
+	uriPortRegexp = regexp.MustCompile(`^[a-zA-Z0-9+.-]+:(?://)?(?:[^@/?]*@)?[^:/?@]+:(\d+)(?:[/?]|$)`)
+
+	// defaultComponentPorts holds the ports components connect to, when the endpoint URI doesn't specify one.
+	defaultComponentPorts = map[string]int{
+		"amqp":    5672,
+		"ftp":     21,
+		"ftps":    990,
+		"http":    80,
+		"https":   443,
+		"kafka":   9092,
+		"mongodb": 27017,
+		"paho":    1883,
+		"sftp":    22,
+		"smtp":    25,
+		"smtps":   465,
+	}
+)
+
+type networkPolicyTrait struct {
+	BaseTrait
+	traitv1.NetworkPolicyTrait `property:",squash"`
+}
+
+func newNetworkPolicyTrait() Trait {
+	return &networkPolicyTrait{
+		BaseTrait: NewBaseTrait(networkPolicyTraitID, 2350),
+	}
+}
+
+// egressRule is the parsed form of the `<component>=<port>[/<protocol>][@<cidr>]` egress rules.
+type egressRule struct {
+	component string
+	port      int
+	protocol  corev1.Protocol
+	cidr      string
+}
+
+func (t *networkPolicyTrait) Configure(e *Environment) (bool, *TraitCondition, error) {
+	if e.Integration == nil || !pointer.BoolDeref(t.Enabled, false) {
+		return false, nil, nil
+	}
+	if !e.IntegrationInRunningPhases() {
+		return false, nil, nil
+	}
+
+	if _, err := t.parseEgressRules(); err != nil {
+		return false, nil, err
+	}
+
+	strategy, err := e.DetermineControllerStrategy()
+	if err != nil {
+		return false, nil, fmt.Errorf("unable to determine the controller strategy")
+	}
+	if strategy == ControllerStrategyKnativeService {
+		return false, NewIntegrationCondition(
+			v1.IntegrationConditionTraitInfo,
+			corev1.ConditionTrue,
+			traitConfigurationReason,
+			fmt.Sprintf("network policy isn't supported with %s controller strategy", strategy),
+		), nil
+	}
+
+	return true, nil, nil
+}
+
+func (t *networkPolicyTrait) Apply(e *Environment) error {
+	sources, err := kubernetes.ResolveIntegrationSources(e.Ctx, e.Client, e.Integration, e.Resources)
+	if err != nil {
+		return err
+	}
+	meta, err := metadata.ExtractAll(e.CamelCatalog, sources)
+	if err != nil {
+		return err
+	}
+
+	policy := networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name,
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				v1.IntegrationLabel: e.Integration.Name,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					v1.IntegrationLabel: e.Integration.Name,
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+
+	if meta.ExposesHTTPServices {
+		if rule := t.ingressRule(e); rule != nil {
+			policy.Spec.Ingress = append(policy.Spec.Ingress, *rule)
+		}
+	}
+
+	if pointer.BoolDeref(t.DefaultDeny, false) {
+		policy.Spec.PolicyTypes = append(policy.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		egress, err := t.egressRules(e, append(meta.FromURIs, meta.ToURIs...))
+		if err != nil {
+			return err
+		}
+		policy.Spec.Egress = egress
+	}
+
+	e.Resources.Add(&policy)
+
+	return nil
+}
+
+// ingressRule allows the traffic towards the target ports of the Integration Service.
+func (t *networkPolicyTrait) ingressRule(e *Environment) *networkingv1.NetworkPolicyIngressRule {
+	service := e.Resources.GetServiceForIntegration(e.Integration)
+	if service == nil {
+		return nil
+	}
+
+	rule := networkingv1.NetworkPolicyIngressRule{}
+	for _, p := range service.Spec.Ports {
+		port := p.TargetPort
+		if port.Type == intstr.Int && port.IntVal == 0 {
+			port = intstr.FromInt(int(p.Port))
+		}
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		rule.Ports = append(rule.Ports, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		})
+	}
+	for _, ns := range t.IngressNamespaces {
+		rule.From = append(rule.From, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					namespaceNameLabel: ns,
+				},
+			},
+		})
+	}
+
+	return &rule
+}
+
+// egressRules allows the traffic towards the DNS, the ports inferred from the endpoint URIs,
+// and the ports of the configured egress rules that apply to the components in use.
+func (t *networkPolicyTrait) egressRules(e *Environment, uris []string) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	udp := corev1.ProtocolUDP
+	tcp := corev1.ProtocolTCP
+	dns := intstr.FromInt(dnsPort)
+	rules := []networkingv1.NetworkPolicyEgressRule{
+		{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &udp, Port: &dns},
+				{Protocol: &tcp, Port: &dns},
+			},
+		},
+	}
+
+	if pointer.BoolDeref(t.AutoEgress, true) {
+		if ports := inferEgressPorts(uris); len(ports) > 0 {
+			rule := networkingv1.NetworkPolicyEgressRule{}
+			for _, p := range ports {
+				port := intstr.FromInt(p)
+				rule.Ports = append(rule.Ports, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &port})
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	configured, err := t.parseEgressRules()
+	if err != nil {
+		return nil, err
+	}
+	components := usedComponents(e.Integration, uris)
+	for _, r := range configured {
+		if r.component != "*" && !components[r.component] {
+			continue
+		}
+		protocol := r.protocol
+		port := intstr.FromInt(r.port)
+		rule := networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &protocol, Port: &port}},
+		}
+		if r.cidr != "" {
+			rule.To = []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: r.cidr}}}
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// parseEgressRules parses the egress rules expressed as `<component>=<port>[/<protocol>][@<cidr>]`.
+func (t *networkPolicyTrait) parseEgressRules() ([]egressRule, error) {
+	rules := make([]egressRule, 0, len(t.Egress))
+	for _, r := range t.Egress {
+		component, value, ok := strings.Cut(r, "=")
+		if !ok || component == "" || value == "" {
+			return nil, fmt.Errorf("could not parse egress rule %q, expected format is <component>=<port>[/<protocol>][@<cidr>]", r)
+		}
+		rule := egressRule{component: component, protocol: corev1.ProtocolTCP}
+		if v, cidr, ok := strings.Cut(value, "@"); ok {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q for egress rule %q: %w", cidr, r, err)
+			}
+			value, rule.cidr = v, cidr
+		}
+		if v, protocol, ok := strings.Cut(value, "/"); ok {
+			rule.protocol = corev1.Protocol(strings.ToUpper(protocol))
+			if rule.protocol != corev1.ProtocolTCP && rule.protocol != corev1.ProtocolUDP && rule.protocol != corev1.ProtocolSCTP {
+				return nil, fmt.Errorf("invalid protocol %q for egress rule %q, must be one of TCP, UDP or SCTP", protocol, r)
+			}
+			value = v
+		}
+		port, err := strconv.Atoi(value)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid port %q for egress rule %q", value, r)
+		}
+		rule.port = port
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// inferEgressPorts returns the sorted ports the given endpoint URIs connect to.
+func inferEgressPorts(uris []string) []int {
+	ports := make(map[int]bool)
+	for _, u := range uris {
+		component := uri.GetComponent(u)
+		if m := uriPortRegexp.FindStringSubmatch(u); m != nil {
+			if port, err := strconv.Atoi(m[1]); err == nil {
+				ports[port] = true
+				continue
+			}
+		}
+		if component == "kafka" {
+			if brokers := uri.GetQueryParameter(u, "brokers"); brokers != "" {
+				for _, broker := range strings.Split(brokers, ",") {
+					if _, p, err := net.SplitHostPort(strings.TrimSpace(broker)); err == nil {
+						if port, err := strconv.Atoi(p); err == nil {
+							ports[port] = true
+						}
+					}
+				}
+				continue
+			}
+		}
+		if port, ok := defaultComponentPorts[component]; ok {
+			ports[port] = true
+		}
+	}
+
+	result := make([]int, 0, len(ports))
+	for p := range ports {
+		result = append(result, p)
+	}
+	sort.Ints(result)
+
+	return result
+}
+
+// usedComponents returns the components used by the endpoint URIs, or declared as dependencies,
+// e.g., by the Kamelets the Integration references.
+func usedComponents(it *v1.Integration, uris []string) map[string]bool {
+	components := make(map[string]bool)
+	for _, u := range uris {
+		if c := uri.GetComponent(u); c != "" {
+			components[c] = true
+		}
+	}
+	for _, d := range it.Status.Dependencies {
+		if strings.HasPrefix(d, "camel:") {
+			components[strings.TrimPrefix(d, "camel:")] = true
+		}
+	}
+
+	return components
+}
@@ -0,0 +1,219 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/camel"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+func TestConfigureNetworkPolicyTraitDoesSucceed(t *testing.T) {
+	networkPolicyTrait, environment := createNetworkPolicyTest(t, `from("timer:tick").to("log:info")`)
+	configured, condition, err := networkPolicyTrait.Configure(environment)
+
+	assert.True(t, configured)
+	require.NoError(t, err)
+	assert.Nil(t, condition)
+}
+
+func TestConfigureNetworkPolicyTraitWithKnativeStrategy(t *testing.T) {
+	networkPolicyTrait, environment := createNetworkPolicyTest(t, `from("timer:tick").to("log:info")`)
+	deployer, _ := newDeployerTrait().(*deployerTrait)
+	deployer.Kind = string(ControllerStrategyKnativeService)
+	environment.ConfiguredTraits = []Trait{deployer}
+
+	configured, condition, err := networkPolicyTrait.Configure(environment)
+	require.NoError(t, err)
+	assert.False(t, configured)
+	require.NotNil(t, condition)
+	assert.Contains(t, condition.message, "isn't supported with knative-service controller strategy")
+}
+
+func TestConfigureNetworkPolicyTraitWithInvalidEgressRules(t *testing.T) {
+	for _, rule := range []string{"kafka", "kafka=", "kafka=abc", "kafka=9092/ICMP", "kafka=9092@10.0.0.0", "kafka=70000"} {
+		networkPolicyTrait, environment := createNetworkPolicyTest(t, `from("timer:tick").to("log:info")`)
+		networkPolicyTrait.Egress = []string{rule}
+
+		configured, _, err := networkPolicyTrait.Configure(environment)
+		require.Error(t, err, rule)
+		assert.False(t, configured)
+	}
+}
+
+func TestNetworkPolicyDeniesIngressWithoutHTTP(t *testing.T) {
+	networkPolicyTrait, environment := createNetworkPolicyTest(t, `from("timer:tick").to("log:info")`)
+
+	policy := networkPolicyCreatedCheck(t, networkPolicyTrait, environment)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, policy.Spec.PolicyTypes)
+	assert.Empty(t, policy.Spec.Ingress)
+	assert.Empty(t, policy.Spec.Egress)
+}
+
+func TestNetworkPolicyAllowsIngressOnServicePorts(t *testing.T) {
+	networkPolicyTrait, environment := createNetworkPolicyTest(t, `from("platform-http:/hello").to("log:info")`)
+	networkPolicyTrait.IngressNamespaces = []string{"ingress-nginx"}
+	environment.Resources.Add(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "integration-name",
+			Labels: map[string]string{
+				v1.IntegrationLabel: "integration-name",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP, TargetPort: intstr.FromString("http")},
+			},
+		},
+	})
+
+	policy := networkPolicyCreatedCheck(t, networkPolicyTrait, environment)
+	require.Len(t, policy.Spec.Ingress, 1)
+	require.Len(t, policy.Spec.Ingress[0].Ports, 1)
+	assert.Equal(t, intstr.FromString("http"), *policy.Spec.Ingress[0].Ports[0].Port)
+	require.Len(t, policy.Spec.Ingress[0].From, 1)
+	assert.Equal(t, "ingress-nginx", policy.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"])
+}
+
+func TestNetworkPolicyDefaultDenyEgress(t *testing.T) {
+	networkPolicyTrait, environment := createNetworkPolicyTest(t, `
+		from("kafka:orders?brokers=my-cluster:9093,other:9094")
+			.to("https://api.example.com/orders")
+			.to("http://legacy:8080/orders")
+			.to("jdbc:default")
+	`)
+	networkPolicyTrait.DefaultDeny = pointer.Bool(true)
+	networkPolicyTrait.Egress = []string{"jdbc=5432@10.0.0.0/16", "mongodb=27017", "*=8443/tcp"}
+
+	policy := networkPolicyCreatedCheck(t, networkPolicyTrait, environment)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, policy.Spec.PolicyTypes)
+	require.Len(t, policy.Spec.Egress, 4)
+
+	assert.Equal(t, []int32{53, 53}, egressPorts(policy.Spec.Egress[0]))
+	assert.Equal(t, []int32{443, 8080, 9093, 9094}, egressPorts(policy.Spec.Egress[1]))
+	assert.Empty(t, policy.Spec.Egress[1].To)
+
+	assert.Equal(t, []int32{5432}, egressPorts(policy.Spec.Egress[2]))
+	require.Len(t, policy.Spec.Egress[2].To, 1)
+	assert.Equal(t, "10.0.0.0/16", policy.Spec.Egress[2].To[0].IPBlock.CIDR)
+
+	// The mongodb rule doesn't apply, as the component isn't used
+	assert.Equal(t, []int32{8443}, egressPorts(policy.Spec.Egress[3]))
+	assert.Equal(t, corev1.ProtocolTCP, *policy.Spec.Egress[3].Ports[0].Protocol)
+}
+
+func TestNetworkPolicyEgressWithoutAutoEgress(t *testing.T) {
+	networkPolicyTrait, environment := createNetworkPolicyTest(t, `from("timer:tick").to("https://api.example.com")`)
+	networkPolicyTrait.DefaultDeny = pointer.Bool(true)
+	networkPolicyTrait.AutoEgress = pointer.Bool(false)
+	environment.Integration.Status.Dependencies = []string{"camel:kafka"}
+	networkPolicyTrait.Egress = []string{"kafka=9092"}
+
+	policy := networkPolicyCreatedCheck(t, networkPolicyTrait, environment)
+	require.Len(t, policy.Spec.Egress, 2)
+	assert.Equal(t, []int32{9092}, egressPorts(policy.Spec.Egress[1]))
+}
+
+func TestInferEgressPorts(t *testing.T) {
+	assert.Equal(t, []int{21, 22, 443, 5672, 8443, 9092}, inferEgressPorts([]string{
+		"https://my-host:8443/api?q=1",
+		"https:my-host/api",
+		"kafka:my-topic",
+		"amqp:queue:orders",
+		"sftp://user@my-host/dir",
+		"ftp:my-host/dir",
+		"timer:tick?period=1000",
+		"log:info",
+		"platform-http:/hello",
+	}))
+}
+
+func egressPorts(rule networkingv1.NetworkPolicyEgressRule) []int32 {
+	ports := make([]int32, 0, len(rule.Ports))
+	for _, p := range rule.Ports {
+		ports = append(ports, p.Port.IntVal)
+	}
+	return ports
+}
+
+func networkPolicyCreatedCheck(t *testing.T, networkPolicyTrait *networkPolicyTrait, environment *Environment) *networkingv1.NetworkPolicy {
+	t.Helper()
+
+	err := networkPolicyTrait.Apply(environment)
+	require.NoError(t, err)
+
+	var policy *networkingv1.NetworkPolicy
+	for _, r := range environment.Resources.Items() {
+		if p, ok := r.(*networkingv1.NetworkPolicy); ok {
+			policy = p
+		}
+	}
+	require.NotNil(t, policy)
+	assert.Equal(t, environment.Integration.Name, policy.Name)
+	assert.Equal(t, environment.Integration.Name, policy.Labels[v1.IntegrationLabel])
+	assert.Equal(t, environment.Integration.Name, policy.Spec.PodSelector.MatchLabels[v1.IntegrationLabel])
+	return policy
+}
+
+func createNetworkPolicyTest(t *testing.T, route string) (*networkPolicyTrait, *Environment) {
+	t.Helper()
+
+	catalog, err := camel.DefaultCatalog()
+	require.NoError(t, err)
+
+	trait, _ := newNetworkPolicyTrait().(*networkPolicyTrait)
+	trait.Enabled = pointer.Bool(true)
+
+	environment := &Environment{
+		CamelCatalog: catalog,
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "integration-name",
+				Namespace: "namespace",
+			},
+			Spec: v1.IntegrationSpec{
+				Sources: []v1.SourceSpec{
+					{
+						DataSpec: v1.DataSpec{
+							Name:    "routes.java",
+							Content: "public class Routes extends RouteBuilder { public void configure() { " + route + "; } }",
+						},
+						Language: v1.LanguageJavaSource,
+					},
+				},
+			},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(),
+	}
+
+	return trait, environment
+}
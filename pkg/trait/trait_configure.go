@@ -18,6 +18,7 @@ limitations under the License.
 package trait
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -118,6 +119,14 @@ func decodeTrait(in map[string]interface{}, target Trait) error {
 }
 
 func (c *Catalog) configureTraitsFromAnnotations(annotations map[string]string) error {
+	options, err := traitOptionsFromAnnotations(annotations)
+	if err != nil {
+		return err
+	}
+	return c.configureFromOptions(options)
+}
+
+func traitOptionsFromAnnotations(annotations map[string]string) (map[string]map[string]interface{}, error) {
 	options := make(map[string]map[string]interface{}, len(annotations))
 	for k, v := range annotations {
 		if strings.HasPrefix(k, v1.TraitAnnotationPrefix) {
@@ -135,22 +144,22 @@ func (c *Catalog) configureTraitsFromAnnotations(annotations map[string]string)
 				if len(propParts) > 1 {
 					c, err := util.NavigateConfigTree(current, propParts[0:len(propParts)-1])
 					if err != nil {
-						return err
+						return nil, err
 					}
 					if cc, ok := c.(map[string]interface{}); ok {
 						current = cc
 					} else {
-						return errors.New(`invalid array specification: to set an array value use the ["v1", "v2"] format`)
+						return nil, errors.New(`invalid array specification: to set an array value use the ["v1", "v2"] format`)
 					}
 				}
 				current[prop] = v
 
 			} else {
-				return fmt.Errorf("wrong format for trait annotation %q: missing trait ID", k)
+				return nil, fmt.Errorf("wrong format for trait annotation %q: missing trait ID", k)
 			}
 		}
 	}
-	return c.configureFromOptions(options)
+	return options, nil
 }
 
 func (c *Catalog) configureFromOptions(traits map[string]map[string]interface{}) error {
@@ -199,3 +208,82 @@ func configureTrait(id string, config map[string]interface{}, trait interface{})
 
 	return decoder.Decode(config)
 }
+
+// ValidateTraits checks that the given traits, either v1.Traits or v1.IntegrationKitTraits, and the traits
+// configured with annotations, only refer to traits and properties that are known to the catalog.
+func (c *Catalog) ValidateTraits(traits interface{}, annotations map[string]string) error {
+	traitMap, err := ToTraitMap(traits)
+	if err != nil {
+		return err
+	}
+
+	for id, trait := range traitMap {
+		if id == "addons" {
+			continue
+		}
+		if err := c.validateTrait(id, trait); err != nil {
+			return err
+		}
+	}
+	for id, trait := range traitMap["addons"] {
+		addon, ok := trait.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type for addon trait %q: %v", id, reflect.TypeOf(trait))
+		}
+		if err := c.validateTrait(id, addon); err != nil {
+			return err
+		}
+	}
+
+	options, err := traitOptionsFromAnnotations(annotations)
+	if err != nil {
+		return err
+	}
+	for id, config := range options {
+		target, err := c.newTrait(id)
+		if err != nil {
+			return err
+		}
+		if err := configureTrait(id, config, target); err != nil {
+			return fmt.Errorf("invalid configuration for trait %q: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Catalog) validateTrait(id string, trait map[string]interface{}) error {
+	target, err := c.newTrait(id)
+	if err != nil {
+		return err
+	}
+	if err := MigrateLegacyConfiguration(trait); err != nil {
+		return fmt.Errorf("invalid configuration for trait %q: %w", id, err)
+	}
+
+	data, err := json.Marshal(trait)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("invalid configuration for trait %q: %w", id, err)
+	}
+
+	return nil
+}
+
+// newTrait returns a new, unconfigured, instance of the trait with the given ID.
+func (c *Catalog) newTrait(id string) (Trait, error) {
+	t := c.GetTrait(id)
+	if t == nil {
+		return nil, fmt.Errorf("trait %s does not exist in catalog", id)
+	}
+	trait, ok := reflect.New(reflect.TypeOf(t).Elem()).Interface().(Trait)
+	if !ok {
+		return nil, fmt.Errorf("unable to instantiate trait %s", id)
+	}
+
+	return trait, nil
+}
@@ -30,8 +30,10 @@ func init() {
 	AddToTraits(newDeploymentTrait)
 	AddToTraits(newEnvironmentTrait)
 	AddToTraits(newErrorHandlerTrait)
+	AddToTraits(newGatewayTrait)
 	AddToTraits(newGCTrait)
 	AddToTraits(newHealthTrait)
+	AddToTraits(newHPATrait)
 	AddToTraits(NewInitTrait)
 	AddToTraits(newIngressTrait)
 	AddToTraits(newIstioTrait)
@@ -42,6 +44,7 @@ func init() {
 	AddToTraits(newKnativeServiceTrait)
 	AddToTraits(newLoggingTraitTrait)
 	AddToTraits(newMountTrait)
+	AddToTraits(newNetworkPolicyTrait)
 	AddToTraits(newOpenAPITrait)
 	AddToTraits(newOwnerTrait)
 	AddToTraits(newPdbTrait)
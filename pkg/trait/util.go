@@ -18,6 +18,7 @@ limitations under the License.
 package trait
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -310,6 +311,42 @@ func MigrateLegacyConfiguration(trait map[string]interface{}) error {
 	return nil
 }
 
+// MigrateLegacyTraits moves up the legacy configuration of the given traits to their top-level properties.
+// The deprecated addon traits, that have no top-level properties, are left unchanged.
+func MigrateLegacyTraits(traits *v1.Traits) error {
+	value := reflect.ValueOf(traits).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() != reflect.Pointer || field.IsNil() || field.Type() == reflect.TypeOf(&v1.TraitSpec{}) {
+			continue
+		}
+		trait, err := ToPropertyMap(field.Interface())
+		if err != nil {
+			return err
+		}
+		if trait["configuration"] == nil {
+			continue
+		}
+		if err := MigrateLegacyConfiguration(trait); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(trait)
+		if err != nil {
+			return err
+		}
+		target := reflect.New(field.Type().Elem())
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(target.Interface()); err != nil {
+			return fmt.Errorf("invalid configuration for trait %q: %w", strings.Split(value.Type().Field(i).Tag.Get("property"), ",")[0], err)
+		}
+		field.Set(target)
+	}
+
+	return nil
+}
+
 // ToTrait unmarshals a map configuration to a target trait.
 func ToTrait(trait map[string]interface{}, target interface{}) error {
 	data, err := json.Marshal(trait)
@@ -66,4 +66,6 @@ type BindingContext struct {
 type EndpointContext struct {
 	Type     v1.EndpointType
 	Position *int
+	// Branch is the position of the routing branch the endpoint belongs to, if any
+	Branch *int
 }
@@ -32,12 +32,15 @@ func (b Binding) AsYamlDSL() map[string]interface{} {
 	return step
 }
 
-// GenerateID generates an identifier based on the context type and its optional position.
+// GenerateID generates an identifier based on the context type, its optional position and its optional routing branch.
 func (c EndpointContext) GenerateID() string {
 	id := string(c.Type)
 	if c.Position != nil {
 		id = fmt.Sprintf("%s-%d", id, *c.Position)
 	}
+	if c.Branch != nil {
+		id = fmt.Sprintf("branch-%d-%s", *c.Branch, id)
+	}
 	return id
 }
 
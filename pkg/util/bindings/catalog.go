@@ -19,6 +19,7 @@ package bindings
 
 import (
 	"errors"
+	"fmt"
 	"sort"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
@@ -56,7 +57,10 @@ func V1alpha1RegisterBindingProvider(bp V1alpha1BindingProvider) {
 
 // Translate execute all chained binding providers, returning the first success or the first error.
 func Translate(ctx BindingContext, endpointCtx EndpointContext, endpoint v1.Endpoint) (*Binding, error) {
-	if err := validateEndpoint(ctx, endpoint); err != nil {
+	if err := ValidateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	if err := ValidateEndpointType(endpointCtx.Type, endpoint); err != nil {
 		return nil, err
 	}
 
@@ -69,7 +73,17 @@ func Translate(ctx BindingContext, endpointCtx EndpointContext, endpoint v1.Endp
 	return nil, nil
 }
 
-func validateEndpoint(ctx BindingContext, e v1.Endpoint) error {
+// ValidateEndpoint checks the given Pipe endpoint specifies either a ref, an URI or an inline step, and doesn't refer to another namespace.
+func ValidateEndpoint(ctx BindingContext, e v1.Endpoint) error {
+	if e.Inline != nil {
+		if e.Ref != nil || e.URI != nil {
+			return errors.New("cannot use an inline step together with a ref or an URI: only one of them should be used")
+		}
+		if e.Properties != nil {
+			return errors.New("cannot use properties with an inline step: they should be set in the inline step itself")
+		}
+		return nil
+	}
 	if e.Ref == nil && e.URI == nil {
 		return errors.New("no ref or URI specified in endpoint")
 	} else if e.Ref != nil && e.URI != nil {
@@ -86,6 +100,36 @@ func validateEndpoint(ctx BindingContext, e v1.Endpoint) error {
 	return nil
 }
 
+// ValidateEndpointType checks the given Pipe endpoint can be used as the given type of endpoint, as inline steps can only be used as actions.
+func ValidateEndpointType(endpointType v1.EndpointType, e v1.Endpoint) error {
+	if e.Inline != nil && endpointType != v1.EndpointTypeAction {
+		return fmt.Errorf("inline steps cannot be used as %s endpoint", endpointType)
+	}
+	return nil
+}
+
+// ValidateBranch checks the given Pipe routing branch declares a condition only when required by the routing type.
+func ValidateBranch(routingType v1.PipeRoutingType, branch v1.PipeBranch) error {
+	switch routingType {
+	case v1.PipeRoutingTypeChoice:
+		if branch.Condition == nil || branch.Condition.Expression == "" {
+			return errors.New("a condition is required by the Choice routing")
+		}
+		switch branch.Condition.Language {
+		case "", v1.PipePredicateLanguageSimple, v1.PipePredicateLanguageJSONPath:
+		default:
+			return fmt.Errorf("unsupported condition language %q", branch.Condition.Language)
+		}
+	case v1.PipeRoutingTypeMulticast:
+		if branch.Condition != nil {
+			return errors.New("a condition cannot be used with the Multicast routing")
+		}
+	default:
+		return fmt.Errorf("unsupported routing type %q", routingType)
+	}
+	return nil
+}
+
 // TranslateV1alpha1 execute all chained binding providers, returning the first success or the first error.
 // Deprecated.
 func TranslateV1alpha1(ctx V1alpha1BindingContext, endpointCtx V1alpha1EndpointContext, endpoint v1alpha1.Endpoint) (*Binding, error) {
@@ -117,7 +117,7 @@ func TestValidateEndpoint(t *testing.T) {
 				Profile:   v1.DefaultTraitProfile,
 			}
 
-			err = validateEndpoint(bindingContext, tc.endpoint)
+			err = ValidateEndpoint(bindingContext, tc.endpoint)
 			require.NoError(t, err)
 		})
 	}
@@ -190,7 +190,7 @@ func TestValidateEndpointError(t *testing.T) {
 				Profile:   v1.DefaultTraitProfile,
 			}
 
-			err = validateEndpoint(bindingContext, tc.endpoint)
+			err = ValidateEndpoint(bindingContext, tc.endpoint)
 			require.Error(t, err, "cross-namespace references are not allowed in Pipe")
 		})
 	}
@@ -0,0 +1,368 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bindings
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"sort"
+	"strings"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/kamelet/repository"
+)
+
+const (
+	mediaTypeBinary = "application/octet-stream"
+	mediaTypeText   = "text/plain"
+	mediaTypeJSON   = "application/json"
+)
+
+// DataTypeConversion is a transformer to apply to the data consumed by a Pipe endpoint,
+// so that it matches one of the data types accepted by the referenced Kamelet.
+type DataTypeConversion struct {
+	// Endpoint is the Pipe endpoint consuming the data, e.g., steps[N] or sink
+	Endpoint string
+	// DataType is the data type the data is converted to
+	DataType v1.DataTypeReference
+}
+
+func (c DataTypeConversion) String() string {
+	return fmt.Sprintf("%s %s:%s", c.Endpoint, c.DataType.Scheme, c.DataType.Format)
+}
+
+// DataTypeIncompatibility reports data produced by a Pipe endpoint that cannot be consumed by the next one.
+type DataTypeIncompatibility struct {
+	// Producer is the Pipe endpoint producing the data, e.g., source or steps[N]
+	Producer string
+	// Consumer is the Pipe endpoint consuming the data, e.g., steps[N] or sink
+	Consumer string
+	// Message is a human-readable description of the incompatibility
+	Message string
+}
+
+func (i DataTypeIncompatibility) String() string {
+	return fmt.Sprintf("%s -> %s: %s", i.Producer, i.Consumer, i.Message)
+}
+
+// DataTypesAnalysis is the result of the data types analysis of a Pipe.
+type DataTypesAnalysis struct {
+	// Conversions are the transformers needed for the data to flow through the Pipe
+	Conversions []DataTypeConversion
+	// Incompatibilities are the data types that cannot be converted
+	Incompatibilities []DataTypeIncompatibility
+}
+
+// Apply sets the conversions as the input data types of the Pipe endpoints, so that the matching transformers
+// are added to the Integration.
+func (a *DataTypesAnalysis) Apply(pipe *v1.Pipe) {
+	for _, c := range a.Conversions {
+		e := pipeEndpoint(pipe, c.Endpoint)
+		if e == nil {
+			continue
+		}
+		if e.DataTypes == nil {
+			e.DataTypes = make(map[v1.TypeSlot]v1.DataTypeReference)
+		}
+		e.DataTypes[v1.TypeSlotIn] = c.DataType
+	}
+}
+
+// FormatDataTypeConversions returns the given conversions as a comma separated list of
+// endpoint=scheme:format entries, that can be parsed back with ParseDataTypeConversions.
+func FormatDataTypeConversions(conversions []DataTypeConversion) string {
+	entries := make([]string, 0, len(conversions))
+	for _, c := range conversions {
+		entries = append(entries, fmt.Sprintf("%s=%s:%s", c.Endpoint, c.DataType.Scheme, c.DataType.Format))
+	}
+	return strings.Join(entries, ",")
+}
+
+// ParseDataTypeConversions parses the conversions formatted with FormatDataTypeConversions.
+func ParseDataTypeConversions(value string) ([]DataTypeConversion, error) {
+	if value == "" {
+		return nil, nil
+	}
+	entries := strings.Split(value, ",")
+	conversions := make([]DataTypeConversion, 0, len(entries))
+	for _, entry := range entries {
+		endpoint, ref, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid data type conversion %q: expected endpoint=scheme:format", entry)
+		}
+		scheme, format, ok := strings.Cut(ref, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid data type conversion %q: expected endpoint=scheme:format", entry)
+		}
+		conversions = append(conversions, DataTypeConversion{
+			Endpoint: endpoint,
+			DataType: v1.DataTypeReference{Scheme: scheme, Format: format},
+		})
+	}
+	return conversions, nil
+}
+
+// dataType is the effective data type of a Kamelet slot.
+type dataType struct {
+	// name is the name of the data type in the Kamelet specification, if any
+	name      string
+	scheme    string
+	format    string
+	mediaType string
+}
+
+func (t dataType) String() string {
+	if t.format == "" {
+		return t.mediaType
+	}
+	s := t.format
+	if t.scheme != "" {
+		s = t.scheme + ":" + s
+	}
+	if t.mediaType != "" {
+		s += " (" + t.mediaType + ")"
+	}
+	return s
+}
+
+// AnalyzeDataTypes walks the Pipe endpoints from the source to the sinks, and checks the data produced by each endpoint
+// can be consumed by the next one, according to the data types of the referenced Kamelets. The data types are resolved
+// from the data types selected by the endpoints, or from the default data types of the Kamelets. When the data types
+// don't match, a conversion to one of the data types accepted by the consumer is proposed, if possible, otherwise an
+// incompatibility is reported. Endpoints with unknown data types, e.g., plain URIs, are not checked.
+func AnalyzeDataTypes(ctx context.Context, repo repository.KameletRepository, pipe *v1.Pipe) (*DataTypesAnalysis, error) {
+	endpoints, err := pipeKameletEndpoints(ctx, repo, pipe)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := DataTypesAnalysis{
+		Conversions:       make([]DataTypeConversion, 0),
+		Incompatibilities: make([]DataTypeIncompatibility, 0),
+	}
+	for _, consumer := range endpoints {
+		if consumer.producer < 0 {
+			continue
+		}
+		producer := endpoints[consumer.producer]
+		produced, ok := outputDataType(producer)
+		if !ok {
+			continue
+		}
+		// The data type selected by the consumer is enforced by a transformer
+		if _, ok := consumer.spec.DataTypes[v1.TypeSlotIn]; ok || consumer.kamelet == nil {
+			continue
+		}
+		accepted := inputDataTypes(consumer.kamelet)
+		if len(accepted) == 0 || acceptsDataType(accepted, produced) {
+			continue
+		}
+
+		if target, ok := convertibleDataType(accepted, produced); ok {
+			if target.name == "" {
+				// The conversion is implicit, as the Kamelet doesn't declare any data type to convert to
+				continue
+			}
+			analysis.Conversions = append(analysis.Conversions, DataTypeConversion{
+				Endpoint: consumer.name,
+				DataType: v1.DataTypeReference{Scheme: target.scheme, Format: target.format},
+			})
+			continue
+		}
+
+		names := make([]string, 0, len(accepted))
+		for _, t := range accepted {
+			names = append(names, t.String())
+		}
+		analysis.Incompatibilities = append(analysis.Incompatibilities, DataTypeIncompatibility{
+			Producer: producer.name,
+			Consumer: consumer.name,
+			Message: fmt.Sprintf("data type %s cannot be converted to any of the data types accepted by Kamelet %s [%s]",
+				produced, consumer.kamelet.Name, strings.Join(names, ", ")),
+		})
+	}
+
+	return &analysis, nil
+}
+
+// outputDataType returns the data type produced by the endpoint, if known.
+func outputDataType(e kameletEndpoint) (dataType, bool) {
+	if ref, ok := e.spec.DataTypes[v1.TypeSlotOut]; ok {
+		t := referencedDataType(ref)
+		if e.kamelet != nil {
+			// Look up the media type of the data type in the Kamelet specification
+			for _, declared := range kameletDataTypes(e.kamelet, v1.TypeSlotOut) {
+				if declared.format == t.format && declared.mediaType != "" {
+					t.mediaType = declared.mediaType
+				}
+			}
+		}
+		return t, t.mediaType != ""
+	}
+	if e.kamelet == nil {
+		return dataType{}, false
+	}
+
+	types := kameletDataTypes(e.kamelet, v1.TypeSlotOut)
+	if spec, ok := e.kamelet.Spec.DataTypes[v1.TypeSlotOut]; ok && spec.Default != "" {
+		for _, t := range types {
+			if t.name == spec.Default {
+				return t, t.mediaType != ""
+			}
+		}
+	}
+	if len(types) == 1 {
+		return types[0], types[0].mediaType != ""
+	}
+	return dataType{}, false
+}
+
+// inputDataTypes returns the data types accepted by the Kamelet, with the default data type first.
+func inputDataTypes(kamelet *v1.Kamelet) []dataType {
+	types := kameletDataTypes(kamelet, v1.TypeSlotIn)
+	if spec, ok := kamelet.Spec.DataTypes[v1.TypeSlotIn]; ok && spec.Default != "" {
+		sort.SliceStable(types, func(i, j int) bool {
+			return types[i].name == spec.Default && types[j].name != spec.Default
+		})
+	}
+	return types
+}
+
+// kameletDataTypes returns the data types of the Kamelet slot, sorted by name, falling back to the deprecated types.
+func kameletDataTypes(kamelet *v1.Kamelet, slot v1.TypeSlot) []dataType {
+	types := make([]dataType, 0)
+	if spec, ok := kamelet.Spec.DataTypes[slot]; ok && len(spec.Types) > 0 {
+		names := make([]string, 0, len(spec.Types))
+		for name := range spec.Types {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			t := referencedDataType(v1.DataTypeReference{Scheme: spec.Types[name].Scheme, Format: spec.Types[name].Format})
+			t.name = name
+			if t.format == "" {
+				t.format = name
+			}
+			t.mediaType = spec.Types[name].MediaType
+			if t.mediaType == "" {
+				t.mediaType = mediaTypeFromFormat(t.format)
+			}
+			types = append(types, t)
+		}
+		return types
+	}
+	// nolint: staticcheck
+	if spec, ok := kamelet.Spec.Types[slot]; ok && spec.MediaType != "" {
+		types = append(types, dataType{mediaType: spec.MediaType})
+	}
+	return types
+}
+
+// referencedDataType splits the scheme out of the format, if any, the same way the data type action does.
+func referencedDataType(ref v1.DataTypeReference) dataType {
+	t := dataType{scheme: ref.Scheme, format: ref.Format}
+	if t.scheme == "" && strings.Contains(t.format, ":") {
+		tuple := strings.SplitN(t.format, ":", 2)
+		t.scheme, t.format = tuple[0], tuple[1]
+	}
+	if t.scheme == "" {
+		t.scheme = "camel"
+	}
+	t.mediaType = mediaTypeFromFormat(t.format)
+	return t
+}
+
+// mediaTypeFromFormat guesses the media type from the name of a data type format, following the Camel naming
+// convention, e.g., application-json for application/json.
+func mediaTypeFromFormat(format string) string {
+	switch format {
+	case "binary":
+		return mediaTypeBinary
+	case "text", "string":
+		return mediaTypeText
+	case "json":
+		return mediaTypeJSON
+	}
+	for _, prefix := range []string{"application-", "text-", "image-", "audio-", "video-"} {
+		if strings.HasPrefix(format, prefix) {
+			return strings.Replace(format, "-", "/", 1)
+		}
+	}
+	return ""
+}
+
+// acceptsDataType returns true if the produced data type matches one of the accepted data types.
+func acceptsDataType(accepted []dataType, produced dataType) bool {
+	for _, t := range accepted {
+		if t.format != "" && t.format == produced.format && t.scheme == produced.scheme {
+			return true
+		}
+		if t.mediaType == "" || sameMediaType(t.mediaType, produced.mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertibleDataType returns the first accepted data type the produced data type can be converted to.
+func convertibleDataType(accepted []dataType, produced dataType) (dataType, bool) {
+	for _, t := range accepted {
+		if convertibleMediaType(produced.mediaType, t.mediaType) {
+			return t, true
+		}
+	}
+	return dataType{}, false
+}
+
+// convertibleMediaType returns true if any data of the given media type can be converted to the target media type:
+// anything can be serialized to binary, textual data can be read as text, and structured JSON formats, e.g.,
+// CloudEvents, are JSON.
+func convertibleMediaType(from, to string) bool {
+	from, to = baseMediaType(from), baseMediaType(to)
+	switch {
+	case from == to:
+		return true
+	case to == mediaTypeBinary:
+		return true
+	case to == mediaTypeText:
+		return isTextual(from)
+	case to == mediaTypeJSON:
+		return strings.HasSuffix(from, "+json")
+	}
+	return false
+}
+
+func isTextual(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") ||
+		mediaType == mediaTypeJSON || strings.HasSuffix(mediaType, "+json") ||
+		mediaType == "application/xml" || strings.HasSuffix(mediaType, "+xml") ||
+		mediaType == "application/yaml"
+}
+
+func sameMediaType(a, b string) bool {
+	return baseMediaType(a) == baseMediaType(b)
+}
+
+// baseMediaType returns the media type without parameters, e.g., the charset.
+func baseMediaType(mediaType string) string {
+	if base, _, err := mime.ParseMediaType(mediaType); err == nil {
+		return base
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
@@ -0,0 +1,202 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bindings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/kamelet/repository"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+func dataTypesKamelet(name string, slot v1.TypeSlot, defaultType string, types map[string]v1.DataTypeSpec) *v1.Kamelet {
+	kamelet := v1.NewKamelet("default", name)
+	kamelet.Spec.DataTypes = map[v1.TypeSlot]v1.DataTypesSpec{
+		slot: {Default: defaultType, Types: types},
+	}
+	return &kamelet
+}
+
+func kameletRefEndpoint(name string) v1.Endpoint {
+	return v1.Endpoint{
+		Ref: &corev1.ObjectReference{
+			Kind:       v1.KameletKind,
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Name:       name,
+		},
+	}
+}
+
+func analyzeDataTypes(t *testing.T, pipe *v1.Pipe, kamelets ...*v1.Kamelet) *DataTypesAnalysis {
+	t.Helper()
+	objects := make([]runtime.Object, 0, len(kamelets))
+	for _, k := range kamelets {
+		objects = append(objects, k)
+	}
+	c, err := test.NewFakeClient(objects...)
+	require.NoError(t, err)
+	repo, err := repository.New(context.TODO(), c, "default")
+	require.NoError(t, err)
+	analysis, err := AnalyzeDataTypes(context.TODO(), repo, pipe)
+	require.NoError(t, err)
+	return analysis
+}
+
+var (
+	cloudEventsSource = dataTypesKamelet("ce-source", v1.TypeSlotOut, "cloudevents", map[string]v1.DataTypeSpec{
+		"binary":      {Format: "application-octet-stream", MediaType: "application/octet-stream"},
+		"cloudevents": {Format: "application-cloudevents", MediaType: "application/cloudevents+json"},
+	})
+	binarySink = dataTypesKamelet("binary-sink", v1.TypeSlotIn, "binary", map[string]v1.DataTypeSpec{
+		"binary": {Format: "application-octet-stream", MediaType: "application/octet-stream"},
+	})
+	jsonSink = dataTypesKamelet("json-sink", v1.TypeSlotIn, "json", map[string]v1.DataTypeSpec{
+		"json": {Format: "application-json", MediaType: "application/json"},
+	})
+)
+
+func TestAnalyzeDataTypesCompatible(t *testing.T) {
+	pipe := v1.NewPipe("default", "my-pipe")
+	pipe.Spec.Source = kameletRefEndpoint("ce-source")
+	pipe.Spec.Source.DataTypes = map[v1.TypeSlot]v1.DataTypeReference{v1.TypeSlotOut: {Format: "application-octet-stream"}}
+	pipe.Spec.Sink = kameletRefEndpoint("binary-sink")
+
+	analysis := analyzeDataTypes(t, &pipe, cloudEventsSource, binarySink)
+	assert.Empty(t, analysis.Conversions)
+	assert.Empty(t, analysis.Incompatibilities)
+}
+
+func TestAnalyzeDataTypesConversion(t *testing.T) {
+	pipe := v1.NewPipe("default", "my-pipe")
+	pipe.Spec.Source = kameletRefEndpoint("ce-source")
+	pipe.Spec.Sink = kameletRefEndpoint("binary-sink")
+
+	analysis := analyzeDataTypes(t, &pipe, cloudEventsSource, binarySink)
+	assert.Empty(t, analysis.Incompatibilities)
+	require.Len(t, analysis.Conversions, 1)
+	assert.Equal(t, "sink camel:application-octet-stream", analysis.Conversions[0].String())
+
+	analysis.Apply(&pipe)
+	assert.Equal(t, v1.DataTypeReference{Scheme: "camel", Format: "application-octet-stream"}, pipe.Spec.Sink.DataTypes[v1.TypeSlotIn])
+}
+
+func TestAnalyzeDataTypesIncompatible(t *testing.T) {
+	pipe := v1.NewPipe("default", "my-pipe")
+	pipe.Spec.Source = kameletRefEndpoint("ce-source")
+	pipe.Spec.Source.DataTypes = map[v1.TypeSlot]v1.DataTypeReference{v1.TypeSlotOut: {Format: "application-octet-stream"}}
+	step := kameletRefEndpoint("json-sink")
+	pipe.Spec.Steps = []v1.Endpoint{step}
+	uri := "log:info"
+	pipe.Spec.Sink = v1.Endpoint{URI: &uri}
+
+	analysis := analyzeDataTypes(t, &pipe, cloudEventsSource, jsonSink)
+	assert.Empty(t, analysis.Conversions)
+	require.Len(t, analysis.Incompatibilities, 1)
+	assert.Equal(t, "source -> steps[0]: data type camel:application-octet-stream (application/octet-stream) cannot be "+
+		"converted to any of the data types accepted by Kamelet json-sink [camel:application-json (application/json)]",
+		analysis.Incompatibilities[0].String())
+}
+
+func TestAnalyzeDataTypesSelectedByConsumer(t *testing.T) {
+	pipe := v1.NewPipe("default", "my-pipe")
+	pipe.Spec.Source = kameletRefEndpoint("ce-source")
+	pipe.Spec.Source.DataTypes = map[v1.TypeSlot]v1.DataTypeReference{v1.TypeSlotOut: {Format: "application-octet-stream"}}
+	pipe.Spec.Sink = kameletRefEndpoint("json-sink")
+	pipe.Spec.Sink.DataTypes = map[v1.TypeSlot]v1.DataTypeReference{v1.TypeSlotIn: {Format: "application-json"}}
+
+	analysis := analyzeDataTypes(t, &pipe, cloudEventsSource, jsonSink)
+	assert.Empty(t, analysis.Conversions)
+	assert.Empty(t, analysis.Incompatibilities)
+}
+
+func TestAnalyzeDataTypesUnknown(t *testing.T) {
+	pipe := v1.NewPipe("default", "my-pipe")
+	uri := "timer:tick"
+	pipe.Spec.Source = v1.Endpoint{URI: &uri}
+	pipe.Spec.Sink = kameletRefEndpoint("json-sink")
+
+	analysis := analyzeDataTypes(t, &pipe, jsonSink)
+	assert.Empty(t, analysis.Conversions)
+	assert.Empty(t, analysis.Incompatibilities)
+}
+
+func TestConvertibleMediaType(t *testing.T) {
+	assert.True(t, convertibleMediaType("application/json", "application/json; charset=UTF-8"))
+	assert.True(t, convertibleMediaType("application/cloudevents+json", "application/octet-stream"))
+	assert.True(t, convertibleMediaType("application/cloudevents+json", "application/json"))
+	assert.True(t, convertibleMediaType("application/xml", "text/plain"))
+	assert.False(t, convertibleMediaType("application/octet-stream", "text/plain"))
+	assert.False(t, convertibleMediaType("text/plain", "application/json"))
+	assert.False(t, convertibleMediaType("application/json", "application/avro"))
+}
+
+func TestAnalyzeDataTypesRoutingBranches(t *testing.T) {
+	pipe := v1.NewPipe("default", "my-pipe")
+	pipe.Spec.Source = kameletRefEndpoint("ce-source")
+	pipe.Spec.Routing = &v1.PipeRouting{
+		Type: v1.PipeRoutingTypeMulticast,
+		Branches: []v1.PipeBranch{
+			{Sink: kameletRefEndpoint("binary-sink")},
+			{Sink: kameletRefEndpoint("json-sink")},
+		},
+	}
+	pipe.Spec.Source.DataTypes = map[v1.TypeSlot]v1.DataTypeReference{v1.TypeSlotOut: {Format: "application-octet-stream"}}
+
+	analysis := analyzeDataTypes(t, &pipe, cloudEventsSource, binarySink, jsonSink)
+	assert.Empty(t, analysis.Conversions)
+	require.Len(t, analysis.Incompatibilities, 1)
+	assert.Equal(t, "source", analysis.Incompatibilities[0].Producer)
+	assert.Equal(t, "routing.branches[1].sink", analysis.Incompatibilities[0].Consumer)
+
+	pipe.Spec.Source.DataTypes = nil
+	analysis = analyzeDataTypes(t, &pipe, cloudEventsSource, binarySink, jsonSink)
+	assert.Empty(t, analysis.Incompatibilities)
+	require.Len(t, analysis.Conversions, 2)
+	analysis.Apply(&pipe)
+	assert.Equal(t, "application-octet-stream", pipe.Spec.Routing.Branches[0].Sink.DataTypes[v1.TypeSlotIn].Format)
+	assert.Equal(t, "application-json", pipe.Spec.Routing.Branches[1].Sink.DataTypes[v1.TypeSlotIn].Format)
+}
+
+func TestFormatDataTypeConversions(t *testing.T) {
+	conversions := []DataTypeConversion{
+		{Endpoint: "steps[0]", DataType: v1.DataTypeReference{Scheme: "camel", Format: "application-json"}},
+		{Endpoint: "routing.branches[1].sink", DataType: v1.DataTypeReference{Scheme: "camel", Format: "application-octet-stream"}},
+	}
+	value := FormatDataTypeConversions(conversions)
+	assert.Equal(t, "steps[0]=camel:application-json,routing.branches[1].sink=camel:application-octet-stream", value)
+
+	parsed, err := ParseDataTypeConversions(value)
+	require.NoError(t, err)
+	assert.Equal(t, conversions, parsed)
+
+	parsed, err = ParseDataTypeConversions("")
+	require.NoError(t, err)
+	assert.Empty(t, parsed)
+	_, err = ParseDataTypeConversions("sink")
+	require.Error(t, err)
+	_, err = ParseDataTypeConversions("sink=application-json")
+	require.Error(t, err)
+}
@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bindings
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+// InlineBindingProvider splices a fragment of Camel YAML DSL declared by a Pipe step into the generated flow.
+type InlineBindingProvider struct{}
+
+// ID --.
+func (k InlineBindingProvider) ID() string {
+	return "inline"
+}
+
+// Translate --.
+func (k InlineBindingProvider) Translate(ctx BindingContext, endpointCtx EndpointContext, e v1.Endpoint) (*Binding, error) {
+	if e.Inline == nil {
+		// works only on inline steps
+		return nil, nil
+	}
+
+	var step map[string]interface{}
+	if err := json.Unmarshal(e.Inline.RawMessage, &step); err != nil {
+		return nil, fmt.Errorf("could not parse inline step: %w", err)
+	}
+	// the step is spliced as is into the flow, where each step is a single EIP
+	if len(step) != 1 {
+		return nil, fmt.Errorf("an inline step must declare exactly one EIP, found %d", len(step))
+	}
+
+	return &Binding{
+		Step: step,
+	}, nil
+}
+
+// Order --.
+func (k InlineBindingProvider) Order() int {
+	return OrderFirst
+}
+
+func init() {
+	RegisterBindingProvider(InlineBindingProvider{})
+}
@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bindings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func TestInlineBinding(t *testing.T) {
+	bindingContext := BindingContext{
+		Ctx:       context.Background(),
+		Namespace: "test",
+	}
+	position := 0
+	actionContext := EndpointContext{
+		Type:     v1.EndpointTypeAction,
+		Position: &position,
+	}
+	endpoint := v1.Endpoint{
+		Inline: &v1.Flow{RawMessage: []byte(`{"setHeader": {"name": "type", "constant": "order"}}`)},
+	}
+
+	binding, err := Translate(bindingContext, actionContext, endpoint)
+	require.NoError(t, err)
+	require.NotNil(t, binding)
+	assert.Equal(t, "", binding.URI)
+	assert.Equal(t, map[string]interface{}{
+		"setHeader": map[string]interface{}{
+			"name":     "type",
+			"constant": "order",
+		},
+	}, binding.AsYamlDSL())
+
+	_, err = Translate(bindingContext, EndpointContext{Type: v1.EndpointTypeSink}, endpoint)
+	require.Error(t, err)
+	assert.Equal(t, "inline steps cannot be used as sink endpoint", err.Error())
+
+	uri := "log:info"
+	endpoint.URI = &uri
+	_, err = Translate(bindingContext, actionContext, endpoint)
+	require.Error(t, err)
+	assert.Equal(t, "cannot use an inline step together with a ref or an URI: only one of them should be used", err.Error())
+
+	_, err = Translate(bindingContext, actionContext, v1.Endpoint{
+		Inline: &v1.Flow{RawMessage: []byte(`{"setHeader": {"name": "type"}, "log": "hello"}`)},
+	})
+	require.Error(t, err)
+	assert.Equal(t, "an inline step must declare exactly one EIP, found 2", err.Error())
+}
@@ -66,6 +66,13 @@ func (k BindingConverter) Translate(ctx BindingContext, endpointCtx EndpointCont
 			id = endpointCtx.GenerateID()
 		}
 
+		// The pinned version is passed in the URI, so that the kamelets trait can materialize it
+		query := ""
+		if version, ok := props[v1.KameletVersionProperty]; ok {
+			delete(props, v1.KameletVersionProperty)
+			query = fmt.Sprintf("?%s=%s", v1.KameletVersionProperty, url.QueryEscape(version))
+		}
+
 		binding := Binding{}
 		binding.ApplicationProperties = make(map[string]string)
 		for k, v := range props {
@@ -91,7 +98,7 @@ func (k BindingConverter) Translate(ctx BindingContext, endpointCtx EndpointCont
 
 			steps = append(steps, map[string]interface{}{
 				"kamelet": map[string]interface{}{
-					"name": fmt.Sprintf("%s/%s", kameletName, url.PathEscape(id)),
+					"name": fmt.Sprintf("%s/%s%s", kameletName, url.PathEscape(id), query),
 				},
 			})
 
@@ -121,7 +128,7 @@ func (k BindingConverter) Translate(ctx BindingContext, endpointCtx EndpointCont
 				}
 			}
 
-			binding.URI = fmt.Sprintf("kamelet:%s/%s", kameletName, url.PathEscape(id))
+			binding.URI = fmt.Sprintf("kamelet:%s/%s%s", kameletName, url.PathEscape(id), query)
 		case v1.EndpointTypeSink:
 			if in, applicationProperties := k.DataTypeStep(e, id, v1.TypeSlotIn, dataTypeActionKamelet); in != nil {
 				binding.Step = in
@@ -130,9 +137,9 @@ func (k BindingConverter) Translate(ctx BindingContext, endpointCtx EndpointCont
 				}
 			}
 
-			binding.URI = fmt.Sprintf("kamelet:%s/%s", kameletName, url.PathEscape(id))
+			binding.URI = fmt.Sprintf("kamelet:%s/%s%s", kameletName, url.PathEscape(id), query)
 		default:
-			binding.URI = fmt.Sprintf("kamelet:%s/%s", kameletName, url.PathEscape(id))
+			binding.URI = fmt.Sprintf("kamelet:%s/%s%s", kameletName, url.PathEscape(id), query)
 		}
 
 		return &binding, nil
@@ -0,0 +1,258 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bindings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/kamelet/repository"
+	"github.com/apache/camel-k/v2/pkg/util/kamelets"
+)
+
+// EndpointPropertyViolation is a property of a Pipe endpoint that does not match the definition of the referenced Kamelet.
+type EndpointPropertyViolation struct {
+	kamelets.PropertyViolation
+	// Endpoint is the Pipe endpoint, e.g., source, sink or steps[N]
+	Endpoint string
+}
+
+func (v EndpointPropertyViolation) String() string {
+	return fmt.Sprintf("%s %s", v.Endpoint, v.PropertyViolation)
+}
+
+// ValidateKameletProperties checks the properties of the Pipe endpoints referencing Kamelets against the Kamelet definitions,
+// before they are turned into Kamelet parameters. The Kamelets that cannot be found are not checked, as they are reported
+// when the Integration is initialized.
+func ValidateKameletProperties(ctx context.Context, repo repository.KameletRepository, pipe *v1.Pipe) ([]EndpointPropertyViolation, error) {
+	endpoints, err := pipeKameletEndpoints(ctx, repo, pipe)
+	if err != nil {
+		return nil, err
+	}
+
+	applicationProperties := pipeApplicationPropertyKeys(pipe)
+	violations := make([]EndpointPropertyViolation, 0)
+	for _, e := range endpoints {
+		// Unknown versions are reported when the Integration is initialized
+		if e.kamelet == nil || e.versionErr != nil {
+			continue
+		}
+		provided := KameletApplicationProperties(applicationProperties, e.kamelet.Name, e.id)
+		for _, violation := range kamelets.ValidateProperties(e.kamelet, e.properties, provided...) {
+			violations = append(violations, EndpointPropertyViolation{
+				PropertyViolation: violation,
+				Endpoint:          e.name,
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// kameletEndpoint is a Pipe endpoint along with the Kamelet it references, if any.
+type kameletEndpoint struct {
+	// name is the Pipe endpoint, i.e., source, sink, steps[N], routing.branches[N].steps[M] or routing.branches[N].sink
+	name string
+	spec v1.Endpoint
+	// producer is the index of the endpoint producing the data consumed by this endpoint, or -1 for the source
+	producer int
+	// kamelet is the referenced Kamelet, with the pinned version, or nil if the Kamelet cannot be found
+	kamelet *v1.Kamelet
+	// versionErr is set when the pinned version cannot be found
+	versionErr error
+	id         string
+	// properties are the endpoint properties, without the id and the version
+	properties map[string]string
+}
+
+// pipeKameletEndpoints returns the Pipe endpoints, in the order of the flow, i.e., source, steps, sink and routing branches,
+// and resolves the Kamelets they reference.
+func pipeKameletEndpoints(ctx context.Context, repo repository.KameletRepository, pipe *v1.Pipe) ([]kameletEndpoint, error) {
+	type endpoint struct {
+		name     string
+		context  EndpointContext
+		spec     v1.Endpoint
+		producer int
+	}
+	endpoints := []endpoint{
+		{name: "source", context: EndpointContext{Type: v1.EndpointTypeSource}, spec: pipe.Spec.Source, producer: -1},
+	}
+	for idx, step := range pipe.Spec.Steps {
+		position := idx
+		endpoints = append(endpoints, endpoint{
+			name:     fmt.Sprintf("steps[%d]", idx),
+			context:  EndpointContext{Type: v1.EndpointTypeAction, Position: &position},
+			spec:     step,
+			producer: len(endpoints) - 1,
+		})
+	}
+	last := len(endpoints) - 1
+	if pipe.Spec.Routing == nil || !pipe.Spec.Sink.IsEmpty() {
+		endpoints = append(endpoints, endpoint{
+			name:     "sink",
+			context:  EndpointContext{Type: v1.EndpointTypeSink},
+			spec:     pipe.Spec.Sink,
+			producer: last,
+		})
+	}
+	if pipe.Spec.Routing != nil {
+		for b, branch := range pipe.Spec.Routing.Branches {
+			branchIdx := b
+			producer := last
+			for idx, step := range branch.Steps {
+				position := idx
+				endpoints = append(endpoints, endpoint{
+					name:     fmt.Sprintf("routing.branches[%d].steps[%d]", b, idx),
+					context:  EndpointContext{Type: v1.EndpointTypeAction, Position: &position, Branch: &branchIdx},
+					spec:     step,
+					producer: producer,
+				})
+				producer = len(endpoints) - 1
+			}
+			endpoints = append(endpoints, endpoint{
+				name:     fmt.Sprintf("routing.branches[%d].sink", b),
+				context:  EndpointContext{Type: v1.EndpointTypeSink, Branch: &branchIdx},
+				spec:     branch.Sink,
+				producer: producer,
+			})
+		}
+	}
+
+	res := make([]kameletEndpoint, 0, len(endpoints))
+	for _, e := range endpoints {
+		ke := kameletEndpoint{name: e.name, spec: e.spec, producer: e.producer}
+		if !isKameletRef(e.spec) {
+			res = append(res, ke)
+			continue
+		}
+		kamelet, err := repo.Get(ctx, e.spec.Ref.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		props, err := e.spec.Properties.GetPropertyMap()
+		if err != nil {
+			return nil, fmt.Errorf("invalid properties for %s: %w", e.name, err)
+		}
+		id, ok := props[v1.KameletIDProperty]
+		if !ok {
+			id = e.context.GenerateID()
+		}
+		delete(props, v1.KameletIDProperty)
+		ke.id = id
+		ke.properties = props
+		ke.kamelet = kamelet
+		if version, ok := props[v1.KameletVersionProperty]; ok {
+			delete(props, v1.KameletVersionProperty)
+			if kamelet != nil {
+				ke.kamelet, ke.versionErr = kamelet.CloneWithVersion(version)
+			}
+		}
+		res = append(res, ke)
+	}
+
+	return res, nil
+}
+
+// pipeEndpoint returns the Pipe endpoint with the given name, as returned by pipeKameletEndpoints.
+func pipeEndpoint(pipe *v1.Pipe, name string) *v1.Endpoint {
+	var b, idx int
+	switch {
+	case name == "source":
+		return &pipe.Spec.Source
+	case name == "sink":
+		return &pipe.Spec.Sink
+	case strings.HasPrefix(name, "steps["):
+		if _, err := fmt.Sscanf(name, "steps[%d]", &idx); err == nil && idx < len(pipe.Spec.Steps) {
+			return &pipe.Spec.Steps[idx]
+		}
+	case pipe.Spec.Routing != nil && strings.HasSuffix(name, ".sink"):
+		if _, err := fmt.Sscanf(name, "routing.branches[%d].sink", &b); err == nil && b < len(pipe.Spec.Routing.Branches) {
+			return &pipe.Spec.Routing.Branches[b].Sink
+		}
+	case pipe.Spec.Routing != nil:
+		if _, err := fmt.Sscanf(name, "routing.branches[%d].steps[%d]", &b, &idx); err == nil &&
+			b < len(pipe.Spec.Routing.Branches) && idx < len(pipe.Spec.Routing.Branches[b].Steps) {
+			return &pipe.Spec.Routing.Branches[b].Steps[idx]
+		}
+	}
+	return nil
+}
+
+func isKameletRef(e v1.Endpoint) bool {
+	if e.Ref == nil || e.Ref.Kind != v1.KameletKind {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(e.Ref.APIVersion)
+	return err == nil && gv.Group == v1.SchemeGroupVersion.Group
+}
+
+// pipeApplicationPropertyKeys returns the keys of the application properties configured on the Pipe, with the camel trait
+// annotation, or the deprecated Integration spec.
+func pipeApplicationPropertyKeys(pipe *v1.Pipe) []string {
+	properties := make([]string, 0)
+	if value, ok := pipe.Annotations[v1.TraitAnnotationPrefix+"camel.properties"]; ok {
+		var values []string
+		if err := json.Unmarshal([]byte(value), &values); err != nil {
+			values = []string{value}
+		}
+		properties = append(properties, values...)
+	}
+	if it := pipe.Spec.Integration; it != nil {
+		if it.Traits.Camel != nil {
+			properties = append(properties, it.Traits.Camel.Properties...)
+		}
+		for _, c := range it.Configuration {
+			if c.Type == "property" {
+				properties = append(properties, c.Value)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(properties))
+	for _, p := range properties {
+		key, _, _ := strings.Cut(p, "=")
+		keys = append(keys, strings.TrimSpace(key))
+	}
+	return keys
+}
+
+// KameletApplicationProperties returns the names of the Kamelet properties set by the given application property keys,
+// either for all the Kamelet instances, or for the instance with the given id.
+func KameletApplicationProperties(keys []string, kamelet string, id string) []string {
+	prefix := fmt.Sprintf("camel.kamelet.%s.", kamelet)
+	res := make([]string, 0)
+	for _, key := range keys {
+		name, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		if n, ok := strings.CutPrefix(name, id+"."); ok {
+			name = n
+		}
+		if name != "" && !strings.Contains(name, ".") {
+			res = append(res, name)
+		}
+	}
+	return res
+}
@@ -74,6 +74,32 @@ func TestBindingConverter(t *testing.T) {
 			uri:          "kamelet:mykamelet/sink-3",
 			step:         nil,
 		},
+		{
+			name:         "source-version",
+			endpointType: v1.EndpointTypeSource,
+			uri:          "kamelet:mykamelet/source-4?kameletVersion=v1",
+			step:         nil,
+			endpointProperties: map[string]string{
+				"foo":            "bar",
+				"kameletVersion": "v1",
+			},
+			applicationProperties: map[string]string{
+				"camel.kamelet.mykamelet.source-4.foo": "bar",
+			},
+		},
+		{
+			name:         "action-version",
+			endpointType: v1.EndpointTypeAction,
+			uri:          "",
+			step: map[string]interface{}{
+				"kamelet": map[string]interface{}{
+					"name": "mykamelet/action-5?kameletVersion=2.0.1",
+				},
+			},
+			endpointProperties: map[string]string{
+				"kameletVersion": "2.0.1",
+			},
+		},
 	}
 
 	for i, tc := range testcases {
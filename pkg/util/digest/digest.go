@@ -86,6 +86,14 @@ func ComputeForIntegration(integration *v1.Integration, configmapVersions []stri
 		}
 	}
 
+	// Integration git sources, and the commit the ref has been resolved to, so that a moved ref rolls the
+	// Integration out
+	if git := integration.Spec.Git; git != nil {
+		if _, err := hash.Write([]byte(fmt.Sprintf("%s#%s:%s@%s", git.URL, git.Ref, git.Path, integration.Status.GitCommit))); err != nil {
+			return "", err
+		}
+	}
+
 	// Integration flows
 	if len(integration.Spec.Flows) > 0 {
 		flows, err := dsl.ToYamlDSL(integration.Spec.Flows)
@@ -148,3 +148,51 @@ func TestDigestMatchingTraitsUpdated(t *testing.T) {
 
 	assert.NotEqual(t, itSpecOnlyTraitUpdatedDigest, itDigest, "Digests must not be equal")
 }
+
+func TestDigestChangesWithKameletVersion(t *testing.T) {
+	it := v1.Integration{
+		Spec: v1.IntegrationSpec{
+			Sources: []v1.SourceSpec{
+				v1.NewSourceSpec("flow.yaml", "- from:\n    uri: kamelet:timer?kameletVersion=v1\n", v1.LanguageYaml),
+			},
+		},
+	}
+	digest1, err := ComputeForIntegration(&it, nil, nil)
+	require.NoError(t, err)
+
+	it.Spec.Sources[0].Content = "- from:\n    uri: kamelet:timer?kameletVersion=v2\n"
+	digest2, err := ComputeForIntegration(&it, nil, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, digest1, digest2)
+
+	it.Spec.Traits.Kamelets = &trait.KameletsTrait{
+		List: "timer@v1",
+	}
+	digest3, err := ComputeForIntegration(&it, nil, nil)
+	require.NoError(t, err)
+	it.Spec.Traits.Kamelets.List = "timer@v2"
+	digest4, err := ComputeForIntegration(&it, nil, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, digest3, digest4)
+}
+
+func TestDigestChangesWithGitCommit(t *testing.T) {
+	it := v1.Integration{
+		Spec: v1.IntegrationSpec{
+			Git: &v1.GitSourceSpec{
+				URL: "https://github.com/my-org/my-routes.git",
+				Ref: "main",
+			},
+		},
+		Status: v1.IntegrationStatus{
+			GitCommit: "0123456789abcdef0123456789abcdef01234567",
+		},
+	}
+	digest1, err := ComputeForIntegration(&it, nil, nil)
+	require.NoError(t, err)
+
+	it.Status.GitCommit = "89abcdef0123456789abcdef0123456789abcdef"
+	digest2, err := ComputeForIntegration(&it, nil, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, digest1, digest2)
+}
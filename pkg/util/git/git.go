@@ -0,0 +1,211 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// DefaultRef is the ref checked out when none is specified.
+const DefaultRef = "HEAD"
+
+var commitRegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// AllowedProtocols are the transports git is allowed to use to reach remote repositories. Notably, the `ext` transport,
+// that runs arbitrary commands, and the `file` transport, that gives access to the operator file system, are refused.
+var AllowedProtocols = []string{"https", "http", "ssh"}
+
+// Checkout fetches the given ref of the repository located at url into dir, and returns the SHA of the
+// commit it resolves to. Only the commit itself is fetched, without its history.
+func Checkout(ctx context.Context, url string, ref string, dir string) (string, error) {
+	if ref == "" {
+		ref = DefaultRef
+	}
+	if err := validate(url, ref); err != nil {
+		return "", err
+	}
+	if _, err := run(ctx, dir, "init", "--quiet"); err != nil {
+		return "", err
+	}
+	if _, err := remote(ctx, dir, "fetch", "--quiet", "--depth", "1", "--end-of-options", url, ref); err != nil {
+		return "", fmt.Errorf("cannot fetch ref %s from %s: %w", ref, url, err)
+	}
+	if _, err := run(ctx, dir, "checkout", "--quiet", "FETCH_HEAD"); err != nil {
+		return "", err
+	}
+
+	return run(ctx, dir, "rev-parse", "HEAD")
+}
+
+// ResolveRef returns the SHA of the commit the given ref of the repository located at url points to,
+// without fetching its content.
+func ResolveRef(ctx context.Context, url string, ref string) (string, error) {
+	if ref == "" {
+		ref = DefaultRef
+	}
+	if err := validate(url, ref); err != nil {
+		return "", err
+	}
+	if commitRegexp.MatchString(ref) {
+		return ref, nil
+	}
+
+	// The peeled entry of annotated tags has to be matched explicitly
+	out, err := remote(ctx, "", "ls-remote", "--end-of-options", url, ref, ref+"^{}")
+	if err != nil {
+		return "", fmt.Errorf("cannot list refs of %s: %w", url, err)
+	}
+
+	return resolveRef(ref, out)
+}
+
+// resolveRef looks up the given ref in the output of ls-remote. An exact match of the ref name takes
+// precedence over branches and tags with the same short name, and the commit an annotated tag
+// points to takes precedence over the tag object itself.
+func resolveRef(ref string, lsRemote string) (string, error) {
+	refs := make(map[string]string)
+	for _, line := range strings.Split(lsRemote, "\n") {
+		sha, name, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if ok {
+			refs[name] = sha
+		}
+	}
+
+	for _, name := range []string{ref, "refs/heads/" + ref, "refs/tags/" + ref} {
+		if sha, ok := refs[name+"^{}"]; ok {
+			return sha, nil
+		}
+		if sha, ok := refs[name]; ok {
+			return sha, nil
+		}
+	}
+
+	return "", fmt.Errorf("ref %s not found", ref)
+}
+
+// ListFiles returns the names of the files directly contained in dir, at the given ref of the local repository
+// dir belongs to.
+func ListFiles(ctx context.Context, dir string, ref string) ([]string, error) {
+	if err := validateRef(ref); err != nil {
+		return nil, err
+	}
+	out, err := output(ctx, dir, "ls-tree", "-z", "--end-of-options", ref, "./")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list files of %s at ref %s: %w", dir, ref, err)
+	}
+
+	var files []string
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// Each entry is formatted as `<mode> <type> <object>\t<name>`
+		info, name, ok := strings.Cut(entry, "\t")
+		if ok && len(strings.Fields(info)) == 3 && strings.Fields(info)[1] == "blob" {
+			files = append(files, name)
+		}
+	}
+
+	return files, nil
+}
+
+// ReadFile returns the content of the file name contained in dir, at the given ref of the local repository
+// dir belongs to.
+func ReadFile(ctx context.Context, dir string, ref string, name string) ([]byte, error) {
+	if err := validateRef(ref); err != nil {
+		return nil, err
+	}
+	content, err := output(ctx, dir, "show", "--end-of-options", ref+":./"+name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read file %s of %s at ref %s: %w", name, dir, ref, err)
+	}
+
+	return content, nil
+}
+
+// validate rejects the repository url and ref values that git would parse as options.
+func validate(url string, ref string) error {
+	if strings.HasPrefix(url, "-") {
+		return fmt.Errorf("invalid repository url %s: must not start with '-'", url)
+	}
+
+	return validateRef(ref)
+}
+
+// validateRef rejects the ref values that git would parse as options.
+func validateRef(ref string) error {
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid ref %s: must not start with '-'", ref)
+	}
+
+	return nil
+}
+
+// remote runs a git command that reaches a remote repository, restricting the transports it can use.
+func remote(ctx context.Context, dir string, args ...string) (string, error) {
+	config := []string{"-c", "protocol.allow=never"}
+	for _, protocol := range AllowedProtocols {
+		config = append(config, "-c", "protocol."+protocol+".allow=always")
+	}
+
+	return run(ctx, dir, append(config, args...)...)
+}
+
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	out, err := output(ctx, dir, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+func output(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	// Never prompt for credentials, as there is no terminal attached to the operator
+	cmd.Env = append(environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w", msg, err)
+		}
+		return nil, err
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// environ returns the environment of the operator, without the variable that would override the allowed protocols.
+func environ() []string {
+	env := make([]string, 0)
+	for _, e := range os.Environ() {
+		if !strings.HasPrefix(e, "GIT_ALLOW_PROTOCOL=") {
+			env = append(env, e)
+		}
+	}
+
+	return env
+}
@@ -0,0 +1,170 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+// allowFileProtocol allows the local test repositories to be fetched.
+func allowFileProtocol(t *testing.T) {
+	t.Helper()
+
+	allowed := AllowedProtocols
+	AllowedProtocols = append([]string{"file"}, allowed...)
+	t.Cleanup(func() {
+		AllowedProtocols = allowed
+	})
+}
+
+func TestCheckoutAndResolveRef(t *testing.T) {
+	ctx := context.TODO()
+	allowFileProtocol(t)
+	repo := test.NewGitRepository(t)
+	first := repo.Commit(t, map[string]string{"routes/route.yaml": "- from:\n    uri: timer:tick\n"})
+	repo.Tag(t, "v1")
+	second := repo.Commit(t, map[string]string{"routes/route.yaml": "- from:\n    uri: timer:tock\n"})
+
+	sha, err := ResolveRef(ctx, repo.URL, "")
+	require.NoError(t, err)
+	assert.Equal(t, second, sha)
+	sha, err = ResolveRef(ctx, repo.URL, "main")
+	require.NoError(t, err)
+	assert.Equal(t, second, sha)
+	sha, err = ResolveRef(ctx, repo.URL, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, first, sha)
+	sha, err = ResolveRef(ctx, repo.URL, first)
+	require.NoError(t, err)
+	assert.Equal(t, first, sha)
+
+	_, err = ResolveRef(ctx, repo.URL, "unknown")
+	require.Error(t, err)
+
+	dir := t.TempDir()
+	sha, err = Checkout(ctx, repo.URL, "v1", dir)
+	require.NoError(t, err)
+	assert.Equal(t, first, sha)
+	content, err := os.ReadFile(filepath.Join(dir, "routes", "route.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "timer:tick")
+
+	_, err = Checkout(ctx, repo.URL, "unknown", t.TempDir())
+	require.Error(t, err)
+}
+
+func TestRefusedProtocol(t *testing.T) {
+	ctx := context.TODO()
+	repo := test.NewGitRepository(t)
+	repo.Commit(t, map[string]string{"routes/route.yaml": "- from:\n    uri: timer:tick\n"})
+
+	_, err := ResolveRef(ctx, repo.URL, "main")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transport 'file' not allowed")
+	_, err = Checkout(ctx, "file://"+repo.URL, "main", t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transport 'file' not allowed")
+	_, err = ResolveRef(ctx, "ext::sh -c touch% /tmp/pwned", "main")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transport 'ext' not allowed")
+}
+
+func TestOptionShapedRefAndURL(t *testing.T) {
+	ctx := context.TODO()
+	allowFileProtocol(t)
+	repo := test.NewGitRepository(t)
+	repo.Commit(t, map[string]string{"routes/route.yaml": "- from:\n    uri: timer:tick\n"})
+	marker := filepath.Join(t.TempDir(), "pwned")
+
+	_, err := Checkout(ctx, repo.URL, "--upload-pack=touch "+marker+";git-upload-pack", t.TempDir())
+	require.Error(t, err)
+	assert.Equal(t, "invalid ref --upload-pack=touch "+marker+";git-upload-pack: must not start with '-'", err.Error())
+	_, err = ResolveRef(ctx, repo.URL, "--upload-pack=touch "+marker)
+	require.Error(t, err)
+	_, err = ResolveRef(ctx, "--upload-pack=touch "+marker, "main")
+	require.Error(t, err)
+	assert.Equal(t, "invalid repository url --upload-pack=touch "+marker+": must not start with '-'", err.Error())
+
+	assert.NoFileExists(t, marker)
+}
+
+func TestResolveAnnotatedTag(t *testing.T) {
+	lsRemote := "1111111111111111111111111111111111111111\trefs/heads/v1\n" +
+		"2222222222222222222222222222222222222222\trefs/tags/v1\n" +
+		"3333333333333333333333333333333333333333\trefs/tags/v1^{}\n"
+
+	sha, err := resolveRef("refs/tags/v1", lsRemote)
+	require.NoError(t, err)
+	assert.Equal(t, "3333333333333333333333333333333333333333", sha)
+	sha, err = resolveRef("v1", lsRemote)
+	require.NoError(t, err)
+	assert.Equal(t, "1111111111111111111111111111111111111111", sha)
+}
+
+func TestListAndReadFiles(t *testing.T) {
+	ctx := context.TODO()
+	repo := test.NewGitRepository(t)
+	repo.Commit(t, map[string]string{"kamelets/a.kamelet.yaml": "a", "kamelets/nested/b.kamelet.yaml": "b"})
+	repo.Tag(t, "v1")
+	repo.Commit(t, map[string]string{"kamelets/a.kamelet.yaml": "a2", "kamelets/c.kamelet.yaml": "c"})
+
+	dir := filepath.Join(repo.Dir, "kamelets")
+	files, err := ListFiles(ctx, dir, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.kamelet.yaml"}, files)
+	files, err = ListFiles(ctx, dir, "main")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.kamelet.yaml", "c.kamelet.yaml"}, files)
+
+	content, err := ReadFile(ctx, dir, "v1", "a.kamelet.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(content))
+	content, err = ReadFile(ctx, dir, "main", "a.kamelet.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "a2", string(content))
+
+	_, err = ReadFile(ctx, dir, "v1", "c.kamelet.yaml")
+	require.Error(t, err)
+	_, err = ListFiles(ctx, dir, "unknown")
+	require.Error(t, err)
+}
+
+func TestListAndReadFilesOptionShapedRef(t *testing.T) {
+	ctx := context.TODO()
+	repo := test.NewGitRepository(t)
+	repo.Commit(t, map[string]string{"kamelets/a.kamelet.yaml": "a"})
+	dir := filepath.Join(repo.Dir, "kamelets")
+	output := filepath.Join(t.TempDir(), "pwned")
+
+	_, err := ListFiles(ctx, dir, "--output="+output)
+	require.Error(t, err)
+	assert.Equal(t, "invalid ref --output="+output+": must not start with '-'", err.Error())
+	_, err = ReadFile(ctx, dir, "--output="+output, "a.kamelet.yaml")
+	require.Error(t, err)
+	assert.Equal(t, "invalid ref --output="+output+": must not start with '-'", err.Error())
+
+	assert.NoFileExists(t, output)
+}
@@ -0,0 +1,256 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util"
+)
+
+const (
+	// PropertyUnknownReason is used when a property is not declared in the Kamelet definition.
+	PropertyUnknownReason = "UnknownProperty"
+	// PropertyRequiredReason is used when a required property without default value is not set.
+	PropertyRequiredReason = "RequiredProperty"
+	// PropertyInvalidTypeReason is used when a property value does not match the property type.
+	PropertyInvalidTypeReason = "InvalidType"
+	// PropertyInvalidEnumReason is used when a property value is not one of the allowed values.
+	PropertyInvalidEnumReason = "InvalidEnum"
+	// PropertyInvalidPatternReason is used when a property value does not match the property pattern.
+	PropertyInvalidPatternReason = "InvalidPattern"
+	// PropertyInvalidFormatReason is used when a property value does not match the property format.
+	PropertyInvalidFormatReason = "InvalidFormat"
+	// PropertyOutOfRangeReason is used when a property value is out of the property bounds.
+	PropertyOutOfRangeReason = "OutOfRange"
+	// PropertyInvalidDefaultReason is used when the default value of a property is not valid.
+	PropertyInvalidDefaultReason = "InvalidDefault"
+)
+
+// PropertyViolation describes a property that does not match the definition of a Kamelet.
+type PropertyViolation struct {
+	// Property is the name of the property
+	Property string
+	// Reason is a machine-readable description of the violation
+	Reason string
+	// Message is a human-readable description of the violation
+	Message string
+}
+
+func (v PropertyViolation) String() string {
+	return fmt.Sprintf("property %q %s", v.Property, v.Message)
+}
+
+// ValidateProperties checks the given property values against the definition of the Kamelet, and returns the violations,
+// sorted by property name. The values are the string representations that are passed to the Kamelet, and values using
+// property placeholders are only resolved at runtime, so they are not checked. The provided properties are set by other
+// means, e.g., application properties, and are only taken into account for the required properties.
+func ValidateProperties(kamelet *v1.Kamelet, values map[string]string, provided ...string) []PropertyViolation {
+	definition := kamelet.Spec.Definition
+	if definition == nil || len(definition.Properties) == 0 {
+		return nil
+	}
+
+	violations := make([]PropertyViolation, 0)
+	for name, value := range values {
+		prop, ok := definition.Properties[name]
+		if !ok {
+			violations = append(violations, PropertyViolation{
+				Property: name,
+				Reason:   PropertyUnknownReason,
+				Message:  fmt.Sprintf("is not declared by Kamelet %s", kamelet.Name),
+			})
+			continue
+		}
+		if reason, message := validatePropertyValue(prop, value); reason != "" {
+			violations = append(violations, PropertyViolation{
+				Property: name,
+				Reason:   reason,
+				Message:  message,
+			})
+		}
+	}
+
+	for _, name := range definition.Required {
+		if _, ok := values[name]; ok || util.StringSliceExists(provided, name) {
+			continue
+		}
+		prop := definition.Properties[name]
+		if prop.Default == nil {
+			violations = append(violations, PropertyViolation{
+				Property: name,
+				Reason:   PropertyRequiredReason,
+				Message:  "is required",
+			})
+			continue
+		}
+		// The default value is used, so it must be valid
+		var value interface{}
+		if err := json.Unmarshal(prop.Default.RawMessage, &value); err != nil {
+			continue
+		}
+		if reason, message := validatePropertyValue(prop, fmt.Sprintf("%v", value)); reason != "" {
+			violations = append(violations, PropertyViolation{
+				Property: name,
+				Reason:   PropertyInvalidDefaultReason,
+				Message:  fmt.Sprintf("has an invalid default value: %s", message),
+			})
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Property < violations[j].Property
+	})
+
+	return violations
+}
+
+// validatePropertyValue returns the reason and the message describing why the value doesn't match the property, if any.
+func validatePropertyValue(prop v1.JSONSchemaProp, value string) (string, string) {
+	if strings.Contains(value, "{{") {
+		return "", ""
+	}
+
+	switch prop.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return PropertyInvalidTypeReason, fmt.Sprintf("value %q is not an integer", value)
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return PropertyInvalidTypeReason, fmt.Sprintf("value %q is not a number", value)
+		}
+	case "boolean":
+		if !strings.EqualFold(value, "true") && !strings.EqualFold(value, "false") {
+			return PropertyInvalidTypeReason, fmt.Sprintf("value %q is not a boolean", value)
+		}
+	}
+
+	if len(prop.Enum) > 0 {
+		allowed := make([]string, 0, len(prop.Enum))
+		for _, e := range prop.Enum {
+			var v interface{}
+			if err := json.Unmarshal(e.RawMessage, &v); err != nil {
+				continue
+			}
+			allowed = append(allowed, fmt.Sprintf("%v", v))
+		}
+		if !util.StringSliceExists(allowed, value) {
+			return PropertyInvalidEnumReason, fmt.Sprintf("value %q is not one of [%s]", value, strings.Join(allowed, ","))
+		}
+	}
+
+	if prop.Type == "integer" || prop.Type == "number" {
+		if message := validateRange(prop, value); message != "" {
+			return PropertyOutOfRangeReason, message
+		}
+	}
+
+	if prop.Type == "string" || prop.Type == "" {
+		length := int64(len([]rune(value)))
+		if prop.MinLength != nil && length < *prop.MinLength {
+			return PropertyOutOfRangeReason, fmt.Sprintf("value %q is shorter than %d characters", value, *prop.MinLength)
+		}
+		if prop.MaxLength != nil && length > *prop.MaxLength {
+			return PropertyOutOfRangeReason, fmt.Sprintf("value %q is longer than %d characters", value, *prop.MaxLength)
+		}
+		if prop.Pattern != "" {
+			// Invalid patterns are ignored, as they cannot be checked
+			if pattern, err := regexp.Compile(prop.Pattern); err == nil && !pattern.MatchString(value) {
+				return PropertyInvalidPatternReason, fmt.Sprintf("value %q does not match pattern %q", value, prop.Pattern)
+			}
+		}
+	}
+
+	if prop.Format != "" && !validFormat(prop.Format, value) {
+		return PropertyInvalidFormatReason, fmt.Sprintf("value %q is not a valid %s", value, prop.Format)
+	}
+
+	return "", ""
+}
+
+func validateRange(prop v1.JSONSchemaProp, value string) string {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return ""
+	}
+	if prop.Minimum != nil {
+		if minimum, err := prop.Minimum.Float64(); err == nil {
+			if number < minimum || (prop.ExclusiveMinimum && number == minimum) {
+				return fmt.Sprintf("value %s is lower than the minimum %s", value, *prop.Minimum)
+			}
+		}
+	}
+	if prop.Maximum != nil {
+		if maximum, err := prop.Maximum.Float64(); err == nil {
+			if number > maximum || (prop.ExclusiveMaximum && number == maximum) {
+				return fmt.Sprintf("value %s is greater than the maximum %s", value, *prop.Maximum)
+			}
+		}
+	}
+	return ""
+}
+
+var (
+	hostnameRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+	uuidRegexp     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// validFormat checks the value matches the given format. Unknown formats, e.g., password, are not checked.
+func validFormat(format string, value string) bool {
+	var err error
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	case "date":
+		_, err = time.Parse(time.DateOnly, value)
+	case "email":
+		_, err = mail.ParseAddress(value)
+	case "uri":
+		var u *url.URL
+		u, err = url.Parse(value)
+		if err == nil && !u.IsAbs() {
+			return false
+		}
+	case "hostname":
+		return len(value) <= 253 && hostnameRegexp.MatchString(value)
+	case "ipv4":
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil && !strings.Contains(value, ":")
+	case "ipv6":
+		ip := net.ParseIP(value)
+		return ip != nil && strings.Contains(value, ":")
+	case "uuid":
+		return uuidRegexp.MatchString(value)
+	case "int32":
+		_, err = strconv.ParseInt(value, 10, 32)
+	case "int64":
+		_, err = strconv.ParseInt(value, 10, 64)
+	}
+	return err == nil
+}
@@ -0,0 +1,130 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelets
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func propertiesKamelet() *v1.Kamelet {
+	minimum := json.Number("1")
+	maximum := json.Number("10")
+	maxLength := int64(5)
+	kamelet := v1.NewKamelet("default", "timer-source")
+	kamelet.Spec.Definition = &v1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"message", "period"},
+		Properties: map[string]v1.JSONSchemaProp{
+			"message": {
+				Type: "string",
+			},
+			"period": {
+				Type:    "integer",
+				Default: &v1.JSON{RawMessage: v1.RawMessage("5")},
+				Minimum: &minimum,
+				Maximum: &maximum,
+			},
+			"enabled": {
+				Type: "boolean",
+			},
+			"level": {
+				Type: "string",
+				Enum: []v1.JSON{{RawMessage: v1.RawMessage(`"INFO"`)}, {RawMessage: v1.RawMessage(`"DEBUG"`)}},
+			},
+			"code": {
+				Type:      "string",
+				Pattern:   "^[A-Z]+$",
+				MaxLength: &maxLength,
+			},
+			"contact": {
+				Type:   "string",
+				Format: "email",
+			},
+			"password": {
+				Type:   "string",
+				Format: "password",
+			},
+		},
+	}
+	return &kamelet
+}
+
+func TestValidateProperties(t *testing.T) {
+	kamelet := propertiesKamelet()
+
+	assert.Empty(t, ValidateProperties(kamelet, map[string]string{
+		"message":  "hello",
+		"enabled":  "true",
+		"level":    "DEBUG",
+		"code":     "ABC",
+		"contact":  "john@example.com",
+		"password": "secret",
+	}))
+	assert.Empty(t, ValidateProperties(kamelet, map[string]string{
+		"message": "{{message}}",
+		"period":  "{{period}}",
+		"level":   "{{secret:my-secret/level}}",
+	}))
+	assert.Empty(t, ValidateProperties(kamelet, map[string]string{}, "message"))
+	assert.Empty(t, ValidateProperties(&v1.Kamelet{}, map[string]string{"any": "value"}))
+}
+
+func TestValidatePropertiesWithViolations(t *testing.T) {
+	kamelet := propertiesKamelet()
+
+	violations := ValidateProperties(kamelet, map[string]string{
+		"mesage":  "hello",
+		"period":  "20",
+		"enabled": "yes",
+		"level":   "TRACE",
+		"code":    "abc",
+		"contact": "john",
+	})
+	assert.Equal(t, []PropertyViolation{
+		{Property: "code", Reason: PropertyInvalidPatternReason, Message: `value "abc" does not match pattern "^[A-Z]+$"`},
+		{Property: "contact", Reason: PropertyInvalidFormatReason, Message: `value "john" is not a valid email`},
+		{Property: "enabled", Reason: PropertyInvalidTypeReason, Message: `value "yes" is not a boolean`},
+		{Property: "level", Reason: PropertyInvalidEnumReason, Message: `value "TRACE" is not one of [INFO,DEBUG]`},
+		{Property: "mesage", Reason: PropertyUnknownReason, Message: "is not declared by Kamelet timer-source"},
+		{Property: "message", Reason: PropertyRequiredReason, Message: "is required"},
+		{Property: "period", Reason: PropertyOutOfRangeReason, Message: "value 20 is greater than the maximum 10"},
+	}, violations)
+	assert.Equal(t, `property "mesage" is not declared by Kamelet timer-source`, violations[4].String())
+
+	violations = ValidateProperties(kamelet, map[string]string{
+		"message": "hello",
+		"period":  "1s",
+		"code":    "ABCDEF",
+	})
+	assert.Equal(t, []PropertyViolation{
+		{Property: "code", Reason: PropertyOutOfRangeReason, Message: `value "ABCDEF" is longer than 5 characters`},
+		{Property: "period", Reason: PropertyInvalidTypeReason, Message: `value "1s" is not an integer`},
+	}, violations)
+
+	prop := kamelet.Spec.Definition.Properties["period"]
+	prop.Default = &v1.JSON{RawMessage: v1.RawMessage("50")}
+	kamelet.Spec.Definition.Properties["period"] = prop
+	assert.Equal(t, []PropertyViolation{
+		{Property: "period", Reason: PropertyInvalidDefaultReason, Message: "has an invalid default value: value 50 is greater than the maximum 10"},
+	}, ValidateProperties(kamelet, map[string]string{"message": "hello"}))
+}
@@ -0,0 +1,170 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelets
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+var jsonSchemaTypes = map[string]bool{
+	"array":   true,
+	"boolean": true,
+	"integer": true,
+	"number":  true,
+	"object":  true,
+	"string":  true,
+}
+
+// ValidateKamelet checks the JSON schemas declared by the given Kamelet, that is its definition
+// and the schemas of the data types it consumes and produces, are well-formed.
+func ValidateKamelet(kamelet *v1.Kamelet) error {
+	if kamelet.Spec.Definition != nil {
+		if err := ValidateSchema(kamelet.Spec.Definition); err != nil {
+			return fmt.Errorf("invalid definition: %w", err)
+		}
+	}
+
+	for slot, types := range kamelet.Spec.DataTypes {
+		if types.Default != "" {
+			if _, ok := types.Types[types.Default]; !ok {
+				return fmt.Errorf("invalid %s data types: default data type %q is not declared", slot, types.Default)
+			}
+		}
+		for name, dataType := range types.Types {
+			if dataType.Schema == nil {
+				continue
+			}
+			if err := ValidateSchema(dataType.Schema); err != nil {
+				return fmt.Errorf("invalid schema for %s data type %q: %w", slot, name, err)
+			}
+		}
+	}
+
+	for slot, eventType := range kamelet.Spec.Types {
+		if eventType.Schema == nil {
+			continue
+		}
+		if err := ValidateSchema(eventType.Schema); err != nil {
+			return fmt.Errorf("invalid schema for %s type: %w", slot, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateSchema checks the given JSON schema is well-formed, i.e., it only uses known types, its required
+// properties are declared, its bounds are consistent, and its default and enum values match the property types.
+func ValidateSchema(schema *v1.JSONSchemaProps) error {
+	if schema.Type != "" && !jsonSchemaTypes[schema.Type] {
+		return fmt.Errorf("unknown type %q", schema.Type)
+	}
+
+	for _, name := range schema.Required {
+		if _, ok := schema.Properties[name]; !ok {
+			return fmt.Errorf("required property %q is not declared", name)
+		}
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := validateSchemaProperty(schema.Properties[name]); err != nil {
+			return fmt.Errorf("invalid property %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateSchemaProperty(prop v1.JSONSchemaProp) error {
+	if prop.Type != "" && !jsonSchemaTypes[prop.Type] {
+		return fmt.Errorf("unknown type %q", prop.Type)
+	}
+
+	if prop.Minimum != nil && prop.Maximum != nil {
+		minimum, err := prop.Minimum.Float64()
+		if err != nil {
+			return fmt.Errorf("invalid minimum %q", *prop.Minimum)
+		}
+		maximum, err := prop.Maximum.Float64()
+		if err != nil {
+			return fmt.Errorf("invalid maximum %q", *prop.Maximum)
+		}
+		if minimum > maximum {
+			return fmt.Errorf("minimum %s is greater than maximum %s", *prop.Minimum, *prop.Maximum)
+		}
+	}
+	if prop.MinLength != nil && prop.MaxLength != nil && *prop.MinLength > *prop.MaxLength {
+		return fmt.Errorf("minLength %d is greater than maxLength %d", *prop.MinLength, *prop.MaxLength)
+	}
+	if prop.MinItems != nil && prop.MaxItems != nil && *prop.MinItems > *prop.MaxItems {
+		return fmt.Errorf("minItems %d is greater than maxItems %d", *prop.MinItems, *prop.MaxItems)
+	}
+
+	if prop.Default != nil {
+		if err := validateSchemaValue(prop.Type, prop.Default); err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
+	}
+	for _, value := range prop.Enum {
+		value := value
+		if err := validateSchemaValue(prop.Type, &value); err != nil {
+			return fmt.Errorf("invalid enum value: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateSchemaValue checks the given JSON value is valid, and matches the given schema type.
+func validateSchemaValue(schemaType string, value *v1.JSON) error {
+	var v interface{}
+	if err := json.Unmarshal(value.RawMessage, &v); err != nil {
+		return err
+	}
+
+	valid := true
+	switch schemaType {
+	case "string":
+		_, valid = v.(string)
+	case "boolean":
+		_, valid = v.(bool)
+	case "number":
+		_, valid = v.(float64)
+	case "integer":
+		f, ok := v.(float64)
+		valid = ok && f == float64(int64(f))
+	case "array":
+		_, valid = v.([]interface{})
+	case "object":
+		_, valid = v.(map[string]interface{})
+	}
+	if !valid {
+		return fmt.Errorf("%s is not of type %s", string(value.RawMessage), schemaType)
+	}
+
+	return nil
+}
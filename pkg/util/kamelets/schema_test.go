@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelets
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func TestValidateSchema(t *testing.T) {
+	minimum := json.Number("1")
+	maximum := json.Number("10")
+	schema := v1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"period"},
+		Properties: map[string]v1.JSONSchemaProp{
+			"period": {
+				Type:    "integer",
+				Default: &v1.JSON{RawMessage: v1.RawMessage("5")},
+				Minimum: &minimum,
+				Maximum: &maximum,
+			},
+			"message": {
+				Type: "string",
+				Enum: []v1.JSON{{RawMessage: v1.RawMessage(`"hello"`)}},
+			},
+		},
+	}
+
+	require.NoError(t, ValidateSchema(&schema))
+}
+
+func TestValidateSchemaWithErrors(t *testing.T) {
+	minimum := json.Number("10")
+	maximum := json.Number("1")
+
+	tests := []struct {
+		name   string
+		schema v1.JSONSchemaProps
+		err    string
+	}{
+		{
+			name:   "unknown type",
+			schema: v1.JSONSchemaProps{Type: "map"},
+			err:    `unknown type "map"`,
+		},
+		{
+			name:   "undeclared required property",
+			schema: v1.JSONSchemaProps{Type: "object", Required: []string{"period"}},
+			err:    `required property "period" is not declared`,
+		},
+		{
+			name: "inconsistent bounds",
+			schema: v1.JSONSchemaProps{Properties: map[string]v1.JSONSchemaProp{
+				"period": {Type: "integer", Minimum: &minimum, Maximum: &maximum},
+			}},
+			err: "minimum 10 is greater than maximum 1",
+		},
+		{
+			name: "mismatching default value",
+			schema: v1.JSONSchemaProps{Properties: map[string]v1.JSONSchemaProp{
+				"period": {Type: "integer", Default: &v1.JSON{RawMessage: v1.RawMessage(`"1s"`)}},
+			}},
+			err: `"1s" is not of type integer`,
+		},
+		{
+			name: "mismatching enum value",
+			schema: v1.JSONSchemaProps{Properties: map[string]v1.JSONSchemaProp{
+				"enabled": {Type: "boolean", Enum: []v1.JSON{{RawMessage: v1.RawMessage("1")}}},
+			}},
+			err: "1 is not of type boolean",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateSchema(&test.schema)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestValidateKameletDataTypes(t *testing.T) {
+	kamelet := v1.NewKamelet("default", "my-source")
+	kamelet.Spec.DataTypes = map[v1.TypeSlot]v1.DataTypesSpec{
+		v1.TypeSlotOut: {
+			Default: "json",
+			Types: map[string]v1.DataTypeSpec{
+				"text": {},
+			},
+		},
+	}
+
+	err := ValidateKamelet(&kamelet)
+	require.Error(t, err)
+	assert.Equal(t, `invalid out data types: default data type "json" is not declared`, err.Error())
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+const (
+	sourceEndpoint = "kamelet:source"
+	sinkEndpoint   = "kamelet:sink"
+)
+
+// ValidateTemplate checks the template of the given Kamelet is a valid route template, that consumes from
+// and produces to the `kamelet:source` and `kamelet:sink` endpoints according to the Kamelet type.
+func ValidateTemplate(kamelet *v1.Kamelet) error {
+	if kamelet.Spec.Template == nil {
+		if len(kamelet.Spec.Sources) > 0 {
+			// Deprecated sources can be written in any DSL and are not inspected
+			return nil
+		}
+		return errors.New("no template specified")
+	}
+
+	var template map[string]interface{}
+	if err := json.Unmarshal(kamelet.Spec.Template.RawMessage, &template); err != nil {
+		return fmt.Errorf("cannot parse template: %w", err)
+	}
+	from, ok := template["from"].(map[string]interface{})
+	if !ok {
+		if route, isMap := template["route"].(map[string]interface{}); isMap {
+			from, ok = route["from"].(map[string]interface{})
+		}
+	}
+	if !ok {
+		return errors.New("the template must declare a from clause")
+	}
+	uri, ok := from["uri"].(string)
+	if !ok || uri == "" {
+		return errors.New("the from clause of the template must declare an uri")
+	}
+
+	consumesSource := isEndpoint(uri, sourceEndpoint)
+	producesSink := false
+	walkStrings(from["steps"], func(s string) {
+		if isEndpoint(s, sinkEndpoint) {
+			producesSink = true
+		}
+	})
+
+	switch kamelet.Labels[v1.KameletTypeLabel] {
+	case v1.KameletTypeSource:
+		if consumesSource {
+			return fmt.Errorf("a source Kamelet cannot consume from %s", sourceEndpoint)
+		}
+		if !producesSink {
+			return fmt.Errorf("a source Kamelet must produce to %s", sinkEndpoint)
+		}
+	case v1.KameletTypeSink:
+		if !consumesSource {
+			return fmt.Errorf("a sink Kamelet must consume from %s", sourceEndpoint)
+		}
+	case v1.KameletTypeAction:
+		if !consumesSource {
+			return fmt.Errorf("an action Kamelet must consume from %s", sourceEndpoint)
+		}
+	}
+
+	return nil
+}
+
+// isEndpoint returns true if the given URI refers to the given endpoint, possibly with parameters.
+func isEndpoint(uri string, endpoint string) bool {
+	return uri == endpoint || strings.HasPrefix(uri, endpoint+"?")
+}
+
+// walkStrings calls the given function for each string value held by the given unstructured value.
+func walkStrings(value interface{}, f func(string)) {
+	switch v := value.(type) {
+	case string:
+		f(v)
+	case []interface{}:
+		for _, item := range v {
+			walkStrings(item, f)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			walkStrings(item, f)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		kameletType string
+		template    string
+		err         string
+	}{
+		{
+			name:        "source",
+			kameletType: v1.KameletTypeSource,
+			template:    `{"from":{"uri":"timer:tick","steps":[{"to":"kamelet:sink"}]}}`,
+		},
+		{
+			name:        "source with route",
+			kameletType: v1.KameletTypeSource,
+			template:    `{"route":{"from":{"uri":"timer:tick","steps":[{"to":{"uri":"kamelet:sink"}}]}}}`,
+		},
+		{
+			name:        "sink",
+			kameletType: v1.KameletTypeSink,
+			template:    `{"from":{"uri":"kamelet:source","steps":[{"to":"log:info"}]}}`,
+		},
+		{
+			name:        "action",
+			kameletType: v1.KameletTypeAction,
+			template:    `{"from":{"uri":"kamelet:source","steps":[{"setBody":{"constant":"hello"}}]}}`,
+		},
+		{
+			name:     "no from",
+			template: `{"to":"log:info"}`,
+			err:      "the template must declare a from clause",
+		},
+		{
+			name:     "no uri",
+			template: `{"from":{"steps":[]}}`,
+			err:      "the from clause of the template must declare an uri",
+		},
+		{
+			name:        "source consuming from source",
+			kameletType: v1.KameletTypeSource,
+			template:    `{"from":{"uri":"kamelet:source","steps":[{"to":"kamelet:sink"}]}}`,
+			err:         "a source Kamelet cannot consume from kamelet:source",
+		},
+		{
+			name:        "sink not consuming from source",
+			kameletType: v1.KameletTypeSink,
+			template:    `{"from":{"uri":"timer:tick","steps":[{"to":"log:info"}]}}`,
+			err:         "a sink Kamelet must consume from kamelet:source",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kamelet := v1.NewKamelet("default", "my-kamelet")
+			kamelet.Labels = map[string]string{
+				v1.KameletTypeLabel: test.kameletType,
+			}
+			kamelet.Spec.Template = &v1.Template{RawMessage: v1.RawMessage(test.template)}
+
+			err := ValidateTemplate(&kamelet)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, test.err, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateTemplateWithSources(t *testing.T) {
+	kamelet := v1.NewKamelet("default", "my-kamelet")
+	require.Error(t, ValidateTemplate(&kamelet))
+
+	kamelet.Spec.Sources = []v1.SourceSpec{
+		{DataSpec: v1.DataSpec{Name: "source.groovy", Content: "from('kamelet:source').to('log:info')"}},
+	}
+	require.NoError(t, ValidateTemplate(&kamelet))
+}
@@ -19,6 +19,8 @@ package kamelets
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strings"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
@@ -57,3 +59,25 @@ func ExtractKameletFromSources(context context.Context, c client.Client, catalog
 
 	return kamelets, nil
 }
+
+// NotReadyMessage returns a message reporting the Kamelets, indexed by name, that have been found invalid
+// by the Kamelet controller, if any.
+func NotReadyMessage(kamelets map[string]*v1.Kamelet) string {
+	notReady := make([]string, 0)
+	for name, kamelet := range kamelets {
+		if kamelet == nil || kamelet.Status.Phase != v1.KameletPhaseError {
+			continue
+		}
+		reason := "unknown reason"
+		if condition := kamelet.Status.GetCondition(v1.KameletConditionReady); condition != nil && condition.Message != "" {
+			reason = condition.Message
+		}
+		notReady = append(notReady, fmt.Sprintf("%s (%s)", name, reason))
+	}
+	if len(notReady) == 0 {
+		return ""
+	}
+	sort.Strings(notReady)
+
+	return fmt.Sprintf("kamelets [%s] are not ready", strings.Join(notReady, ","))
+}
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func TestNotReadyMessage(t *testing.T) {
+	ready := v1.NewKamelet("ns", "ready")
+	ready.Status.Phase = v1.KameletPhaseReady
+	invalid := v1.NewKamelet("ns", "invalid")
+	invalid.Status.Phase = v1.KameletPhaseError
+	invalid.Status.SetCondition(v1.KameletConditionReady, corev1.ConditionFalse, "InvalidDefinition", "invalid definition")
+	unknown := v1.NewKamelet("ns", "unknown")
+	unknown.Status.Phase = v1.KameletPhaseError
+
+	assert.Equal(t, "", NotReadyMessage(map[string]*v1.Kamelet{"ready": &ready, "missing": nil}))
+	assert.Equal(t, "kamelets [invalid (invalid definition),unknown (unknown reason)] are not ready",
+		NotReadyMessage(map[string]*v1.Kamelet{"unknown": &unknown, "ready": &ready, "invalid": &invalid}))
+}
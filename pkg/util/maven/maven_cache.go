@@ -0,0 +1,326 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+// CacheRepositoryID is the identifier of the repository that serves the artifacts from the Maven cache.
+const CacheRepositoryID = "camel-k-maven-cache"
+
+const (
+	remoteRepositoriesFile = "_remote.repositories"
+	cacheLockFile          = ".lock"
+	// cacheLockTimeout is the duration after which a lock is considered stale,
+	// e.g., when the builder Pod that acquired it has been killed.
+	cacheLockTimeout = 10 * time.Minute
+)
+
+// CacheRepository returns the option that configures the cache directory as the first repository,
+// so that the artifacts it contains are resolved before reaching the remote repositories.
+func CacheRepository(dir string) SettingsOption {
+	return cacheRepository{
+		dir: dir,
+	}
+}
+
+type cacheRepository struct {
+	dir string
+}
+
+func (o cacheRepository) apply(settings *Settings) error {
+	repository := v1.Repository{
+		ID:  CacheRepositoryID,
+		URL: "file://" + o.dir,
+		Releases: v1.RepositoryPolicy{
+			Enabled: true,
+			// The local repository layout does not store the checksum files
+			ChecksumPolicy: "ignore",
+		},
+		Snapshots: v1.RepositoryPolicy{
+			Enabled:        false,
+			ChecksumPolicy: "ignore",
+		},
+	}
+	profile := &settings.Profiles[0]
+	profile.Repositories = append([]v1.Repository{repository}, profile.Repositories...)
+	profile.PluginRepositories = append([]v1.Repository{repository}, profile.PluginRepositories...)
+	for i := range settings.Mirrors {
+		settings.Mirrors[i].MirrorOf = excludeCacheFromMirrorOf(settings.Mirrors[i].MirrorOf)
+	}
+	return nil
+}
+
+var mirrorOfPattern = regexp.MustCompile(`(<mirrorOf>)([^<]*)(</mirrorOf>)`)
+
+// ExcludeCacheFromMirrors excludes the cache repository from the mirrors of all the repositories
+// declared in the given Maven settings, so that the cache is not bypassed by these mirrors.
+func ExcludeCacheFromMirrors(settings string) string {
+	return mirrorOfPattern.ReplaceAllStringFunc(settings, func(mirror string) string {
+		groups := mirrorOfPattern.FindStringSubmatch(mirror)
+		return groups[1] + excludeCacheFromMirrorOf(groups[2]) + groups[3]
+	})
+}
+
+// excludeCacheFromMirrorOf excludes the cache repository from the given mirrorOf value when it matches
+// all the repositories. The external:* pattern already excludes the file based repositories.
+func excludeCacheFromMirrorOf(mirrorOf string) string {
+	all := false
+	for _, pattern := range strings.Split(mirrorOf, ",") {
+		switch strings.TrimSpace(pattern) {
+		case "*":
+			all = true
+		case "!" + CacheRepositoryID:
+			return mirrorOf
+		}
+	}
+	if !all {
+		return mirrorOf
+	}
+	return mirrorOf + ",!" + CacheRepositoryID
+}
+
+// PublishToCache copies the artifacts that have been downloaded into the local repository since the given time
+// to the cache directory, and returns how many of them have been resolved from the cache.
+// Artifacts are published atomically, so that the cache can be shared by concurrent builds.
+func PublishToCache(localRepository string, cache string, since time.Time) (v1.MavenCacheStatus, error) {
+	status := v1.MavenCacheStatus{}
+	// Account for file systems with a coarse modification time granularity
+	since = since.Truncate(time.Second)
+
+	err := filepath.WalkDir(localRepository, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != remoteRepositoriesFile {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().Before(since) {
+			// Not resolved by this build
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		rel, err := filepath.Rel(localRepository, dir)
+		if err != nil {
+			return err
+		}
+		artifacts, err := readRemoteRepositories(path)
+		if err != nil {
+			return err
+		}
+		for file, repository := range artifacts {
+			target := filepath.Join(cache, rel, file)
+			switch repository {
+			case "":
+				// Installed locally
+				continue
+			case CacheRepositoryID:
+				status.Hits++
+				// Record the access for the eviction of the least recently used artifacts
+				now := time.Now()
+				if err := os.Chtimes(target, now, now); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			default:
+				status.Misses++
+				if err := publishFile(filepath.Join(dir, file), target); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+
+	return status, err
+}
+
+// readRemoteRepositories returns the repository each artifact file has been resolved from,
+// as recorded by the Maven resolver.
+func readRemoteRepositories(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	artifacts := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// The format is <file>><repository>=
+		file, repository, found := strings.Cut(strings.TrimSuffix(line, "="), ">")
+		if !found {
+			continue
+		}
+		artifacts[file] = repository
+	}
+
+	return artifacts, scanner.Err()
+}
+
+func publishFile(source string, target string) error {
+	if _, err := os.Stat(target); err == nil {
+		// Already published by another build
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	in, err := os.Open(source)
+	if os.IsNotExist(err) {
+		// The artifact may only have been partially resolved, e.g., its POM but not its JAR
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// #nosec G301
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	// Hidden temporary files are not visible to the other builds, nor to the eviction
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// #nosec G302
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), target)
+}
+
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// EvictFromCache deletes the least recently used artifacts from the cache directory, until its content size
+// goes below the given maximum size. It returns the number of deleted files.
+// The eviction is skipped if it's already being performed by another build.
+func EvictFromCache(cache string, maxSize int64) (int, error) {
+	unlock, err := lockCache(cache)
+	if err != nil {
+		return 0, err
+	}
+	if unlock == nil {
+		return 0, nil
+	}
+	defer unlock()
+
+	var entries []cacheEntry
+	var size int64
+	err = filepath.WalkDir(cache, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entries = append(entries, cacheEntry{path: path, size: info.Size(), modTime: info.ModTime()})
+		size += info.Size()
+		return nil
+	})
+	if err != nil || size <= maxSize {
+		return 0, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	deleted := 0
+	for _, entry := range entries {
+		if size <= maxSize {
+			break
+		}
+		if err := os.Remove(entry.path); err != nil && !os.IsNotExist(err) {
+			return deleted, err
+		}
+		size -= entry.size
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// lockCache acquires the cache lock, and returns the function that releases it,
+// or nil if the lock is held by another build.
+func lockCache(cache string) (func(), error) {
+	lock := filepath.Join(cache, cacheLockFile)
+	for i := 0; i < 2; i++ {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			f.Close()
+			return func() {
+				os.Remove(lock)
+			}, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("cannot lock Maven cache: %w", err)
+		}
+		info, err := os.Stat(lock)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil && time.Since(info.ModTime()) < cacheLockTimeout {
+			return nil, nil
+		}
+		// Release the stale lock and retry
+		if err := os.Remove(lock); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeArtifact(t *testing.T, dir string, file string, content string, repository string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600))
+	remote := "#NOTE: This is a Maven Resolver internal implementation file\n" + file + ">" + repository + "=\n"
+	f, err := os.OpenFile(filepath.Join(dir, remoteRepositoriesFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(remote)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func TestCacheRepositorySettings(t *testing.T) {
+	settings, err := NewSettings(DefaultRepositories, CacheRepository("/cache"))
+	require.NoError(t, err)
+
+	repositories := settings.Profiles[0].Repositories
+	require.Len(t, repositories, 2)
+	assert.Equal(t, CacheRepositoryID, repositories[0].ID)
+	assert.Equal(t, "file:///cache", repositories[0].URL)
+	assert.Equal(t, "central", repositories[1].ID)
+	assert.Equal(t, CacheRepositoryID, settings.Profiles[0].PluginRepositories[0].ID)
+}
+
+func TestCacheRepositoryMirrors(t *testing.T) {
+	settings, err := NewSettings(
+		Repositories("https://all@id=all@mirrorOf=*", "https://central@id=central@mirrorOf=central"),
+		CacheRepository("/cache"),
+	)
+	require.NoError(t, err)
+
+	require.Len(t, settings.Mirrors, 2)
+	assert.Equal(t, "*,!"+CacheRepositoryID, settings.Mirrors[0].MirrorOf)
+	assert.Equal(t, "central", settings.Mirrors[1].MirrorOf)
+}
+
+func TestExcludeCacheFromMirrors(t *testing.T) {
+	settings := `<settings>
+  <mirrors>
+    <mirror><id>all</id><mirrorOf>*</mirrorOf><url>https://all</url></mirror>
+    <mirror><id>some</id><mirrorOf>* , !snapshots</mirrorOf><url>https://some</url></mirror>
+    <mirror><id>excluded</id><mirrorOf>*,!camel-k-maven-cache</mirrorOf><url>https://excluded</url></mirror>
+    <mirror><id>external</id><mirrorOf>external:*</mirrorOf><url>https://external</url></mirror>
+    <mirror><id>central</id><mirrorOf>central</mirrorOf><url>https://central</url></mirror>
+  </mirrors>
+</settings>`
+
+	assert.Equal(t, `<settings>
+  <mirrors>
+    <mirror><id>all</id><mirrorOf>*,!camel-k-maven-cache</mirrorOf><url>https://all</url></mirror>
+    <mirror><id>some</id><mirrorOf>* , !snapshots,!camel-k-maven-cache</mirrorOf><url>https://some</url></mirror>
+    <mirror><id>excluded</id><mirrorOf>*,!camel-k-maven-cache</mirrorOf><url>https://excluded</url></mirror>
+    <mirror><id>external</id><mirrorOf>external:*</mirrorOf><url>https://external</url></mirror>
+    <mirror><id>central</id><mirrorOf>central</mirrorOf><url>https://central</url></mirror>
+  </mirrors>
+</settings>`, ExcludeCacheFromMirrors(settings))
+}
+
+func TestPublishToCache(t *testing.T) {
+	local := t.TempDir()
+	cache := t.TempDir()
+
+	// Pre-existing artifact that has not been resolved by the build
+	old := filepath.Join(local, "org", "old", "1.0")
+	writeArtifact(t, old, "old-1.0.jar", "old", "central")
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(old, remoteRepositoriesFile), past, past))
+
+	since := time.Now()
+	writeArtifact(t, filepath.Join(local, "org", "foo", "1.0"), "foo-1.0.jar", "foo", "central")
+	writeArtifact(t, filepath.Join(local, "org", "foo", "1.0"), "foo-1.0.pom", "pom", "central")
+	writeArtifact(t, filepath.Join(local, "org", "bar", "2.0"), "bar-2.0.jar", "bar", CacheRepositoryID)
+	writeArtifact(t, filepath.Join(local, "org", "baz", "3.0"), "baz-3.0.jar", "baz", "")
+
+	status, err := PublishToCache(local, cache, since)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), status.Hits)
+	assert.Equal(t, int32(2), status.Misses)
+
+	content, err := os.ReadFile(filepath.Join(cache, "org", "foo", "1.0", "foo-1.0.jar"))
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(content))
+	assert.FileExists(t, filepath.Join(cache, "org", "foo", "1.0", "foo-1.0.pom"))
+	assert.NoFileExists(t, filepath.Join(cache, "org", "foo", "1.0", remoteRepositoriesFile))
+	assert.NoFileExists(t, filepath.Join(cache, "org", "old", "1.0", "old-1.0.jar"))
+	assert.NoFileExists(t, filepath.Join(cache, "org", "baz", "3.0", "baz-3.0.jar"))
+}
+
+func TestEvictFromCache(t *testing.T) {
+	cache := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"a.jar", "b.jar", "c.jar"} {
+		path := filepath.Join(cache, name)
+		require.NoError(t, os.WriteFile(path, make([]byte, 10), 0o600))
+		modTime := now.Add(time.Duration(i-3) * time.Minute)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	deleted, err := EvictFromCache(cache, 25)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.NoFileExists(t, filepath.Join(cache, "a.jar"))
+	assert.FileExists(t, filepath.Join(cache, "b.jar"))
+	assert.FileExists(t, filepath.Join(cache, "c.jar"))
+	assert.NoFileExists(t, filepath.Join(cache, cacheLockFile))
+
+	deleted, err = EvictFromCache(cache, 25)
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
+
+func TestEvictFromCacheLocked(t *testing.T) {
+	cache := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(cache, "a.jar"), make([]byte, 10), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(cache, cacheLockFile), nil, 0o600))
+
+	deleted, err := EvictFromCache(cache, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+	assert.FileExists(t, filepath.Join(cache, "a.jar"))
+
+	// Stale lock
+	past := time.Now().Add(-2 * cacheLockTimeout)
+	require.NoError(t, os.Chtimes(filepath.Join(cache, cacheLockFile), past, past))
+	deleted, err = EvictFromCache(cache, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/v2/pkg/client"
+)
+
+// DeleteImage deletes the given image from the container registry. The image is authenticated against the registry
+// with the configuration held by the given secret, if any. Deleting an image which doesn't exist is not an error.
+func DeleteImage(ctx context.Context, c client.Client, namespace, secretName, image string, insecure bool) error {
+	var options []name.Option
+	if insecure {
+		options = append(options, name.Insecure)
+	}
+	ref, err := name.ParseReference(image, options...)
+	if err != nil {
+		return err
+	}
+
+	keychain := authn.NewMultiKeychain()
+	if secretName != "" {
+		secret, err := c.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if keychain, err = newSecretKeychain(secret.Data); err != nil {
+			return err
+		}
+	}
+
+	err = remote.Delete(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+	var terr *transport.Error
+	if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// secretKeychain resolves the registry credentials from the content of a Docker config secret.
+type secretKeychain struct {
+	auths map[string]DockerConfig
+}
+
+func newSecretKeychain(data map[string][]byte) (authn.Keychain, error) {
+	auths := make(map[string]DockerConfig)
+	for file, content := range data {
+		switch remap(file) {
+		case "config.json":
+			var config DockerConfigList
+			if err := json.Unmarshal(content, &config); err != nil {
+				return nil, err
+			}
+			for server, auth := range config.Auths {
+				auths[server] = auth
+			}
+		case ".dockercfg":
+			if err := json.Unmarshal(content, &auths); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return secretKeychain{auths: auths}, nil
+}
+
+func (k secretKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	for server, auth := range k.auths {
+		if registryHost(server) == target.RegistryStr() {
+			return authn.FromConfig(authn.AuthConfig{
+				Username: auth.Username,
+				Password: auth.Password,
+				Auth:     auth.Auth,
+			}), nil
+		}
+	}
+	return authn.Anonymous, nil
+}
+
+// registryHost returns the registry host of a Docker config server entry, that can be an URL.
+func registryHost(server string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	if host == "docker.io" {
+		return name.DefaultRegistry
+	}
+	return host
+}
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+func TestDeleteImage(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	ref, err := name.ParseReference(u.Host + "/ns/kit-1:latest")
+	require.NoError(t, err)
+	img, err := random.Image(1024, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	image := u.Host + "/ns/kit-1@" + digest.String()
+
+	c, err := test.NewFakeClient()
+	require.NoError(t, err)
+	require.NoError(t, DeleteImage(context.TODO(), c, "ns", "", image, true))
+	_, err = remote.Head(ref.Context().Digest(digest.String()))
+	require.Error(t, err)
+
+	// the image does not exist anymore
+	require.NoError(t, DeleteImage(context.TODO(), c, "ns", "", image, true))
+}
+
+func TestSecretKeychain(t *testing.T) {
+	keychain, err := newSecretKeychain(map[string][]byte{
+		".dockerconfigjson": []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"bmljOnBhc3M="},"quay.io":{"username":"nic","password":"pass"}}}`),
+	})
+	require.NoError(t, err)
+
+	for _, image := range []string{"docker.io/nic/kit", "quay.io/nic/kit"} {
+		ref, err := name.ParseReference(image)
+		require.NoError(t, err)
+		auth, err := keychain.Resolve(ref.Context())
+		require.NoError(t, err)
+		config, err := auth.Authorization()
+		require.NoError(t, err)
+		assert.NotEqual(t, authn.Anonymous, auth)
+		assert.True(t, config.Auth == "bmljOnBhc3M=" || (config.Username == "nic" && config.Password == "pass"))
+	}
+
+	ref, err := name.ParseReference("gcr.io/nic/kit")
+	require.NoError(t, err)
+	auth, err := keychain.Resolve(ref.Context())
+	require.NoError(t, err)
+	assert.Equal(t, authn.Anonymous, auth)
+}
@@ -0,0 +1,227 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+const (
+	// KindLabel is the label holding the kind of the resource a revision belongs to.
+	KindLabel = "camel.apache.org/revision.kind"
+	// NameLabel is the label holding the name of the resource a revision belongs to.
+	NameLabel = "camel.apache.org/revision.name"
+	// DigestAnnotation is the annotation holding the digest of the Integration a revision was recorded for.
+	DigestAnnotation = "camel.apache.org/revision.digest"
+	// HistoryLimitAnnotation can be set on an Integration or a Pipe to change the number of revisions that are kept.
+	HistoryLimitAnnotation = "camel.apache.org/revision-history.limit"
+
+	// DefaultHistoryLimit is the number of revisions kept when no limit is configured.
+	DefaultHistoryLimit = 10
+)
+
+// Revision is a snapshot of an Integration or a Pipe, recorded once it has been successfully deployed.
+type Revision struct {
+	// Number is the sequence number of the revision
+	Number int64 `json:"-"`
+	// Namespace is the namespace of the resource the revision belongs to
+	Namespace string `json:"-"`
+	// Spec is the JSON serialized specification of the Integration or the Pipe
+	Spec json.RawMessage `json:"spec"`
+	// Digest is the digest of the deployed Integration
+	Digest string `json:"digest,omitempty"`
+	// IntegrationKit is the kit the Integration was running with
+	IntegrationKit *corev1.ObjectReference `json:"integrationKit,omitempty"`
+	// Image is the container image the Integration was running with
+	Image string `json:"image,omitempty"`
+	// Timestamp is the time the revision was recorded
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// DecodeSpec unmarshals the recorded specification into the given target.
+func (r *Revision) DecodeSpec(target interface{}) error {
+	return json.Unmarshal(r.Spec, target)
+}
+
+// List returns the revisions recorded for the resource with the given kind and name, ordered by number.
+func List(ctx context.Context, c ctrl.Reader, namespace string, kind string, name string) ([]Revision, error) {
+	items, err := listControllerRevisions(ctx, c, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode(items)
+}
+
+// ListAll returns the revisions recorded for all the resources of the given namespace, or of all the namespaces
+// when the namespace is empty.
+func ListAll(ctx context.Context, c ctrl.Reader, namespace string) ([]Revision, error) {
+	list := appsv1.ControllerRevisionList{}
+	if err := c.List(ctx, &list, ctrl.InNamespace(namespace), ctrl.HasLabels{KindLabel}); err != nil {
+		return nil, err
+	}
+
+	return decode(list.Items)
+}
+
+func decode(items []appsv1.ControllerRevision) ([]Revision, error) {
+	revisions := make([]Revision, 0, len(items))
+	for i := range items {
+		r := Revision{}
+		if err := json.Unmarshal(items[i].Data.Raw, &r); err != nil {
+			return nil, fmt.Errorf("unable to decode revision %s: %w", items[i].Name, err)
+		}
+		r.Number = items[i].Revision
+		r.Namespace = items[i].Namespace
+		revisions = append(revisions, r)
+	}
+
+	return revisions, nil
+}
+
+// Get returns the revision with the given number, or nil if it does not exist.
+func Get(ctx context.Context, c ctrl.Reader, namespace string, kind string, name string, number int64) (*Revision, error) {
+	revisions, err := List(ctx, c, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range revisions {
+		if revisions[i].Number == number {
+			return &revisions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Record stores a new revision for the owner, unless the latest revision has been recorded for the same digest.
+// The oldest revisions exceeding the history limit are deleted. It returns whether a new revision has been created.
+func Record(ctx context.Context, c ctrl.Client, owner ctrl.Object, kind string, spec interface{}, digest string, kit *corev1.ObjectReference, image string) (bool, error) {
+	items, err := listControllerRevisions(ctx, c, owner.GetNamespace(), kind, owner.GetName())
+	if err != nil {
+		return false, err
+	}
+
+	next := int64(1)
+	if len(items) > 0 {
+		latest := items[len(items)-1]
+		if latest.Annotations[DigestAnnotation] == digest {
+			return false, nil
+		}
+		next = latest.Revision + 1
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return false, err
+	}
+	raw, err := json.Marshal(Revision{
+		Spec:           data,
+		Digest:         digest,
+		IntegrationKit: kit,
+		Image:          image,
+		Timestamp:      metav1.Now(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	cr := appsv1.ControllerRevision{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "ControllerRevision",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: owner.GetNamespace(),
+			Name:      fmt.Sprintf("%s-%s-%d", owner.GetName(), strings.ToLower(kind), next),
+			Labels: map[string]string{
+				KindLabel: kind,
+				NameLabel: owner.GetName(),
+			},
+			Annotations: map[string]string{
+				DigestAnnotation: digest,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: v1.SchemeGroupVersion.String(),
+					Kind:       kind,
+					Name:       owner.GetName(),
+					UID:        owner.GetUID(),
+				},
+			},
+		},
+		Data:     runtime.RawExtension{Raw: raw},
+		Revision: next,
+	}
+	if err := c.Create(ctx, &cr); err != nil {
+		return false, err
+	}
+
+	items = append(items, cr)
+	limit := HistoryLimit(owner)
+	for i := 0; i < len(items)-limit; i++ {
+		if err := c.Delete(ctx, &items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// HistoryLimit returns the number of revisions to keep for the given resource.
+func HistoryLimit(obj metav1.Object) int {
+	if value, ok := obj.GetAnnotations()[HistoryLimitAnnotation]; ok {
+		if limit, err := strconv.Atoi(value); err == nil && limit > 0 {
+			return limit
+		}
+	}
+	return DefaultHistoryLimit
+}
+
+func listControllerRevisions(ctx context.Context, c ctrl.Reader, namespace string, kind string, name string) ([]appsv1.ControllerRevision, error) {
+	list := appsv1.ControllerRevisionList{}
+	if err := c.List(ctx, &list,
+		ctrl.InNamespace(namespace),
+		ctrl.MatchingLabels{
+			KindLabel: kind,
+			NameLabel: name,
+		}); err != nil {
+		return nil, err
+	}
+
+	items := list.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Revision < items[j].Revision
+	})
+
+	return items, nil
+}
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/test"
+)
+
+func TestRecordSkipsSameDigest(t *testing.T) {
+	it := v1.NewIntegration("ns", "my-it")
+	c, err := test.NewFakeClient(&it)
+	require.NoError(t, err)
+
+	kit := &corev1.ObjectReference{Namespace: "ns", Name: "my-kit"}
+	created, err := Record(context.TODO(), c, &it, v1.IntegrationKind, it.Spec, "v1", kit, "my-image:1")
+	require.NoError(t, err)
+	assert.True(t, created)
+	created, err = Record(context.TODO(), c, &it, v1.IntegrationKind, it.Spec, "v1", kit, "my-image:1")
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	revisions, err := List(context.TODO(), c, "ns", v1.IntegrationKind, "my-it")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, int64(1), revisions[0].Number)
+	assert.Equal(t, "v1", revisions[0].Digest)
+	assert.Equal(t, kit, revisions[0].IntegrationKit)
+	assert.Equal(t, "my-image:1", revisions[0].Image)
+
+	// Revisions of a Pipe with the same name are kept apart
+	revisions, err = List(context.TODO(), c, "ns", v1.PipeKind, "my-it")
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
+
+func TestListAll(t *testing.T) {
+	it := v1.NewIntegration("ns", "my-it")
+	pipe := v1.NewPipe("other", "my-pipe")
+	c, err := test.NewFakeClient(&it, &pipe)
+	require.NoError(t, err)
+
+	_, err = Record(context.TODO(), c, &it, v1.IntegrationKind, it.Spec, "v1", nil, "my-image:1")
+	require.NoError(t, err)
+	_, err = Record(context.TODO(), c, &pipe, v1.PipeKind, pipe.Spec, "v1", nil, "my-image:2")
+	require.NoError(t, err)
+
+	revisions, err := ListAll(context.TODO(), c, "")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	revisions, err = ListAll(context.TODO(), c, "other")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, "other", revisions[0].Namespace)
+	assert.Equal(t, "my-image:2", revisions[0].Image)
+}
+
+func TestRecordPrunesHistory(t *testing.T) {
+	it := v1.NewIntegration("ns", "my-it")
+	it.Annotations = map[string]string{
+		HistoryLimitAnnotation: "2",
+	}
+	c, err := test.NewFakeClient(&it)
+	require.NoError(t, err)
+
+	for i := 1; i <= 3; i++ {
+		it.Spec.Dependencies = []string{fmt.Sprintf("camel:dep-%d", i)}
+		_, err := Record(context.TODO(), c, &it, v1.IntegrationKind, it.Spec, fmt.Sprintf("v%d", i), nil, "")
+		require.NoError(t, err)
+	}
+
+	revisions, err := List(context.TODO(), c, "ns", v1.IntegrationKind, "my-it")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, int64(2), revisions[0].Number)
+	assert.Equal(t, int64(3), revisions[1].Number)
+
+	spec := v1.IntegrationSpec{}
+	require.NoError(t, revisions[0].DecodeSpec(&spec))
+	assert.Equal(t, []string{"camel:dep-2"}, spec.Dependencies)
+}
+
+func TestHistoryLimit(t *testing.T) {
+	it := v1.NewIntegration("ns", "my-it")
+	assert.Equal(t, DefaultHistoryLimit, HistoryLimit(&it))
+	it.Annotations = map[string]string{HistoryLimitAnnotation: "5"}
+	assert.Equal(t, 5, HistoryLimit(&it))
+	it.Annotations = map[string]string{HistoryLimitAnnotation: "-1"}
+	assert.Equal(t, DefaultHistoryLimit, HistoryLimit(&it))
+}
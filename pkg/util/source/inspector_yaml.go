@@ -89,6 +89,11 @@ func (i YAMLInspector) parseStep(key string, content interface{}, meta *Metadata
 			AddKamelet(meta, "kamelet:"+t)
 		case map[interface{}]interface{}:
 			if name, ok := t["name"].(string); ok {
+				if params, ok := t["parameters"].(map[interface{}]interface{}); ok {
+					if version, ok := params[v1.KameletVersionProperty]; ok {
+						name = fmt.Sprintf("%s?%s=%v", name, v1.KameletVersionProperty, version)
+					}
+				}
 				AddKamelet(meta, "kamelet:"+name)
 			}
 		}
@@ -449,6 +449,23 @@ const yamlKameletEndpoint = `
     - to: "kamelet:foo/bar?baz=test"
 `
 
+const yamlKameletEndpointWithVersion = `
+- from:
+    uri: "kamelet:foo?kameletVersion=v1"
+    steps:
+    - to: "kamelet:bar/baz?param=test&kameletVersion=2.0.1"
+`
+
+const yamlKameletEipMapWithVersion = `
+- from:
+    uri: timer:tick
+    steps:
+    - kamelet:
+        name: "foo/bar"
+        parameters:
+          kameletVersion: v1
+`
+
 func TestYAMLKamelet(t *testing.T) {
 	tc := []struct {
 		source   string
@@ -474,6 +491,14 @@ func TestYAMLKamelet(t *testing.T) {
 			source:   yamlKameletEndpoint,
 			kamelets: []string{"foo/bar"},
 		},
+		{
+			source:   yamlKameletEndpointWithVersion,
+			kamelets: []string{"foo@v1", "bar/baz@2.0.1"},
+		},
+		{
+			source:   yamlKameletEipMapWithVersion,
+			kamelets: []string{"foo/bar@v1"},
+		},
 	}
 
 	inspector := newTestYAMLInspector(t)
@@ -643,3 +668,26 @@ func TestYAMLRouteWithUnknownScheme(t *testing.T) {
 		})
 	}
 }
+
+const yamlPipeWithInlineSteps = `
+- route:
+    id: binding
+    from:
+      uri: timer:tick
+      steps:
+      - filter:
+          jsonpath: $[?(@.priority > 5)]
+      - marshal:
+          json: {}
+      - to: log:info
+`
+
+func TestYAMLPipeWithInlineSteps(t *testing.T) {
+	inspector := newTestYAMLInspector(t)
+	assertExtractYAML(t, inspector, yamlPipeWithInlineSteps, func(meta *Metadata) {
+		assert.Equal(t, []string{"timer:tick"}, meta.FromURIs)
+		assert.Equal(t, []string{"log:info"}, meta.ToURIs)
+		assert.True(t, meta.Dependencies.Has("camel:jsonpath"))
+		assert.True(t, meta.Dependencies.Has("camel:jackson"))
+	})
+}
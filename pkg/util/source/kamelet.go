@@ -19,9 +19,14 @@ package source
 
 import (
 	"regexp"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 )
 
-var kameletNameRegexp = regexp.MustCompile("kamelet:(?://)?([a-z0-9-.]+(/[a-z0-9-.]+)?)(?:$|[^a-z0-9-.].*)")
+var (
+	kameletNameRegexp    = regexp.MustCompile("kamelet:(?://)?([a-z0-9-.]+(/[a-z0-9-.]+)?)(?:$|[^a-z0-9-.].*)")
+	kameletVersionRegexp = regexp.MustCompile(`^\?(?:[^\s"'()]*&)?` + v1.KameletVersionProperty + `=([A-Za-z0-9._-]+)`)
+)
 
 func ExtractKamelets(uris []string) []string {
 	var kamelets []string
@@ -34,12 +39,18 @@ func ExtractKamelets(uris []string) []string {
 	return kamelets
 }
 
+// ExtractKamelet returns the Kamelet referenced by the given URI, in the format name[/id][@version],
+// where version is the value of the kameletVersion parameter of the URI, if any.
 func ExtractKamelet(uri string) string {
-	matches := kameletNameRegexp.FindStringSubmatch(uri)
-	if len(matches) > 1 {
-		return matches[1]
+	matches := kameletNameRegexp.FindStringSubmatchIndex(uri)
+	if len(matches) < 4 {
+		return ""
+	}
+	kamelet := uri[matches[2]:matches[3]]
+	if version := kameletVersionRegexp.FindStringSubmatch(uri[matches[3]:]); len(version) > 1 {
+		kamelet += "@" + version[1]
 	}
-	return ""
+	return kamelet
 }
 
 func AddKamelet(meta *Metadata, content string) {
@@ -243,5 +243,15 @@ func (f *FakeDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*me
 			GroupVersion: "messaging.knative.dev/v1beta1",
 		}, nil
 	}
+	// used to verify if the Gateway API is installed
+	if groupVersion == "gateway.networking.k8s.io/v1" && !util.StringSliceExists(f.disabledGroups, groupVersion) {
+		return &metav1.APIResourceList{
+			GroupVersion: "gateway.networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{Kind: "HTTPRoute"},
+				{Kind: "GRPCRoute"},
+			},
+		}, nil
+	}
 	return f.DiscoveryInterface.ServerResourcesForGroupVersion(groupVersion)
 }
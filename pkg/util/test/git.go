@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// GitRepository is a local bare git repository, whose main branch is populated through a work tree.
+type GitRepository struct {
+	// URL is the location the repository can be cloned from
+	URL string
+	// Dir is the work tree the commits are pushed from
+	Dir string
+}
+
+// NewGitRepository creates an empty git repository, that is removed when the test completes.
+func NewGitRepository(t *testing.T) *GitRepository {
+	t.Helper()
+
+	root := t.TempDir()
+	repo := &GitRepository{
+		URL: filepath.Join(root, "repo.git"),
+		Dir: filepath.Join(root, "work"),
+	}
+	repo.git(t, root, "init", "--quiet", "--bare", "--initial-branch=main", repo.URL)
+	repo.git(t, root, "init", "--quiet", "--initial-branch=main", repo.Dir)
+	repo.git(t, repo.Dir, "remote", "add", "origin", repo.URL)
+
+	return repo
+}
+
+// Commit writes the given files into the repository, pushes them to the main branch and returns the SHA of the commit.
+func (r *GitRepository) Commit(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(r.Dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	r.git(t, r.Dir, "add", "--all")
+	r.git(t, r.Dir, "commit", "--quiet", "--allow-empty", "--message", "update")
+	r.git(t, r.Dir, "push", "--quiet", "origin", "main")
+
+	return r.git(t, r.Dir, "rev-parse", "HEAD")
+}
+
+// Symlink commits a symbolic link with the given name and target, pushes it to the main branch and returns the SHA
+// of the commit.
+func (r *GitRepository) Symlink(t *testing.T, name string, target string) string {
+	t.Helper()
+
+	path := filepath.Join(r.Dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.Symlink(target, path))
+
+	return r.Commit(t, nil)
+}
+
+// Tag creates an annotated tag on the last commit.
+func (r *GitRepository) Tag(t *testing.T, name string) {
+	t.Helper()
+
+	r.git(t, r.Dir, "tag", "--annotate", "--message", name, name)
+	r.git(t, r.Dir, "push", "--quiet", "origin", name)
+}
+
+func (r *GitRepository) git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@camel.apache.org", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return strings.TrimSpace(string(out))
+}
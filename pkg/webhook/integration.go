@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/trait"
+)
+
+func newIntegrationValidator(catalog *trait.Catalog) *validator[*v1.Integration] {
+	return &validator[*v1.Integration]{
+		kind: v1.IntegrationKind,
+		validate: func(_ context.Context, it *v1.Integration) field.ErrorList {
+			return validateTraits(catalog, it.Spec.Traits, it.Annotations, field.NewPath("spec", "traits"))
+		},
+		validated: func(it *v1.Integration) []interface{} {
+			return []interface{}{it.Spec, it.Annotations}
+		},
+	}
+}
+
+// integrationDefaulter migrates the legacy trait configuration of Integrations.
+type integrationDefaulter struct{}
+
+func (d *integrationDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	it, ok := obj.(*v1.Integration)
+	if !ok {
+		return fmt.Errorf("expected an Integration but got a %T", obj)
+	}
+
+	return trait.MigrateLegacyTraits(&it.Spec.Traits)
+}
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/trait"
+)
+
+func newIntegrationPlatformValidator(catalog *trait.Catalog) *validator[*v1.IntegrationPlatform] {
+	return &validator[*v1.IntegrationPlatform]{
+		kind: v1.IntegrationPlatformKind,
+		validate: func(_ context.Context, platform *v1.IntegrationPlatform) field.ErrorList {
+			return validateTraits(catalog, platform.Spec.Traits, platform.Annotations, field.NewPath("spec", "traits"))
+		},
+		validated: func(platform *v1.IntegrationPlatform) []interface{} {
+			return []interface{}{platform.Spec, platform.Annotations}
+		},
+	}
+}
+
+// integrationPlatformDefaulter migrates the legacy trait configuration of IntegrationPlatforms.
+type integrationPlatformDefaulter struct{}
+
+func (d *integrationPlatformDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	platform, ok := obj.(*v1.IntegrationPlatform)
+	if !ok {
+		return fmt.Errorf("expected an IntegrationPlatform but got a %T", obj)
+	}
+
+	return trait.MigrateLegacyTraits(&platform.Spec.Traits)
+}
+
+func newIntegrationProfileValidator(catalog *trait.Catalog) *validator[*v1.IntegrationProfile] {
+	return &validator[*v1.IntegrationProfile]{
+		kind: v1.IntegrationProfileKind,
+		validate: func(_ context.Context, profile *v1.IntegrationProfile) field.ErrorList {
+			return validateTraits(catalog, profile.Spec.Traits, nil, field.NewPath("spec", "traits"))
+		},
+		validated: func(profile *v1.IntegrationProfile) []interface{} {
+			return []interface{}{profile.Spec}
+		},
+	}
+}
+
+// integrationProfileDefaulter migrates the legacy trait configuration of IntegrationProfiles.
+type integrationProfileDefaulter struct{}
+
+func (d *integrationProfileDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	profile, ok := obj.(*v1.IntegrationProfile)
+	if !ok {
+		return fmt.Errorf("expected an IntegrationProfile but got a %T", obj)
+	}
+
+	return trait.MigrateLegacyTraits(&profile.Spec.Traits)
+}
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/kamelets"
+)
+
+func newKameletValidator() *validator[*v1.Kamelet] {
+	return &validator[*v1.Kamelet]{
+		kind: v1.KameletKind,
+		validate: func(_ context.Context, kamelet *v1.Kamelet) field.ErrorList {
+			if err := kamelets.ValidateKamelet(kamelet); err != nil {
+				return field.ErrorList{field.Invalid(field.NewPath("spec"), field.OmitValueType{}, err.Error())}
+			}
+			return nil
+		},
+		validated: func(kamelet *v1.Kamelet) []interface{} {
+			return []interface{}{kamelet.Spec}
+		},
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/trait"
+	"github.com/apache/camel-k/v2/pkg/util/bindings"
+)
+
+func newPipeValidator(catalog *trait.Catalog) *validator[*v1.Pipe] {
+	return &validator[*v1.Pipe]{
+		kind: v1.PipeKind,
+		validate: func(ctx context.Context, pipe *v1.Pipe) field.ErrorList {
+			var errs field.ErrorList
+
+			bindingContext := bindings.BindingContext{
+				Ctx:       ctx,
+				Namespace: pipe.Namespace,
+			}
+			validateEndpoint := func(path *field.Path, endpointType v1.EndpointType, endpoint v1.Endpoint) {
+				err := bindings.ValidateEndpoint(bindingContext, endpoint)
+				if err == nil {
+					err = bindings.ValidateEndpointType(endpointType, endpoint)
+				}
+				if err != nil {
+					errs = append(errs, field.Invalid(path, field.OmitValueType{}, err.Error()))
+				}
+			}
+			specPath := field.NewPath("spec")
+			validateEndpoint(specPath.Child("source"), v1.EndpointTypeSource, pipe.Spec.Source)
+			for i, step := range pipe.Spec.Steps {
+				validateEndpoint(specPath.Child("steps").Index(i), v1.EndpointTypeAction, step)
+			}
+			// the sink is optional when the data is routed to branches
+			if pipe.Spec.Routing == nil || !pipe.Spec.Sink.IsEmpty() {
+				validateEndpoint(specPath.Child("sink"), v1.EndpointTypeSink, pipe.Spec.Sink)
+			}
+			if pipe.Spec.Routing != nil {
+				branchesPath := specPath.Child("routing", "branches")
+				if len(pipe.Spec.Routing.Branches) == 0 {
+					errs = append(errs, field.Required(branchesPath, "at least one branch is required"))
+				}
+				for i, branch := range pipe.Spec.Routing.Branches {
+					if err := bindings.ValidateBranch(pipe.Spec.Routing.Type, branch); err != nil {
+						errs = append(errs, field.Invalid(branchesPath.Index(i).Child("condition"), field.OmitValueType{}, err.Error()))
+					}
+					for j, step := range branch.Steps {
+						validateEndpoint(branchesPath.Index(i).Child("steps").Index(j), v1.EndpointTypeAction, step)
+					}
+					validateEndpoint(branchesPath.Index(i).Child("sink"), v1.EndpointTypeSink, branch.Sink)
+				}
+			}
+
+			var traits v1.Traits
+			if pipe.Spec.Integration != nil {
+				traits = pipe.Spec.Integration.Traits
+			}
+			errs = append(errs, validateTraits(catalog, traits, pipe.Annotations, specPath.Child("integration", "traits"))...)
+
+			return errs
+		},
+		validated: func(pipe *v1.Pipe) []interface{} {
+			return []interface{}{pipe.Spec, pipe.Annotations}
+		},
+	}
+}
+
+// pipeDefaulter migrates the legacy trait configuration of Pipes.
+type pipeDefaulter struct{}
+
+func (d *pipeDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	pipe, ok := obj.(*v1.Pipe)
+	if !ok {
+		return fmt.Errorf("expected a Pipe but got a %T", obj)
+	}
+	if pipe.Spec.Integration == nil {
+		return nil
+	}
+
+	return trait.MigrateLegacyTraits(&pipe.Spec.Integration.Traits)
+}
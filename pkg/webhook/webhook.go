@@ -0,0 +1,154 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook contains the admission webhooks served by the operator for the Camel K resources.
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/trait"
+)
+
+// AddToManager registers the validating and defaulting webhooks of the Camel K resources
+// to the webhook server of the Manager.
+func AddToManager(mgr ctrl.Manager, c client.Client) error {
+	catalog := trait.NewCatalog(c)
+
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1.Integration{}).
+		WithDefaulter(&integrationDefaulter{}).
+		WithValidator(newIntegrationValidator(catalog)).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1.Pipe{}).
+		WithDefaulter(&pipeDefaulter{}).
+		WithValidator(newPipeValidator(catalog)).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1.Kamelet{}).
+		WithValidator(newKameletValidator()).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1.IntegrationPlatform{}).
+		WithDefaulter(&integrationPlatformDefaulter{}).
+		WithValidator(newIntegrationPlatformValidator(catalog)).
+		Complete(); err != nil {
+		return err
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1.IntegrationProfile{}).
+		WithDefaulter(&integrationProfileDefaulter{}).
+		WithValidator(newIntegrationProfileValidator(catalog)).
+		Complete()
+}
+
+// validator adapts a validation function to the admission.CustomValidator interface.
+type validator[T ctrlclient.Object] struct {
+	kind     string
+	validate func(ctx context.Context, obj T) field.ErrorList
+	// validated returns the parts of the resource that are validated, so that the updates
+	// that don't change them are admitted, e.g., when the operator adds a finalizer.
+	validated func(obj T) []interface{}
+}
+
+func (v *validator[T]) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	o, err := v.cast(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, v.toError(o, v.validate(ctx, o))
+}
+
+func (v *validator[T]) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, err := v.cast(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	n, err := v.cast(newObj)
+	if err != nil {
+		return nil, err
+	}
+	if n.GetDeletionTimestamp() != nil || equality.Semantic.DeepEqual(v.validated(o), v.validated(n)) {
+		return nil, nil
+	}
+
+	return nil, v.toError(n, v.validate(ctx, n))
+}
+
+func (v *validator[T]) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *validator[T]) cast(obj runtime.Object) (T, error) {
+	o, ok := obj.(T)
+	if !ok {
+		return o, fmt.Errorf("expected a %s but got a %T", v.kind, obj)
+	}
+	return o, nil
+}
+
+func (v *validator[T]) toError(obj T, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	kind := schema.GroupKind{Group: v1.SchemeGroupVersion.Group, Kind: v.kind}
+	return k8serrors.NewInvalid(kind, obj.GetName(), errs)
+}
+
+// validateTraits checks the traits only refer to traits and properties known to the catalog,
+// and that the cron trait schedule, if any, is valid.
+func validateTraits(catalog *trait.Catalog, traits v1.Traits, annotations map[string]string, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if err := catalog.ValidateTraits(traits, annotations); err != nil {
+		errs = append(errs, field.Invalid(path, field.OmitValueType{}, err.Error()))
+	}
+	if traits.Cron != nil && traits.Cron.Schedule != "" {
+		if err := validateCronSchedule(traits.Cron.Schedule); err != nil {
+			errs = append(errs, field.Invalid(path.Child("cron", "schedule"), traits.Cron.Schedule, err.Error()))
+		}
+	}
+	if schedule, ok := annotations[v1.TraitAnnotationPrefix+"cron.schedule"]; ok {
+		if err := validateCronSchedule(schedule); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("metadata", "annotations").Key(v1.TraitAnnotationPrefix+"cron.schedule"), schedule, err.Error()))
+		}
+	}
+
+	return errs
+}
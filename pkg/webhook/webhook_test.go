@@ -0,0 +1,301 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
+	"github.com/apache/camel-k/v2/pkg/trait"
+)
+
+func TestValidateIntegration(t *testing.T) {
+	validator := newIntegrationValidator(trait.NewCatalog(nil))
+
+	it := v1.NewIntegration("default", "my-it")
+	it.Spec.Traits.Cron = &traitv1.CronTrait{
+		Schedule: "*/5 * * * *",
+	}
+	it.Annotations = map[string]string{
+		v1.TraitAnnotationPrefix + "container.port": "8081",
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), &it)
+	require.NoError(t, err)
+}
+
+func TestValidateIntegrationWithUnknownTrait(t *testing.T) {
+	validator := newIntegrationValidator(trait.NewCatalog(nil))
+
+	it := v1.NewIntegration("default", "my-it")
+	it.Spec.Traits.Addons = map[string]v1.AddonTrait{
+		"unknown": {RawMessage: v1.RawMessage(`{"enabled":true}`)},
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), &it)
+	require.Error(t, err)
+	assert.True(t, k8serrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.traits")
+	assert.Contains(t, err.Error(), "trait unknown does not exist in catalog")
+}
+
+func TestValidateIntegrationWithUnknownTraitProperty(t *testing.T) {
+	validator := newIntegrationValidator(trait.NewCatalog(nil))
+
+	it := v1.NewIntegration("default", "my-it")
+	it.Spec.Traits.Container = &traitv1.ContainerTrait{
+		PlatformBaseTrait: traitv1.PlatformBaseTrait{
+			Configuration: &traitv1.Configuration{RawMessage: traitv1.RawMessage(`{"prot":8081}`)},
+		},
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), &it)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid configuration for trait "container"`)
+	assert.Contains(t, err.Error(), `unknown field "prot"`)
+}
+
+func TestValidateIntegrationWithUnknownTraitAnnotation(t *testing.T) {
+	validator := newIntegrationValidator(trait.NewCatalog(nil))
+
+	it := v1.NewIntegration("default", "my-it")
+	it.Annotations = map[string]string{
+		v1.TraitAnnotationPrefix + "container.prot": "8081",
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), &it)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid configuration for trait "container"`)
+}
+
+func TestValidateIntegrationWithInvalidCronSchedule(t *testing.T) {
+	validator := newIntegrationValidator(trait.NewCatalog(nil))
+
+	it := v1.NewIntegration("default", "my-it")
+	it.Spec.Traits.Cron = &traitv1.CronTrait{
+		Schedule: "every 5 minutes",
+	}
+	it.Annotations = map[string]string{
+		v1.TraitAnnotationPrefix + "cron.schedule": "*/5 * * *",
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), &it)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.traits.cron.schedule")
+	assert.Contains(t, err.Error(), "metadata.annotations[trait.camel.apache.org/cron.schedule]")
+}
+
+func TestValidateIntegrationUpdate(t *testing.T) {
+	validator := newIntegrationValidator(trait.NewCatalog(nil))
+
+	oldIt := v1.NewIntegration("default", "my-it")
+	oldIt.Spec.Traits.Cron = &traitv1.CronTrait{
+		Schedule: "every 5 minutes",
+	}
+	newIt := oldIt.DeepCopy()
+	newIt.Finalizers = []string{"camel.apache.org/finalizer"}
+	newIt.Status.Phase = v1.IntegrationPhaseError
+
+	// Updates that don't change the validated fields are admitted
+	_, err := validator.ValidateUpdate(context.TODO(), &oldIt, newIt)
+	require.NoError(t, err)
+
+	newIt.Spec.Replicas = pointer.Int32(2)
+	_, err = validator.ValidateUpdate(context.TODO(), &oldIt, newIt)
+	require.Error(t, err)
+}
+
+func TestValidatePipe(t *testing.T) {
+	validator := newPipeValidator(trait.NewCatalog(nil))
+
+	pipe := v1.NewPipe("default", "my-pipe")
+	pipe.Spec.Source = v1.Endpoint{
+		Ref: &corev1.ObjectReference{
+			Kind:       v1.KameletKind,
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Name:       "timer-source",
+		},
+	}
+	pipe.Spec.Sink = v1.Endpoint{
+		URI: pointer.String("log:info"),
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), &pipe)
+	require.NoError(t, err)
+}
+
+func TestValidatePipeWithInvalidEndpoints(t *testing.T) {
+	validator := newPipeValidator(trait.NewCatalog(nil))
+
+	pipe := v1.NewPipe("default", "my-pipe")
+	pipe.Spec.Source = v1.Endpoint{
+		Ref: &corev1.ObjectReference{
+			Kind:      v1.KameletKind,
+			Namespace: "other",
+			Name:      "timer-source",
+		},
+	}
+	pipe.Spec.Steps = []v1.Endpoint{
+		{
+			Ref: &corev1.ObjectReference{Kind: v1.KameletKind, Name: "insert-header-action"},
+			URI: pointer.String("log:info"),
+		},
+	}
+	pipe.Spec.Integration = &v1.IntegrationSpec{
+		Traits: v1.Traits{
+			Addons: map[string]v1.AddonTrait{
+				"unknown": {RawMessage: v1.RawMessage(`{}`)},
+			},
+		},
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), &pipe)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.source: Invalid value: cross-namespace references are not allowed in Pipe")
+	assert.Contains(t, err.Error(), "spec.steps[0]: Invalid value: cannot use both ref and URI")
+	assert.Contains(t, err.Error(), "spec.sink: Invalid value: no ref or URI specified in endpoint")
+	assert.Contains(t, err.Error(), "spec.integration.traits")
+}
+
+func TestValidatePipeWithInlineSteps(t *testing.T) {
+	validator := newPipeValidator(trait.NewCatalog(nil))
+
+	pipe := v1.NewPipe("default", "my-pipe")
+	pipe.Spec.Source = v1.Endpoint{
+		URI: pointer.String("timer:tick"),
+	}
+	pipe.Spec.Steps = []v1.Endpoint{
+		{
+			Inline: &v1.Flow{RawMessage: v1.RawMessage(`{"setBody": {"constant": "Hello"}}`)},
+		},
+	}
+	pipe.Spec.Sink = v1.Endpoint{
+		Inline: &v1.Flow{RawMessage: v1.RawMessage(`{"log": "${body}"}`)},
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), &pipe)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "spec.steps[0]")
+	assert.Contains(t, err.Error(), "spec.sink: Invalid value: inline steps cannot be used as sink endpoint")
+
+	pipe.Spec.Sink = v1.Endpoint{
+		URI: pointer.String("log:info"),
+	}
+	_, err = validator.ValidateCreate(context.TODO(), &pipe)
+	require.NoError(t, err)
+}
+
+func TestValidatePipeWithInvalidRouting(t *testing.T) {
+	validator := newPipeValidator(trait.NewCatalog(nil))
+
+	pipe := v1.NewPipe("default", "my-pipe")
+	pipe.Spec.Source = v1.Endpoint{
+		URI: pointer.String("timer:tick"),
+	}
+	pipe.Spec.Routing = &v1.PipeRouting{
+		Type: v1.PipeRoutingTypeChoice,
+		Branches: []v1.PipeBranch{
+			{
+				Condition: &v1.PipePredicate{Expression: "${header.type} == 'order'"},
+				Sink:      v1.Endpoint{URI: pointer.String("log:orders")},
+			},
+			{
+				Steps: []v1.Endpoint{{}},
+				Sink:  v1.Endpoint{URI: pointer.String("log:others")},
+			},
+		},
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), &pipe)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "spec.sink")
+	assert.NotContains(t, err.Error(), "spec.routing.branches[0]")
+	assert.Contains(t, err.Error(), "spec.routing.branches[1].condition: Invalid value: a condition is required by the Choice routing")
+	assert.Contains(t, err.Error(), "spec.routing.branches[1].steps[0]: Invalid value: no ref or URI specified in endpoint")
+
+	pipe.Spec.Routing.Branches[1].Condition = &v1.PipePredicate{Expression: "${header.type} == 'invoice'"}
+	pipe.Spec.Routing.Branches[1].Steps = nil
+	_, err = validator.ValidateCreate(context.TODO(), &pipe)
+	require.NoError(t, err)
+}
+
+func TestValidateKamelet(t *testing.T) {
+	validator := newKameletValidator()
+
+	kamelet := v1.NewKamelet("default", "my-source")
+	kamelet.Spec.Definition = &v1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"period"},
+	}
+
+	_, err := validator.ValidateCreate(context.TODO(), &kamelet)
+	require.Error(t, err)
+	assert.True(t, k8serrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), `invalid definition: required property "period" is not declared`)
+
+	kamelet.Spec.Definition.Properties = map[string]v1.JSONSchemaProp{
+		"period": {Type: "integer"},
+	}
+	_, err = validator.ValidateCreate(context.TODO(), &kamelet)
+	require.NoError(t, err)
+}
+
+func TestValidateWithUnexpectedType(t *testing.T) {
+	validator := newKameletValidator()
+
+	pipe := v1.NewPipe("default", "my-pipe")
+	_, err := validator.ValidateCreate(context.TODO(), &pipe)
+	require.Error(t, err)
+	assert.Equal(t, "expected a Kamelet but got a *v1.Pipe", err.Error())
+}
+
+func TestDefaultIntegrationMigratesLegacyTraits(t *testing.T) {
+	defaulter := integrationDefaulter{}
+
+	it := v1.NewIntegration("default", "my-it")
+	it.Spec.Traits.Container = &traitv1.ContainerTrait{
+		PlatformBaseTrait: traitv1.PlatformBaseTrait{
+			Configuration: &traitv1.Configuration{RawMessage: traitv1.RawMessage(`{"port":8081,"name":"my-container"}`)},
+		},
+		Name: "integration",
+	}
+
+	require.NoError(t, defaulter.Default(context.TODO(), &it))
+	require.NotNil(t, it.Spec.Traits.Container)
+	assert.Nil(t, it.Spec.Traits.Container.Configuration)
+	assert.Equal(t, 8081, it.Spec.Traits.Container.Port)
+	// The properties that are already set take precedence
+	assert.Equal(t, "integration", it.Spec.Traits.Container.Name)
+}
+
+func TestDefaultPipeWithoutIntegration(t *testing.T) {
+	defaulter := pipeDefaulter{}
+
+	pipe := v1.NewPipe("default", "my-pipe")
+	require.NoError(t, defaulter.Default(context.TODO(), &pipe))
+	assert.Nil(t, pipe.Spec.Integration)
+}